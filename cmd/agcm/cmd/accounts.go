@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
 	"text/tabwriter"
 	"time"
 
@@ -27,66 +28,86 @@ account, use:
 
 func init() {
 	listCmd.AddCommand(listAccountsCmd)
+	supportsOutput(listAccountsCmd)
+}
+
+// account is an account found on the cases the user can view
+type account struct {
+	Number string `json:"accountNumber"`
+	Name   string `json:"accountName"`
 }
 
 func runListAccounts(cmd *cobra.Command, args []string) error {
 	client := GetAPIClient()
+	p, err := newPrinter()
+	if err != nil {
+		return err
+	}
+	chatty := p.table() && !noHeaders
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
 
 	// Get all cases to extract unique account numbers
 	// This is a workaround since there's no direct accounts API
-	// Paginate through all results
 	accounts := make(map[string]string) // number -> name
-	startIndex := 0
-	pageSize := 100
 
-	fmt.Println("Fetching accounts from cases...")
-
-	for {
-		filter := &api.CaseFilter{
-			StartIndex:    startIndex,
-			Count:         pageSize,
-			IncludeClosed: true, // Include all cases to get all accounts
-		}
-		result, err := client.ListCases(ctx, filter)
-		if err != nil {
-			return fmt.Errorf("failed to list cases: %w", err)
-		}
+	if chatty {
+		fmt.Println("Fetching accounts from cases...")
+	}
 
-		for _, c := range result.Items {
+	// Include all cases to get all accounts
+	it := client.IterateCases(ctx, &api.CaseFilter{IncludeClosed: true})
+	for it.Next() {
+		for _, c := range it.Page() {
 			if c.AccountNumber != "" {
 				accounts[c.AccountNumber] = c.AccountName
 			}
 		}
+	}
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("failed to list cases: %w", err)
+	}
 
-		// Check if we've fetched all cases
-		if len(result.Items) < pageSize || startIndex+len(result.Items) >= result.TotalCount {
-			break
+	list := make([]account, 0, len(accounts))
+	for num, name := range accounts {
+		list = append(list, account{Number: num, Name: name})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Number < list[j].Number })
+
+	if !p.table() {
+		rows := make([][]string, len(list))
+		for i, a := range list {
+			rows[i] = []string{a.Number, a.Name}
 		}
-		startIndex += len(result.Items)
+		return p.print(list, []string{"account_number", "account_name"}, rows)
 	}
 
-	if len(accounts) == 0 {
-		fmt.Println("No accounts found in accessible cases.")
-		fmt.Println("\nTo view cases for a specific account, use:")
-		fmt.Println("  agcm list cases --account <account-number>")
+	if len(list) == 0 {
+		if chatty {
+			fmt.Println("No accounts found in accessible cases.")
+			fmt.Println("\nTo view cases for a specific account, use:")
+			fmt.Println("  agcm list cases --account <account-number>")
+		}
 		return nil
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	_, _ = fmt.Fprintln(w, "ACCOUNT NUMBER\tACCOUNT NAME")
-	_, _ = fmt.Fprintln(w, "--------------\t------------")
+	if !noHeaders {
+		_, _ = fmt.Fprintln(w, "ACCOUNT NUMBER\tACCOUNT NAME")
+		_, _ = fmt.Fprintln(w, "--------------\t------------")
+	}
 
-	for num, name := range accounts {
-		_, _ = fmt.Fprintf(w, "%s\t%s\n", num, name)
+	for _, a := range list {
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", a.Number, a.Name)
 	}
 	_ = w.Flush()
 
-	fmt.Printf("\nFound %d account(s)\n", len(accounts))
-	fmt.Println("\nTo view cases for a specific account:")
-	fmt.Println("  agcm list cases --account <account-number>")
+	if chatty {
+		fmt.Printf("\nFound %d account(s)\n", len(list))
+		fmt.Println("\nTo view cases for a specific account:")
+		fmt.Println("  agcm list cases --account <account-number>")
+	}
 
 	return nil
 }
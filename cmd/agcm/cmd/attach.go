@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/green/agcm/internal/api"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var attachCmd = &cobra.Command{
+	Use:   "attach [case-number] [file...]",
+	Short: "Upload attachments to a case",
+	Long: `Upload one or more files to a support case.
+
+Files are streamed to the portal, so large sosreports and must-gathers
+are never loaded into memory. Uploads are not resumable: a failed upload
+starts again from the beginning. Files already attached to the case with
+the same name and size are skipped, so re-running an interrupted batch only
+uploads the files that are missing. Use --force to upload them again.
+
+Examples:
+  agcm attach 01234567 sosreport-host1.tar.xz
+  agcm attach 01234567 must-gather.tar.gz logs/*.txt`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runAttach,
+}
+
+var attachForce bool
+
+func init() {
+	rootCmd.AddCommand(attachCmd)
+
+	attachCmd.Flags().BoolVar(&attachForce, "force", false, "upload files even if already attached")
+}
+
+func runAttach(cmd *cobra.Command, args []string) error {
+	client := GetAPIClient()
+	caseNumber := args[0]
+	files := args[1:]
+
+	// Validate all files before starting any upload
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", f, err)
+		}
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("%s is not a regular file", f)
+		}
+	}
+
+	// Uploads can take a long time; allow Ctrl+C to cancel cleanly
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	existing := make(map[string]int64)
+	if !attachForce {
+		lookupCtx, lookupCancel := context.WithTimeout(ctx, 30*time.Second)
+		attachments, err := client.GetCaseAttachments(lookupCtx, caseNumber)
+		lookupCancel()
+		if err != nil {
+			return fmt.Errorf("failed to get attachments: %w", err)
+		}
+		for _, a := range attachments {
+			existing[a.Filename] = a.GetSize()
+		}
+	}
+
+	interactive := term.IsTerminal(int(os.Stderr.Fd()))
+	uploaded, skipped := 0, 0
+
+	for _, f := range files {
+		info, _ := os.Stat(f)
+		name := filepath.Base(f)
+
+		if size, ok := existing[name]; ok && size == info.Size() {
+			fmt.Printf("Skipping %s (already attached)\n", name)
+			skipped++
+			continue
+		}
+
+		start := time.Now()
+		var progress api.UploadProgress
+		if interactive {
+			lastPct := -1
+			progress = func(sent, total int64) {
+				// Redraw only when the percentage changes
+				pct := 100
+				if total > 0 {
+					pct = int(sent * 100 / total)
+				}
+				if pct != lastPct {
+					lastPct = pct
+					_, _ = fmt.Fprintf(os.Stderr, "\r%s", renderUploadProgress(name, sent, total))
+				}
+			}
+		}
+
+		_, err := client.UploadAttachment(ctx, caseNumber, f, progress)
+		if interactive {
+			_, _ = fmt.Fprint(os.Stderr, "\r\033[K")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to upload %s: %w", name, err)
+		}
+
+		fmt.Printf("Uploaded %s (%s in %s)\n", name, api.FormatSize(info.Size()), time.Since(start).Round(time.Second))
+		uploaded++
+	}
+
+	fmt.Printf("Case %s: %d uploaded, %d skipped\n", caseNumber, uploaded, skipped)
+	return nil
+}
+
+// renderUploadProgress renders a single-line progress bar for a file upload
+func renderUploadProgress(name string, sent, total int64) string {
+	const width = 30
+	frac := 1.0
+	if total > 0 {
+		frac = float64(sent) / float64(total)
+	}
+	filled := int(frac * width)
+	if filled > width {
+		filled = width
+	}
+	if len(name) > 30 {
+		name = name[:27] + "..."
+	}
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+	return fmt.Sprintf("%-30s %s %3d%% %s/%s", name, bar, int(frac*100), api.FormatSize(sent), api.FormatSize(total))
+}
@@ -5,6 +5,7 @@ package cmd
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -13,6 +14,12 @@ import (
 	"github.com/green/agcm/internal/auth"
 	"github.com/green/agcm/internal/config"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	loginServiceAccount  bool
+	loginCredentialsFile string
 )
 
 var authCmd = &cobra.Command{
@@ -29,7 +36,16 @@ var loginCmd = &cobra.Command{
 To generate an offline token:
 1. Go to https://access.redhat.com/management/api
 2. Click "Generate Token"
-3. Copy the token and paste it when prompted`,
+3. Copy the token and paste it when prompted
+
+For automation, log in with a Red Hat service account instead:
+
+  agcm auth login --service-account
+
+The client ID and secret are read from --credentials-file (JSON with
+client_id and client_secret), from AGCM_CLIENT_ID and AGCM_CLIENT_SECRET,
+or prompted for. In CI, setting those variables (or AGCM_CREDENTIALS_FILE)
+is enough; nothing needs to be stored.`,
 	RunE: runLogin,
 }
 
@@ -40,6 +56,18 @@ var logoutCmd = &cobra.Command{
 	RunE:  runLogout,
 }
 
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Encrypt credentials stored in plaintext",
+	Long: `Move credentials that older versions stored in plaintext files into the
+system keyring, or into the passphrase-encrypted store when no keyring is
+available. Every profile is migrated.
+
+The passphrase is read from AGCM_PASSPHRASE or prompted for.`,
+	Args: cobra.NoArgs,
+	RunE: runMigrate,
+}
+
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Check authentication status",
@@ -52,24 +80,39 @@ func init() {
 	authCmd.AddCommand(loginCmd)
 	authCmd.AddCommand(logoutCmd)
 	authCmd.AddCommand(statusCmd)
+	authCmd.AddCommand(migrateCmd)
+
+	loginCmd.Flags().BoolVar(&loginServiceAccount, "service-account", false, "log in with service account client credentials")
+	loginCmd.Flags().StringVar(&loginCredentialsFile, "credentials-file", "", "JSON file with client_id and client_secret (implies --service-account)")
 }
 
 func runLogin(cmd *cobra.Command, args []string) error {
-	// Get config directory
-	cfgDir := GetConfigDir()
-	if cfgDir == "" {
-		var err error
-		cfgDir, err = config.DefaultConfigDir()
-		if err != nil {
-			return fmt.Errorf("failed to get config directory: %w", err)
-		}
+	cfgDir, cm, err := authConfig()
+	if err != nil {
+		return err
 	}
+	profile := selectedProfile(cm)
+	storage := openStorage(cfgDir, profile)
 
-	storage := auth.NewStorage(cfgDir)
+	// Log in against the profile's SSO settings; a new profile is only
+	// saved once its credentials are
+	newProfile := profile != config.DefaultProfile && cm.Get().Profiles[profile] == nil
+	cm.AddProfile(profile)
+	if err := cm.UseProfile(profile); err != nil {
+		return err
+	}
 
 	fmt.Println("Red Hat Support Portal Authentication Setup")
 	fmt.Println("==========================================")
 	fmt.Println()
+	if profile != config.DefaultProfile {
+		fmt.Printf("Profile: %s\n\n", profile)
+	}
+
+	if loginServiceAccount || loginCredentialsFile != "" {
+		return runServiceAccountLogin(cm, storage, newProfile)
+	}
+
 	fmt.Println("To authenticate, you need an offline token from Red Hat.")
 	fmt.Println()
 	fmt.Println("1. Go to: https://access.redhat.com/management/api")
@@ -95,45 +138,140 @@ func runLogin(cmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	if err := auth.ValidateOfflineToken(ctx, token); err != nil {
+	if err := auth.ValidateOfflineToken(ctx, token, tokenOptions(cm)...); err != nil {
 		fmt.Println("FAILED")
+		if errors.Is(err, auth.ErrLoginRequired) {
+			return fmt.Errorf("token validation failed: Red Hat SSO rejected the token; generate a new one and try again")
+		}
 		return fmt.Errorf("token validation failed: %w", err)
 	}
 	fmt.Println("OK")
 
-	// Save the token
+	// Save the token, replacing any service account so the token is used
 	fmt.Print("Saving token... ")
 	if err := storage.SaveToken(token); err != nil {
 		fmt.Println("FAILED")
 		return fmt.Errorf("failed to save token: %w", err)
 	}
+	_ = storage.DeleteServiceAccount()
+	if err := saveNewProfile(cm, newProfile); err != nil {
+		fmt.Println("FAILED")
+		return err
+	}
 	fmt.Println("OK")
 
 	fmt.Println()
 	fmt.Println("Authentication configured successfully!")
-	if storage.UsingKeyring() {
-		fmt.Println("Token stored securely in system keyring.")
-	} else {
-		fmt.Println("Token stored in config file (keyring unavailable).")
-	}
+	fmt.Printf("Token stored %s.\n", storedIn(storage.Backend()))
 	fmt.Println("You can now use agcm to access the Red Hat Customer Portal.")
 
 	return nil
 }
 
-func runLogout(cmd *cobra.Command, args []string) error {
-	cfgDir := GetConfigDir()
-	if cfgDir == "" {
-		var err error
-		cfgDir, err = config.DefaultConfigDir()
+// runServiceAccountLogin validates and stores service account credentials
+func runServiceAccountLogin(cm *config.Manager, storage *auth.Storage, newProfile bool) error {
+	sa, err := readServiceAccount()
+	if err != nil {
+		return err
+	}
+
+	fmt.Print("Validating service account... ")
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := auth.ValidateServiceAccount(ctx, sa, tokenOptions(cm)...); err != nil {
+		fmt.Println("FAILED")
+		if errors.Is(err, auth.ErrLoginRequired) {
+			return fmt.Errorf("service account validation failed: Red Hat SSO rejected the client ID or secret")
+		}
+		return fmt.Errorf("service account validation failed: %w", err)
+	}
+	fmt.Println("OK")
+
+	// Save the credentials, replacing any offline token
+	fmt.Print("Saving credentials... ")
+	if err := storage.SaveServiceAccount(sa); err != nil {
+		fmt.Println("FAILED")
+		return fmt.Errorf("failed to save credentials: %w", err)
+	}
+	_ = storage.DeleteToken()
+	if err := saveNewProfile(cm, newProfile); err != nil {
+		fmt.Println("FAILED")
+		return err
+	}
+	fmt.Println("OK")
+
+	fmt.Println()
+	fmt.Printf("Service account %s configured successfully!\n", sa.ClientID)
+	fmt.Printf("Credentials stored %s.\n", storedIn(storage.Backend()))
+
+	return nil
+}
+
+// readServiceAccount gets service account credentials from
+// --credentials-file, the environment, or the terminal
+func readServiceAccount() (*auth.ServiceAccount, error) {
+	if loginCredentialsFile != "" {
+		return auth.LoadServiceAccountFile(loginCredentialsFile)
+	}
+	if sa, err := auth.ServiceAccountFromEnv(); sa != nil || err != nil {
+		if sa != nil {
+			fmt.Printf("Using service account %s from the environment.\n", sa.ClientID)
+		}
+		return sa, err
+	}
+
+	fmt.Println("To authenticate, you need a Red Hat service account.")
+	fmt.Println()
+	fmt.Println("1. Go to: https://console.redhat.com/iam/service-accounts")
+	fmt.Println("2. Create a service account")
+	fmt.Println("3. Copy its client ID and secret")
+	fmt.Println()
+
+	reader := bufio.NewReader(os.Stdin)
+	fmt.Print("Client ID: ")
+	id, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client ID: %w", err)
+	}
+
+	fmt.Print("Client secret: ")
+	var secret string
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		b, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Println()
 		if err != nil {
-			return fmt.Errorf("failed to get config directory: %w", err)
+			return nil, fmt.Errorf("failed to read client secret: %w", err)
 		}
+		secret = string(b)
+	} else if secret, err = reader.ReadString('\n'); err != nil {
+		return nil, fmt.Errorf("failed to read client secret: %w", err)
 	}
 
-	storage := auth.NewStorage(cfgDir)
+	sa := &auth.ServiceAccount{ClientID: strings.TrimSpace(id), ClientSecret: strings.TrimSpace(secret)}
+	if sa.ClientID == "" || sa.ClientSecret == "" {
+		return nil, fmt.Errorf("client ID and secret cannot be empty")
+	}
+	return sa, nil
+}
+
+// saveNewProfile records a profile created by logging in to it
+func saveNewProfile(cm *config.Manager, newProfile bool) error {
+	if !newProfile {
+		return nil
+	}
+	return cm.Save()
+}
+
+func runLogout(cmd *cobra.Command, args []string) error {
+	cfgDir, cm, err := authConfig()
+	if err != nil {
+		return err
+	}
+	profile := selectedProfile(cm)
+	storage := openStorage(cfgDir, profile)
 
-	if !storage.HasToken() {
+	if !storage.HasCredentials() {
 		fmt.Println("No stored credentials found.")
 		return nil
 	}
@@ -141,58 +279,221 @@ func runLogout(cmd *cobra.Command, args []string) error {
 	if err := storage.DeleteToken(); err != nil {
 		return fmt.Errorf("failed to remove credentials: %w", err)
 	}
+	if err := storage.DeleteServiceAccount(); err != nil {
+		return fmt.Errorf("failed to remove credentials: %w", err)
+	}
 
 	fmt.Println("Credentials removed successfully.")
 	return nil
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
-	cfgDir := GetConfigDir()
-	if cfgDir == "" {
-		var err error
-		cfgDir, err = config.DefaultConfigDir()
-		if err != nil {
-			return fmt.Errorf("failed to get config directory: %w", err)
-		}
+	cfgDir, cm, err := authConfig()
+	if err != nil {
+		return err
 	}
+	profile := selectedProfile(cm)
+	_ = cm.UseProfile(profile)
+	storage := openStorage(cfgDir, profile)
 
-	storage := auth.NewStorage(cfgDir)
-
-	if !storage.HasToken() {
+	creds, err := loadCredentials(storage)
+	if err != nil {
+		fmt.Println("Status: Error reading stored credentials")
+		return err
+	}
+	if creds == nil {
 		fmt.Println("Status: Not authenticated")
 		fmt.Println()
-		fmt.Println("Run 'agcm auth login' to configure authentication.")
+		fmt.Printf("Run '%s' to configure authentication.\n", loginHint(profile))
 		return nil
 	}
 
-	// Try to load and validate the token
-	token, err := storage.LoadToken()
-	if err != nil {
-		fmt.Println("Status: Error reading stored token")
-		return fmt.Errorf("failed to load token: %w", err)
+	what := "token"
+	if creds.serviceAccount != nil {
+		what = "service account"
 	}
-
-	fmt.Print("Status: Validating token... ")
+	fmt.Printf("Status: Validating %s... ", what)
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	if err := auth.ValidateOfflineToken(ctx, token); err != nil {
+	if _, err := newTokenManager(cm, profile, creds).GetAccessToken(ctx); err != nil {
 		fmt.Println("INVALID")
 		fmt.Println()
-		fmt.Println("Your stored token is no longer valid.")
-		fmt.Println("Run 'agcm auth login' to configure a new token.")
+		if errors.Is(err, auth.ErrLoginRequired) {
+			fmt.Printf("Your %s is no longer valid.\n", what)
+		} else {
+			fmt.Printf("Could not validate your %s: %v\n", what, err)
+		}
+		if creds.serviceAccount == nil {
+			printTokenClaims(creds.offlineToken)
+			fmt.Printf("Run '%s' to configure a new token.\n", loginHint(profile))
+		} else if creds.fromEnv {
+			fmt.Printf("Check %s and %s.\n", auth.EnvClientID, auth.EnvClientSecret)
+		} else {
+			fmt.Printf("Run '%s --service-account' to update the credentials.\n", loginHint(profile))
+		}
 		return nil
 	}
 
 	fmt.Println("OK")
 	fmt.Println()
 	fmt.Println("Status: Authenticated")
+	fmt.Printf("Profile: %s\n", profile)
 	fmt.Printf("Config: %s\n", cfgDir)
-	if storage.UsingKeyring() {
+	switch {
+	case creds.fromEnv:
+		fmt.Println("Storage: Environment")
+	case storage.Backend() == auth.BackendKeyring:
 		fmt.Println("Storage: System keyring (secure)")
+	case storage.Backend() == auth.BackendEncrypted:
+		fmt.Println("Storage: Encrypted file (keyring unavailable)")
+	default:
+		fmt.Println("Storage: Plaintext file (run 'agcm auth migrate' to encrypt it)")
+	}
+	if creds.serviceAccount != nil {
+		fmt.Printf("Method: Service account (client ID %s)\n", creds.serviceAccount.ClientID)
 	} else {
-		fmt.Println("Storage: Config file (keyring unavailable)")
+		fmt.Println("Method: Offline token")
+		printTokenClaims(creds.offlineToken)
 	}
 
 	return nil
 }
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	cfgDir, cm, err := authConfig()
+	if err != nil {
+		return err
+	}
+
+	total := 0
+	for _, profile := range cm.ProfileNames() {
+		storage := openStorage(cfgDir, profile)
+		moved, err := storage.MigratePlaintext()
+		if err != nil {
+			return fmt.Errorf("failed to migrate profile %s: %w", profile, err)
+		}
+		if moved > 0 {
+			fmt.Printf("Profile %s: moved %d credential(s) to the %s\n", profile, moved, storage.Backend())
+		}
+		total += moved
+	}
+	if total == 0 {
+		fmt.Println("No plaintext credentials found.")
+	}
+	return nil
+}
+
+// storedIn describes a storage backend for login messages
+func storedIn(b auth.Backend) string {
+	switch b {
+	case auth.BackendKeyring:
+		return "securely in system keyring"
+	case auth.BackendEncrypted:
+		return "in an encrypted file (keyring unavailable)"
+	}
+	return "in the config directory"
+}
+
+// openStorage opens a profile's credential storage, prompting for the
+// encrypted store's passphrase when needed
+func openStorage(cfgDir, profile string) *auth.Storage {
+	return auth.NewStorage(cfgDir, profile, auth.WithPassphrase(promptPassphrase))
+}
+
+// promptPassphrase asks for the encrypted token store's passphrase on the
+// terminal, twice when creating a new store. It is remembered for the rest
+// of the run so switching profiles in the TUI does not prompt.
+func promptPassphrase(confirm bool) (string, error) {
+	if passphrase != "" {
+		return passphrase, nil
+	}
+	if tuiActive || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("the encrypted token store is locked; set %s", auth.EnvPassphrase)
+	}
+
+	read := func(prompt string) (string, error) {
+		fmt.Fprint(os.Stderr, prompt)
+		b, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		return string(b), nil
+	}
+
+	if confirm {
+		fmt.Fprintln(os.Stderr, "No keyring is available; credentials will be encrypted with a passphrase.")
+	}
+	p, err := read("Passphrase for the agcm token store: ")
+	if err != nil {
+		return "", err
+	}
+	if confirm {
+		again, err := read("Repeat passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if again != p {
+			return "", fmt.Errorf("passphrases do not match")
+		}
+	}
+	passphrase = p
+	return p, nil
+}
+
+// printTokenClaims reports who issued the offline token, to whom, and
+// when it expires
+func printTokenClaims(token string) {
+	claims, err := auth.ParseClaims(token)
+	if err != nil {
+		return
+	}
+	if claims.Issuer != "" {
+		fmt.Printf("Issuer: %s\n", claims.Issuer)
+	}
+	if claims.Subject != "" {
+		fmt.Printf("Subject: %s\n", claims.Subject)
+	}
+	if !claims.IssuedAt.IsZero() {
+		fmt.Printf("Issued: %s\n", claims.IssuedAt.Local().Format(time.RFC1123))
+	}
+	switch {
+	case claims.ExpiresAt.IsZero():
+		fmt.Printf("Expires: after %d days unused\n", int(auth.OfflineIdleTimeout.Hours()/24))
+	case claims.Expired():
+		fmt.Printf("Expired: %s\n", claims.ExpiresAt.Local().Format(time.RFC1123))
+	default:
+		fmt.Printf("Expires: %s (in %s), or after %d days unused\n",
+			claims.ExpiresAt.Local().Format(time.RFC1123),
+			formatRemaining(time.Until(claims.ExpiresAt)),
+			int(auth.OfflineIdleTimeout.Hours()/24))
+	}
+}
+
+// formatRemaining renders a duration in days or hours
+func formatRemaining(d time.Duration) string {
+	if d >= 48*time.Hour {
+		return fmt.Sprintf("%d days", int(d.Hours()/24))
+	}
+	return fmt.Sprintf("%d hours", int(d.Hours())+1)
+}
+
+// authConfig loads the config so auth commands can resolve the profile
+// without initializing the API client
+func authConfig() (string, *config.Manager, error) {
+	cfgDir := GetConfigDir()
+	if cfgDir == "" {
+		var err error
+		cfgDir, err = config.DefaultConfigDir()
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get config directory: %w", err)
+		}
+	}
+
+	cm := config.NewManager(cfgDir)
+	if err := cm.Load(); err != nil {
+		return "", nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	return cfgDir, cm, nil
+}
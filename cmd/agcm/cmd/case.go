@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/green/agcm/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// Status used when reopening a closed case
+const reopenStatus = "Waiting on Red Hat"
+
+var caseCmd = &cobra.Command{
+	Use:   "case",
+	Short: "Change support cases",
+	Long:  `Update, close, or reopen support cases.`,
+}
+
+var caseUpdateCmd = &cobra.Command{
+	Use:   "update [case-number]",
+	Short: "Update case fields",
+	Long: `Update the severity, status, or contact of a support case.
+
+Severity values (can use just the number):
+  1, 2, 3, 4  or  "1 (Urgent)", "2 (High)", "3 (Normal)", "4 (Low)"
+
+Status values (case-insensitive):
+  "Open", "Waiting on Red Hat", "Waiting on Customer", "Closed"
+
+Examples:
+  agcm case update 01234567 --severity 2
+  agcm case update 01234567 --status "Waiting on Red Hat" --contact jdoe`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCaseUpdate,
+}
+
+var caseCloseCmd = &cobra.Command{
+	Use:   "close [case-number]",
+	Short: "Close a case",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return applyCaseUpdate(args[0], &api.CaseUpdate{Status: "Closed"})
+	},
+}
+
+var caseReopenCmd = &cobra.Command{
+	Use:   "reopen [case-number]",
+	Short: "Reopen a closed case",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return applyCaseUpdate(args[0], &api.CaseUpdate{Status: reopenStatus})
+	},
+}
+
+var (
+	caseUpdateSeverity string
+	caseUpdateStatus   string
+	caseUpdateContact  string
+)
+
+func init() {
+	rootCmd.AddCommand(caseCmd)
+	caseCmd.AddCommand(caseUpdateCmd)
+	caseCmd.AddCommand(caseCloseCmd)
+	caseCmd.AddCommand(caseReopenCmd)
+	supportsOutput(caseUpdateCmd, caseCloseCmd, caseReopenCmd)
+
+	caseUpdateCmd.Flags().StringVar(&caseUpdateSeverity, "severity", "", "new severity")
+	caseUpdateCmd.Flags().StringVar(&caseUpdateStatus, "status", "", "new status")
+	caseUpdateCmd.Flags().StringVar(&caseUpdateContact, "contact", "", "new contact (SSO username)")
+}
+
+func runCaseUpdate(cmd *cobra.Command, args []string) error {
+	// UpdateCase resolves and validates severity and status
+	update := &api.CaseUpdate{
+		Severity:       caseUpdateSeverity,
+		Status:         caseUpdateStatus,
+		ContactSSOName: caseUpdateContact,
+	}
+	if update.IsEmpty() {
+		return fmt.Errorf("nothing to update (use --severity, --status, or --contact)")
+	}
+
+	return applyCaseUpdate(args[0], update)
+}
+
+// applyCaseUpdate sends an update and prints the resulting case state
+func applyCaseUpdate(caseNumber string, update *api.CaseUpdate) error {
+	client := GetAPIClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	c, err := client.UpdateCase(ctx, caseNumber, update)
+	if err != nil {
+		return fmt.Errorf("failed to update case: %w", err)
+	}
+
+	return printCase(c, fmt.Sprintf("Updated case %s: severity %s, status %s", c.CaseNumber, c.Severity, c.Status))
+}
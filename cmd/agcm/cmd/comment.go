@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var commentCmd = &cobra.Command{
+	Use:   "comment [case-number] [-]",
+	Short: "Add a comment to a case",
+	Long: `Add a public comment to a support case.
+
+The comment body is read from a file with --file, from stdin when the
+last argument is "-", or otherwise composed in $VISUAL / $EDITOR.
+Lines starting with '#' are removed from editor input.
+
+Examples:
+  agcm comment 01234567                 # Compose in $EDITOR
+  agcm comment 01234567 -f update.txt   # Read comment from file
+  sosreport-summary | agcm comment 01234567 -`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runComment,
+}
+
+var commentFile string
+
+func init() {
+	rootCmd.AddCommand(commentCmd)
+
+	commentCmd.Flags().StringVarP(&commentFile, "file", "f", "", "read comment from file (- for stdin)")
+}
+
+func runComment(cmd *cobra.Command, args []string) error {
+	client := GetAPIClient()
+	caseNumber := args[0]
+
+	if len(args) == 2 && args[1] != "-" {
+		return fmt.Errorf("unexpected argument %q (use - to read from stdin)", args[1])
+	}
+	if len(args) == 2 && commentFile != "" {
+		return fmt.Errorf("use either --file or -, not both")
+	}
+
+	var text string
+	var err error
+	switch {
+	case commentFile != "":
+		text, err = readTextInput(commentFile)
+	case len(args) == 2:
+		text, err = readTextInput("-")
+	default:
+		text, err = editText(fmt.Sprintf("# Enter your comment for case %s.\n# Lines starting with '#' are ignored; an empty comment aborts.\n", caseNumber))
+	}
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(text) == "" {
+		return fmt.Errorf("empty comment, nothing posted")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	if _, err := client.AddComment(ctx, caseNumber, text); err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	fmt.Printf("Comment added to case %s\n", caseNumber)
+	return nil
+}
+
+// editText opens the user's editor on a temporary file seeded with template
+// and returns the saved text with '#' comment lines removed
+func editText(template string) (string, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		} else {
+			editor = "vi"
+		}
+	}
+
+	f, err := os.CreateTemp("", "agcm-comment-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	path := f.Name()
+	defer func() { _ = os.Remove(path) }()
+
+	if _, err := f.WriteString(template); err != nil {
+		_ = f.Close()
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	// EDITOR may carry arguments, e.g. "code --wait"
+	parts := strings.Fields(editor)
+	editCmd := exec.Command(parts[0], append(parts[1:], path)...)
+	editCmd.Stdin = os.Stdin
+	editCmd.Stdout = os.Stdout
+	editCmd.Stderr = os.Stderr
+	if err := editCmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to run editor %s: %w", editor, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")), nil
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/green/agcm/internal/tui/styles"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect configuration",
+	Long:  `Inspect the effective configuration from config.yaml and its defaults.`,
+}
+
+var configKeysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Show the effective TUI key bindings",
+	Long: `Show the TUI key bindings after applying overrides from config.yaml.
+
+Override a binding under "ui.keys" with a key or a list of keys; an empty
+list unbinds it. Each key may only be bound to one action.
+
+  ui:
+    keys:
+      down: [j, ctrl+n]
+      up: [k, ctrl+p]
+      bulk_export: []
+
+Key names follow the terminal: letters, "ctrl+x", "alt+x", "enter", "esc",
+"tab", "up", "pgdown" and so on. The digits are reserved for presets.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigKeys,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configKeysCmd)
+	supportsOutput(configKeysCmd)
+}
+
+// keyBinding is a TUI action and the keys bound to it
+type keyBinding struct {
+	Action      string   `json:"action"`
+	Keys        []string `json:"keys"`
+	Description string   `json:"description"`
+	Source      string   `json:"source"` // default or config
+}
+
+func runConfigKeys(cmd *cobra.Command, args []string) error {
+	_, cm, err := authConfig()
+	if err != nil {
+		return err
+	}
+	overrides := cm.GetKeys()
+	keys, err := styles.LoadKeyMap(overrides)
+	if err != nil {
+		return fmt.Errorf("invalid ui.keys in config: %w", err)
+	}
+
+	overridden := make(map[string]bool)
+	for name := range overrides {
+		overridden[strings.ReplaceAll(strings.ToLower(name), "-", "_")] = true
+	}
+
+	var bindings []keyBinding
+	for _, info := range keys.Keys() {
+		b := keyBinding{Action: info.Name, Keys: info.Keys, Description: info.Desc, Source: "default"}
+		if b.Keys == nil {
+			b.Keys = []string{}
+		}
+		if overridden[info.Name] {
+			b.Source = "config"
+		}
+		bindings = append(bindings, b)
+	}
+
+	p, err := newPrinter()
+	if err != nil {
+		return err
+	}
+	if !p.table() {
+		rows := make([][]string, len(bindings))
+		for i, b := range bindings {
+			rows[i] = []string{b.Action, strings.Join(b.Keys, " "), b.Description, b.Source}
+		}
+		return p.print(bindings, []string{"action", "keys", "description", "source"}, rows)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if !noHeaders {
+		_, _ = fmt.Fprintln(w, "ACTION\tKEYS\tDESCRIPTION\tSOURCE")
+	}
+	for _, b := range bindings {
+		bound := strings.Join(b.Keys, ", ")
+		if bound == "" {
+			bound = "(unbound)"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", b.Action, bound, b.Description, b.Source)
+	}
+	return w.Flush()
+}
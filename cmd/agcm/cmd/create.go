@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/green/agcm/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var createCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create resources",
+	Long:  `Create support cases or other resources.`,
+}
+
+var createCaseCmd = &cobra.Command{
+	Use:   "case",
+	Short: "Open a new support case",
+	Long: `Open a new support case.
+
+The description can be given inline with --description, read from a file
+with --description-file, or read from stdin with --description-file -.
+
+Severity values (can use just the number):
+  1, 2, 3, 4  or  "1 (Urgent)", "2 (High)", "3 (Normal)", "4 (Low)"
+
+Examples:
+  agcm create case --summary "Kernel panic on boot" \
+      --product "Red Hat Enterprise Linux" --version 9.4 \
+      --severity 2 --description-file ./panic.txt
+  dmesg | agcm create case -s "NVMe timeouts" --product "Red Hat Enterprise Linux" \
+      --version 9.4 -f -`,
+	Args: cobra.NoArgs,
+	RunE: runCreateCase,
+}
+
+var (
+	createSummary         string
+	createDescription     string
+	createDescriptionFile string
+	createProduct         string
+	createVersion         string
+	createSeverity        string
+	createType            string
+	createAccount         string
+	createGroup           string
+)
+
+func init() {
+	rootCmd.AddCommand(createCmd)
+	createCmd.AddCommand(createCaseCmd)
+	supportsOutput(createCaseCmd)
+
+	createCaseCmd.Flags().StringVarP(&createSummary, "summary", "s", "", "case summary (required)")
+	createCaseCmd.Flags().StringVar(&createDescription, "description", "", "case description")
+	createCaseCmd.Flags().StringVarP(&createDescriptionFile, "description-file", "f", "", "read description from file (- for stdin)")
+	createCaseCmd.Flags().StringVar(&createProduct, "product", "", "product name (required)")
+	createCaseCmd.Flags().StringVar(&createVersion, "version", "", "product version (required)")
+	createCaseCmd.Flags().StringVar(&createSeverity, "severity", "3", "case severity")
+	createCaseCmd.Flags().StringVar(&createType, "type", "", "case type")
+	createCaseCmd.Flags().StringVarP(&createAccount, "account", "a", "", "account number (defaults to config)")
+	createCaseCmd.Flags().StringVarP(&createGroup, "group", "g", "", "case group number (defaults to config)")
+}
+
+func runCreateCase(cmd *cobra.Command, args []string) error {
+	client := GetAPIClient()
+
+	if createDescription != "" && createDescriptionFile != "" {
+		return fmt.Errorf("use either --description or --description-file, not both")
+	}
+
+	description := createDescription
+	if createDescriptionFile != "" {
+		text, err := readTextInput(createDescriptionFile)
+		if err != nil {
+			return err
+		}
+		description = text
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	values, err := client.GetCaseValues(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get case values: %w", err)
+	}
+
+	severity, err := api.ResolveCaseValue("severity", createSeverity, values.Severities)
+	if err != nil {
+		return err
+	}
+	caseType := ""
+	if createType != "" {
+		caseType, err = api.ResolveCaseValue("type", createType, values.Types)
+		if err != nil {
+			return err
+		}
+	}
+
+	req := &api.CreateCaseRequest{
+		Summary:       strings.TrimSpace(createSummary),
+		Description:   description,
+		Product:       strings.TrimSpace(createProduct),
+		Version:       strings.TrimSpace(createVersion),
+		Severity:      severity,
+		Type:          caseType,
+		AccountNumber: createAccount,
+		GroupNumber:   createGroup,
+	}
+	if req.AccountNumber == "" {
+		req.AccountNumber = configMgr.GetDefaults().AccountNumber
+	}
+	if req.GroupNumber == "" {
+		req.GroupNumber = configMgr.GetDefaults().GroupNumber
+	}
+
+	c, err := client.CreateCase(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to create case: %w", err)
+	}
+
+	return printCase(c, fmt.Sprintf("Created case %s: %s\nhttps://access.redhat.com/support/cases/#/case/%s",
+		c.CaseNumber, c.Summary, c.CaseNumber))
+}
+
+// readTextInput reads text from a file, or from stdin when path is "-"
+func readTextInput(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return strings.TrimRight(string(data), "\n"), nil
+}
@@ -17,8 +17,25 @@ import (
 
 var exportCmd = &cobra.Command{
 	Use:   "export",
-	Short: "Export cases to markdown",
-	Long:  `Export support cases and their conversations to markdown format.`,
+	Short: "Export cases to markdown, JSON, HTML, and more",
+	Long: `Export support cases and their conversations.
+
+Formats (--format):
+` + exportFormatList() + `
+
+JSON exports write one versioned document per case (case.json) containing
+the case, its comments, attachment metadata, and export metadata. With
+--combined, all documents are written as a JSON array to all-cases.json,
+or as one document per line when --output-file ends in .ndjson or .jsonl.
+
+Every export writes export-manifest.json to the output directory, recording
+each case's file, last modified date, export time, and content hash. Run the
+same export again with --resume to pick up an interrupted export, skipping
+cases whose files already exist, or with --incremental to re-export only
+cases modified since they were last exported.
+
+Export commands reject the global -o/--output flag: --format picks the
+export format and -f/--output-file the file written.`,
 }
 
 var exportCaseCmd = &cobra.Command{
@@ -29,7 +46,8 @@ var exportCaseCmd = &cobra.Command{
 Examples:
   agcm export case 01234567
   agcm export case 01234567 01234568 01234569 --output-dir ./exports/
-  agcm export case 01234567 --output ./case.md`,
+  agcm export case 01234567 --output-file ./case.md
+  agcm export case 01234567 --format json --output-file ./case.json`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: runExportCase,
 }
@@ -49,29 +67,35 @@ Examples:
   agcm export cases --status open                  # Export open cases
   agcm export cases 1 --severity 1,2               # Preset 1 + severity filter
   agcm export cases --bundle 1                     # Bundle export using preset 1
-  agcm export cases --bundle --status open         # Bundle export open cases`,
+  agcm export cases --bundle --status open         # Bundle export open cases
+  agcm export cases 1 --format json                # One case.json per case
+  agcm export cases 1 --format json --combined -f cases.ndjson
+  agcm export cases 1 --resume                     # Continue an interrupted export
+  agcm export cases 1 --incremental                # Only re-export changed cases`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runExportCases,
 }
 
 var (
 	// Export flags
-	exportOutput          string
-	exportOutputDir       string
-	exportFormat          string
-	exportCombined        bool
-	exportBundle          bool
-	exportIncludeAttach   bool
-	exportAttachmentsDir  string
-	exportTemplate        string
-	exportConcurrency     int
+	exportOutput         string
+	exportOutputDir      string
+	exportFormat         string
+	exportCombined       bool
+	exportBundle         bool
+	exportIncludeAttach  bool
+	exportAttachmentsDir string
+	exportTemplate       string
+	exportConcurrency    int
+	exportResume         bool
+	exportIncremental    bool
 
 	// Filter flags
-	exportStatus    string
-	exportSeverity  string
-	exportProduct   string
-	exportSince     string
-	exportUntil     string
+	exportStatus   string
+	exportSeverity string
+	exportProduct  string
+	exportSince    string
+	exportUntil    string
 )
 
 func init() {
@@ -80,23 +104,28 @@ func init() {
 	exportCmd.AddCommand(exportCasesCmd)
 
 	// Common export flags
-	exportCaseCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "output file (for single case)")
+	exportCaseCmd.Flags().StringVarP(&exportOutput, "output-file", "f", "", "output file (for single case)")
 	exportCaseCmd.Flags().StringVarP(&exportOutputDir, "output-dir", "d", "./exports", "output directory")
-	exportCaseCmd.Flags().StringVar(&exportFormat, "format", "markdown", "output format (markdown, json)")
+	exportCaseCmd.Flags().StringVar(&exportFormat, "format", "markdown", exportFormatUsage())
 	exportCaseCmd.Flags().BoolVar(&exportCombined, "combined", false, "combine all cases into single file")
 	exportCaseCmd.Flags().BoolVar(&exportIncludeAttach, "include-attachments", false, "download attachments")
 	exportCaseCmd.Flags().StringVar(&exportAttachmentsDir, "attachments-dir", "attachments", "attachments directory name")
 	exportCaseCmd.Flags().StringVar(&exportTemplate, "template", "", "custom Go template file")
 	exportCaseCmd.Flags().IntVar(&exportConcurrency, "concurrency", 4, "parallel downloads")
+	exportCaseCmd.Flags().BoolVar(&exportResume, "resume", false, "skip cases already in the output directory's manifest")
+	exportCaseCmd.Flags().BoolVar(&exportIncremental, "incremental", false, "skip cases unchanged since they were last exported")
 
+	exportCasesCmd.Flags().StringVarP(&exportOutput, "output-file", "f", "", "output file (for --combined)")
 	exportCasesCmd.Flags().StringVarP(&exportOutputDir, "output-dir", "d", "./exports", "output directory")
-	exportCasesCmd.Flags().StringVar(&exportFormat, "format", "markdown", "output format (markdown, json)")
+	exportCasesCmd.Flags().StringVar(&exportFormat, "format", "markdown", exportFormatUsage())
 	exportCasesCmd.Flags().BoolVar(&exportCombined, "combined", false, "combine all cases into single file")
 	exportCasesCmd.Flags().BoolVar(&exportBundle, "bundle", false, "bundle into 4MB markdown files (for AI tools)")
 	exportCasesCmd.Flags().BoolVar(&exportIncludeAttach, "include-attachments", false, "download attachments")
 	exportCasesCmd.Flags().StringVar(&exportAttachmentsDir, "attachments-dir", "attachments", "attachments directory name")
 	exportCasesCmd.Flags().StringVar(&exportTemplate, "template", "", "custom Go template file")
 	exportCasesCmd.Flags().IntVar(&exportConcurrency, "concurrency", 4, "parallel downloads")
+	exportCasesCmd.Flags().BoolVar(&exportResume, "resume", false, "skip cases already in the output directory's manifest")
+	exportCasesCmd.Flags().BoolVar(&exportIncremental, "incremental", false, "skip cases unchanged since they were last exported")
 
 	// Filter flags for cases command
 	exportCasesCmd.Flags().StringVar(&exportStatus, "status", "", "filter by status: open, closed, or exact values (comma-separated)")
@@ -113,6 +142,20 @@ var (
 	exportGroup   string
 )
 
+// exportFormatUsage returns the --format flag usage listing registered formats
+func exportFormatUsage() string {
+	return "output format (" + strings.Join(export.FormatNames(), ", ") + ")"
+}
+
+// exportFormatList describes each registered format for help text
+func exportFormatList() string {
+	var lines []string
+	for _, f := range export.Formats() {
+		lines = append(lines, fmt.Sprintf("  %-10s %s", f.Name, f.Description))
+	}
+	return strings.Join(lines, "\n")
+}
+
 func runExportCase(cmd *cobra.Command, args []string) error {
 	client := GetAPIClient()
 
@@ -126,7 +169,10 @@ func runExportCase(cmd *cobra.Command, args []string) error {
 		Concurrency:        exportConcurrency,
 		TemplatePath:       exportTemplate,
 		CaseNumbers:        args,
-		Debug:              IsDebugMode(),
+		Logger:             GetLogger(),
+		Version:            version,
+		Resume:             exportResume,
+		Incremental:        exportIncremental,
 	}
 
 	exporter, err := export.NewExporter(client, opts)
@@ -136,6 +182,9 @@ func runExportCase(cmd *cobra.Command, args []string) error {
 
 	// Single case with direct output
 	if len(args) == 1 && exportOutput != "" {
+		if exportResume || exportIncremental {
+			return fmt.Errorf("--resume and --incremental use the manifest in --output-dir; drop --output")
+		}
 		fmt.Printf("Exporting case %s to %s...\n", args[0], exportOutput)
 		if err := exporter.ExportCaseToFile(context.Background(), args[0], exportOutput); err != nil {
 			return fmt.Errorf("export failed: %w", err)
@@ -145,17 +194,13 @@ func runExportCase(cmd *cobra.Command, args []string) error {
 	}
 
 	// Multiple cases
-	progressCh := make(chan export.Progress, 10)
-	go func() {
-		for p := range progressCh {
-			fmt.Printf("\r[%d/%d] %s: %s          ",
-				p.CompletedCases, p.TotalCases, p.CurrentCase, p.CurrentStep)
-		}
-	}()
+	progressCh, upToDate := exportProgress()
 
 	fmt.Printf("Exporting %d case(s) to %s...\n", len(args), exportOutputDir)
+	start := time.Now()
 	manifest, err := exporter.ExportCases(context.Background(), args, progressCh)
 	close(progressCh)
+	skipped := <-upToDate
 	fmt.Println()
 
 	if err != nil {
@@ -163,8 +208,7 @@ func runExportCase(cmd *cobra.Command, args []string) error {
 	}
 
 	if manifest != nil {
-		fmt.Printf("Exported %d cases\n", len(manifest.Cases))
-		fmt.Printf("Manifest: %s/export-manifest.json\n", exportOutputDir)
+		printExportSummary(manifest, start, skipped, "")
 	}
 
 	return nil
@@ -192,18 +236,7 @@ func runExportCases(cmd *cobra.Command, args []string) error {
 			} else {
 				// Load preset filters as defaults
 				fmt.Printf("Using preset %s: %s\n", presetSlot, preset.Name)
-				if len(preset.Status) > 0 {
-					filter.Status = preset.Status
-				}
-				if len(preset.Severity) > 0 {
-					filter.Severity = preset.Severity
-				}
-				if len(preset.Products) > 0 {
-					filter.Products = preset.Products
-				}
-				if len(preset.Accounts) > 0 {
-					filter.Accounts = preset.Accounts
-				}
+				applyPreset(filter, preset)
 			}
 		} else {
 			return fmt.Errorf("invalid preset: %s (must be 0-9)", presetSlot)
@@ -266,18 +299,28 @@ func runExportCases(cmd *cobra.Command, args []string) error {
 
 	// Handle bundle export mode
 	if exportBundle {
+		if exportFormat != "markdown" {
+			return fmt.Errorf("--bundle only supports markdown")
+		}
+		if exportResume || exportIncremental {
+			return fmt.Errorf("--bundle cannot be used with --resume or --incremental")
+		}
 		return runBundleExport(client, filter)
 	}
 
 	opts := &export.Options{
 		OutputDir:          exportOutputDir,
+		OutputFile:         exportOutput,
 		Format:             exportFormat,
 		IncludeAttachments: exportIncludeAttach,
 		AttachmentsDir:     exportAttachmentsDir,
 		Combined:           exportCombined,
 		Concurrency:        exportConcurrency,
 		TemplatePath:       exportTemplate,
-		Debug:              IsDebugMode(),
+		Logger:             GetLogger(),
+		Version:            version,
+		Resume:             exportResume,
+		Incremental:        exportIncremental,
 	}
 
 	exporter, err := export.NewExporter(client, opts)
@@ -285,17 +328,13 @@ func runExportCases(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create exporter: %w", err)
 	}
 
-	progressCh := make(chan export.Progress, 10)
-	go func() {
-		for p := range progressCh {
-			fmt.Printf("\r[%d/%d] %s: %s          ",
-				p.CompletedCases, p.TotalCases, p.CurrentCase, p.CurrentStep)
-		}
-	}()
+	progressCh, upToDate := exportProgress()
 
 	fmt.Println("Fetching cases matching filters...")
+	start := time.Now()
 	manifest, err := exporter.ExportWithFilter(context.Background(), filter, progressCh)
 	close(progressCh)
+	skipped := <-upToDate
 	fmt.Println()
 
 	if err != nil {
@@ -303,14 +342,13 @@ func runExportCases(cmd *cobra.Command, args []string) error {
 	}
 
 	if manifest != nil {
-		fmt.Printf("Exported %d cases to %s\n", len(manifest.Cases), exportOutputDir)
-		fmt.Printf("Manifest: %s/export-manifest.json\n", exportOutputDir)
+		printExportSummary(manifest, start, skipped, exportOutputDir)
 
 		// Record filters in manifest and re-save
 		if filter.Status != nil || filter.Severity != nil || len(filter.Products) > 0 {
 			manifest.SetFilters(filter.Status, filter.Severity, filter.Products, exportSince, exportUntil)
 			// Re-save manifest with filter metadata
-			manifestPath := exportOutputDir + "/export-manifest.json"
+			manifestPath := filepath.Join(exportOutputDir, export.ManifestFileName)
 			if err := manifest.Save(manifestPath); err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: failed to save manifest with filters: %v\n", err)
 			}
@@ -320,6 +358,42 @@ func runExportCases(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// exportProgress starts printing export progress. The returned channel
+// yields the number of up-to-date cases skipped once progress is closed.
+func exportProgress() (chan export.Progress, <-chan int) {
+	progressCh := make(chan export.Progress, 10)
+	upToDate := make(chan int, 1)
+	go func() {
+		skipped := 0
+		for p := range progressCh {
+			if p.CurrentStep == export.StepUpToDate {
+				skipped++
+			}
+			fmt.Printf("\r[%d/%d] %s: %s          ",
+				p.CompletedCases, p.TotalCases, p.CurrentCase, p.CurrentStep)
+		}
+		upToDate <- skipped
+	}()
+	return progressCh, upToDate
+}
+
+// printExportSummary reports how many cases an export wrote
+func printExportSummary(manifest *export.Manifest, start time.Time, skipped int, dir string) {
+	exported := len(manifest.Cases)
+	if exportResume || exportIncremental {
+		exported = manifest.CountExportedSince(start)
+	}
+	if dir != "" {
+		fmt.Printf("Exported %d cases to %s\n", exported, dir)
+	} else {
+		fmt.Printf("Exported %d cases\n", exported)
+	}
+	if skipped > 0 {
+		fmt.Printf("Skipped %d up-to-date cases\n", skipped)
+	}
+	fmt.Printf("Manifest: %s\n", filepath.Join(exportOutputDir, export.ManifestFileName))
+}
+
 const maxBundleSize = 4 * 1024 * 1024 // 4MB
 
 func runBundleExport(client *api.Client, filter *api.CaseFilter) error {
@@ -332,12 +406,14 @@ func runBundleExport(client *api.Client, filter *api.CaseFilter) error {
 
 	// Fetch cases matching filter
 	fmt.Println("Fetching cases matching filters...")
-	result, err := client.ListCases(ctx, filter)
+	filter.StartIndex = 0
+	filter.Count = api.PageSize
+	cases, err := client.IterateCases(ctx, filter).All()
 	if err != nil {
 		return fmt.Errorf("failed to list cases: %w", err)
 	}
 
-	if len(result.Items) == 0 {
+	if len(cases) == 0 {
 		fmt.Println("No cases found matching the criteria.")
 		return nil
 	}
@@ -347,13 +423,13 @@ func runBundleExport(client *api.Client, filter *api.CaseFilter) error {
 		return fmt.Errorf("failed to create formatter: %w", err)
 	}
 
-	totalCases := len(result.Items)
+	totalCases := len(cases)
 	bundleNum := 1
 	var currentBundle strings.Builder
 	casesInBundle := 0
 	casesExported := 0
 
-	for i, c := range result.Items {
+	for i, c := range cases {
 		fmt.Printf("\r[%d/%d] Fetching %s...          ", i+1, totalCases, c.CaseNumber)
 
 		// Fetch full case details
@@ -4,13 +4,15 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
-	"text/tabwriter"
 	"time"
+	"unicode/utf8"
 
 	"github.com/green/agcm/internal/api"
+	"github.com/green/agcm/internal/config"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
@@ -35,13 +37,41 @@ Status values (case-insensitive):
 Severity values (can use just the number):
   1, 2, 3, 4  or  "1 (Urgent)", "2 (High)", "3 (Normal)", "4 (Low)"
 
+--query takes field:value terms, ANDed together. Separate alternatives
+with commas, negate a term with -, and quote values containing spaces.
+Bare words and "phrases" search the case text.
+
+  Fields:  status, severity, product, version, owner, account, group,
+           contact, case, created, modified, text
+  Dates:   >7d (last 7 days), <2w, >=2026-01-02, 2026-01-01..2026-02-01
+           (ages use h, d, w, y)
+
+A status term replaces the default exclusion of closed cases.
+
+Cases are fetched and printed a page at a time. --limit caps how many
+are shown; --all lists every match instead. --page N shows the Nth
+--limit-sized page, and --offset N skips the first N matches.
+
+--columns picks the table's columns, in order; name:width fixes a
+column's width. The default comes from ui.table_columns in config.yaml.
+
+  Columns: case, modified, created, age, severity, status, product,
+           version, owner, account, contact, summary
+
 Examples:
   agcm list cases 1                         # List using preset 1
   agcm list cases --status Open
   agcm list cases --status "Waiting on Red Hat"
   agcm list cases --status Closed           # List closed cases
   agcm list cases 1 --severity 1,2          # Preset 1 + severity filter
-  agcm list cases --account 12345678`,
+  agcm list cases --account 12345678
+  agcm list cases -q 'product:"OpenShift" severity:1,2 modified:>7d -status:closed'
+  agcm list cases -q 'owner:jdoe text:"kernel panic"'
+  agcm list cases --columns case,severity,owner,age,summary:60
+  agcm list cases --all -o json             # Every open case as JSON
+  agcm list cases --page 2 -n 50            # Cases 51-100
+  agcm list cases --offset 10 --all         # All but the first 10
+  agcm list cases -o template='{{.CaseNumber}} {{.Owner}}'`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runListCases,
 }
@@ -53,7 +83,12 @@ var (
 	listAccount  string
 	listGroup    string
 	listOwner    string
+	listQuery    string
+	listColumns  string
 	listLimit    int
+	listPage     int
+	listOffset   int
+	listAll      bool
 )
 
 func init() {
@@ -66,18 +101,39 @@ func init() {
 	listCasesCmd.Flags().StringVarP(&listAccount, "account", "a", "", "filter by account number")
 	listCasesCmd.Flags().StringVarP(&listGroup, "group", "g", "", "filter by case group number")
 	listCasesCmd.Flags().StringVar(&listOwner, "owner", "", "filter by owner SSO username")
+	listCasesCmd.Flags().StringVarP(&listQuery, "query", "q", "", "filter with a query, e.g. 'severity:1,2 modified:>7d'")
+	listCasesCmd.Flags().StringVar(&listColumns, "columns", "", "table columns in order, e.g. case,severity,owner:20,summary")
 	listCasesCmd.Flags().IntVarP(&listLimit, "limit", "n", 25, "maximum number of cases to show")
+	listCasesCmd.Flags().BoolVar(&listAll, "all", false, "list every matching case, ignoring --limit")
+	listCasesCmd.Flags().IntVar(&listPage, "page", 0, "show page N of --limit cases each")
+	listCasesCmd.Flags().IntVar(&listOffset, "offset", 0, "skip the first N matching cases")
+	listCasesCmd.MarkFlagsMutuallyExclusive("page", "offset")
+	listCasesCmd.MarkFlagsMutuallyExclusive("page", "all")
+
+	supportsOutput(listCasesCmd)
 }
 
 func runListCases(cmd *cobra.Command, args []string) error {
-	client := GetAPIClient()
+	if listLimit <= 0 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+	if listPage < 0 || listOffset < 0 {
+		return fmt.Errorf("--page and --offset cannot be negative")
+	}
+	filter := &api.CaseFilter{}
 
-	filter := &api.CaseFilter{
-		Count: listLimit,
+	columns, err := tableColumns()
+	if err != nil {
+		return err
+	}
+	p, err := newPrinter()
+	if err != nil {
+		return err
 	}
+	chatty := p.table() && !noHeaders
 
 	hasCliFilters := listStatus != "" || listSeverity != "" || listProduct != "" ||
-		listAccount != "" || listGroup != "" || listOwner != ""
+		listAccount != "" || listGroup != "" || listOwner != "" || listQuery != ""
 
 	// Check for preset argument (0-9)
 	if len(args) == 1 {
@@ -86,25 +142,19 @@ func runListCases(cmd *cobra.Command, args []string) error {
 			preset := configMgr.GetPreset(presetSlot)
 			if preset == nil {
 				if !hasCliFilters {
+					if !p.table() {
+						return p.print([]api.Case{}, nil, nil)
+					}
 					fmt.Printf("No preset saved in slot %s. Nothing to list.\n", presetSlot)
 					return nil
 				}
 				// Has CLI filters, continue without preset
 			} else {
 				// Load preset filters as defaults
-				fmt.Printf("Using preset %s: %s\n", presetSlot, preset.Name)
-				if len(preset.Status) > 0 {
-					filter.Status = preset.Status
-				}
-				if len(preset.Severity) > 0 {
-					filter.Severity = preset.Severity
-				}
-				if len(preset.Products) > 0 {
-					filter.Products = preset.Products
-				}
-				if len(preset.Accounts) > 0 {
-					filter.Accounts = preset.Accounts
+				if chatty {
+					fmt.Printf("Using preset %s: %s\n", presetSlot, preset.Name)
 				}
+				applyPreset(filter, preset)
 			}
 		} else {
 			return fmt.Errorf("invalid preset: %s (must be 0-9)", presetSlot)
@@ -145,18 +195,47 @@ func runListCases(cmd *cobra.Command, args []string) error {
 	if listOwner != "" {
 		filter.OwnerSSOName = listOwner
 	}
+	if listQuery != "" {
+		if _, err := api.ParseQuery(listQuery); err != nil {
+			return queryError(listQuery, err)
+		}
+		filter.Query = listQuery
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	timeout := 30 * time.Second
+	if listAll {
+		timeout = 5 * time.Minute
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	result, err := client.ListCases(ctx, filter)
-	if err != nil {
-		return fmt.Errorf("failed to list cases: %w", err)
+	// --limit is both the page size for --page and the most cases shown
+	filter.StartIndex = listOffset
+	if listPage > 0 {
+		filter.StartIndex = (listPage - 1) * listLimit
+	}
+	filter.Count = min(listLimit, api.PageSize)
+	if listAll {
+		filter.Count = api.PageSize
+	}
+	it := api.IterateCasesWith(ctx, listCases, filter)
+	if !listAll {
+		it.Limit(listLimit)
 	}
 
-	if len(result.Items) == 0 {
-		fmt.Println("No cases found matching the criteria.")
-		return nil
+	if !p.table() {
+		header, _ := caseRows(nil, columns)
+		out := p.stream(header)
+		for it.Next() {
+			_, rows := caseRows(it.Page(), columns)
+			if err := out.write(it.Page(), rows); err != nil {
+				return err
+			}
+		}
+		if err := it.Err(); err != nil {
+			return fmt.Errorf("failed to list cases: %w", err)
+		}
+		return out.close()
 	}
 
 	// Get terminal width
@@ -164,60 +243,126 @@ func runListCases(cmd *cobra.Command, args []string) error {
 	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
 		termWidth = w
 	}
+	widths := api.LayoutColumns(columns, termWidth, 2)
 
-	// Calculate column widths based on terminal size
-	// Fixed columns: CASE(10) + SEV(4) + STATUS(22) + spacing(12) = ~48
-	// Remaining space split between PRODUCT and SUMMARY
-	fixedWidth := 48
-	remaining := termWidth - fixedWidth
-	productWidth := remaining / 4       // 25% for product
-	summaryWidth := remaining - productWidth // 75% for summary
-
-	if productWidth < 15 {
-		productWidth = 15
+	// Print the table a page at a time, padding cells to the layout widths
+	// so the pages line up
+	shown := 0
+	now := time.Now()
+	cells := make([]string, len(columns))
+	for it.Next() {
+		if shown == 0 && !noHeaders {
+			for i, col := range columns {
+				cells[i] = col.Title
+			}
+			printTableRow(cells, widths)
+			for i, col := range columns {
+				cells[i] = strings.Repeat("-", len(col.Title))
+			}
+			printTableRow(cells, widths)
+		}
+		for _, c := range it.Page() {
+			for i, col := range columns {
+				cells[i] = api.TruncateColumn(col.Value(&c, now), widths[i])
+			}
+			printTableRow(cells, widths)
+		}
+		shown += len(it.Page())
 	}
-	if summaryWidth < 30 {
-		summaryWidth = 30
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("failed to list cases: %w", err)
 	}
 
-	// Print table
-	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	_, _ = fmt.Fprintln(tw, "CASE\tSEV\tSTATUS\tPRODUCT\tSUMMARY")
-	_, _ = fmt.Fprintln(tw, "----\t---\t------\t-------\t-------")
-
-	for _, c := range result.Items {
-		summary := c.Summary
-		if len(summary) > summaryWidth {
-			summary = summary[:summaryWidth-3] + "..."
+	if shown == 0 {
+		if chatty {
+			fmt.Println("No cases found matching the criteria.")
 		}
-		product := c.Product
-		if len(product) > productWidth {
-			product = product[:productWidth-3] + "..."
+		return nil
+	}
+	if chatty {
+		first := filter.StartIndex + 1
+		if first > 1 {
+			fmt.Printf("\nShowing cases %d-%d of %d\n", first, filter.StartIndex+shown, it.Total())
+		} else {
+			fmt.Printf("\nShowing %d of %d cases\n", shown, it.Total())
 		}
+	}
 
-		// Shorten severity display
-		sev := c.Severity
-		if len(sev) > 0 {
-			sev = string(sev[0]) // Just the number
+	return nil
+}
+
+// printTableRow prints cells padded to widths, leaving the last unpadded
+func printTableRow(cells []string, widths []int) {
+	var b strings.Builder
+	for i, cell := range cells {
+		if i == len(cells)-1 {
+			b.WriteString(cell)
+			break
 		}
+		b.WriteString(cell)
+		b.WriteString(strings.Repeat(" ", max(widths[i]-utf8.RuneCountInString(cell), 0)+2))
+	}
+	fmt.Println(strings.TrimRight(b.String(), " "))
+}
 
-		// Shorten status
-		status := c.Status
-		if len(status) > 20 {
-			status = status[:17] + "..."
+// tableColumns returns the columns from --columns, the config, or the defaults
+func tableColumns() ([]api.Column, error) {
+	if listColumns != "" {
+		columns, err := api.ParseColumns([]string{listColumns})
+		if err != nil {
+			return nil, fmt.Errorf("invalid --columns: %w", err)
 		}
+		return columns, nil
+	}
+	if specs := configMgr.GetTableColumns(); len(specs) > 0 {
+		columns, err := api.ParseColumns(specs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ui.table_columns in config: %w", err)
+		}
+		return columns, nil
+	}
+	return api.ParseColumns(api.DefaultTableColumns)
+}
 
-		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
-			c.CaseNumber,
-			sev,
-			status,
-			product,
-			summary,
-		)
+// applyPreset copies the settings of a saved filter preset into filter
+func applyPreset(filter *api.CaseFilter, preset *config.FilterPreset) {
+	if len(preset.Status) > 0 {
+		filter.Status = preset.Status
+	}
+	if len(preset.Severity) > 0 {
+		filter.Severity = preset.Severity
+	}
+	if len(preset.Products) > 0 {
+		filter.Products = preset.Products
+	}
+	if len(preset.Accounts) > 0 {
+		filter.Accounts = preset.Accounts
 	}
-	_ = tw.Flush()
+	if preset.Keyword != "" {
+		filter.Keyword = preset.Keyword
+	}
+	if preset.Query != "" {
+		filter.Query = preset.Query
+	}
+}
 
-	fmt.Printf("\nShowing %d of %d cases\n", len(result.Items), result.TotalCount)
+// listCases lists cases from the API, or from the local store in offline mode
+func listCases(ctx context.Context, filter *api.CaseFilter) (*api.ListResponse[api.Case], error) {
+	if offlineMode {
+		return caseStore.QueryCases(filter)
+	}
+	return GetAPIClient().ListCases(ctx, filter)
+}
 
-	return nil
+// queryError points at the malformed part of a query
+func queryError(query string, err error) error {
+	var qerr *api.QueryError
+	if !errors.As(err, &qerr) {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+	// Offsets are in bytes; the caret goes under characters
+	start, end := min(qerr.Pos, len(query)), min(qerr.End, len(query))
+	width := max(utf8.RuneCountInString(query[start:end]), 1)
+	marker := strings.Repeat(" ", utf8.RuneCountInString(query[:start])) + strings.Repeat("^", width)
+	return fmt.Errorf("invalid query: %s\n  %s\n  %s", qerr.Msg, query, marker)
 }
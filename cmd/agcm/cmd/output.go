@@ -0,0 +1,311 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/green/agcm/internal/api"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats for --output; a Go template is given as template=TEXT
+const (
+	outputTable    = "table"
+	outputJSON     = "json"
+	outputYAML     = "yaml"
+	outputCSV      = "csv"
+	outputTemplate = "template"
+)
+
+var (
+	outputFormat string
+	noHeaders    bool
+)
+
+// outputAnnotation marks commands that honor --output
+const outputAnnotation = "agcm/output"
+
+// supportsOutput marks commands whose results --output can format
+func supportsOutput(cmds ...*cobra.Command) {
+	for _, c := range cmds {
+		if c.Annotations == nil {
+			c.Annotations = make(map[string]string)
+		}
+		c.Annotations[outputAnnotation] = "true"
+	}
+}
+
+// checkOutput validates --output before a command does any work. Commands
+// that only print messages reject it, so a script does not silently get text.
+func checkOutput(cmd *cobra.Command) error {
+	if outputFormat != outputTable && cmd.Annotations[outputAnnotation] == "" {
+		return fmt.Errorf("--output is not supported by '%s'", cmd.CommandPath())
+	}
+	_, err := newPrinter()
+	return err
+}
+
+// printer writes a command's results in the format chosen with --output
+type printer struct {
+	format string
+	tmpl   *template.Template
+	w      io.Writer
+}
+
+// newPrinter validates --output
+func newPrinter() (*printer, error) {
+	p := &printer{w: os.Stdout}
+	name, text, isTemplate := strings.Cut(outputFormat, "=")
+	switch strings.ToLower(name) {
+	case outputTable, outputJSON, outputYAML, outputCSV:
+		if isTemplate {
+			return nil, fmt.Errorf("--output %s does not take a value", name)
+		}
+		p.format = strings.ToLower(name)
+	case outputTemplate, "go-template":
+		if !isTemplate || text == "" {
+			return nil, fmt.Errorf("--output template needs a template, e.g. -o template='{{.CaseNumber}}'")
+		}
+		tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --output template: %w", err)
+		}
+		p.format = outputTemplate
+		p.tmpl = tmpl
+	default:
+		return nil, fmt.Errorf("invalid --output %q (valid: table, json, yaml, csv, template=TEXT)", outputFormat)
+	}
+	return p, nil
+}
+
+// templateFuncs are available to --output templates besides the built-ins
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"date": func(layout string, t time.Time) string {
+		return t.Format(layout)
+	},
+}
+
+// table reports whether the command should print its own human output
+func (p *printer) table() bool {
+	return p.format == outputTable
+}
+
+// print writes v as JSON or YAML, or through the template once per element
+// when v is a slice. CSV writes header and rows instead.
+func (p *printer) print(v any, header []string, rows [][]string) error {
+	switch p.format {
+	case outputJSON:
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case outputYAML:
+		// Go through JSON so YAML uses the same field names
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to encode output: %w", err)
+		}
+		var doc any
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("failed to encode output: %w", err)
+		}
+		enc := yaml.NewEncoder(p.w)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return fmt.Errorf("failed to encode output: %w", err)
+		}
+		return enc.Close()
+	case outputCSV:
+		w := csv.NewWriter(p.w)
+		if !noHeaders {
+			_ = w.Write(header)
+		}
+		_ = w.WriteAll(rows)
+		if err := w.Error(); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+		return nil
+	case outputTemplate:
+		return p.execute(v)
+	}
+	return fmt.Errorf("output format %s has no table printer", p.format)
+}
+
+// execute runs the template over v, or over each element of a slice
+func (p *printer) execute(v any) error {
+	items := []any{v}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+		items = make([]any, rv.Len())
+		for i := range items {
+			items[i] = rv.Index(i).Interface()
+		}
+	}
+	for _, item := range items {
+		if err := p.tmpl.Execute(p.w, item); err != nil {
+			return fmt.Errorf("failed to execute --output template: %w", err)
+		}
+		if _, err := fmt.Fprintln(p.w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stream writes a list one page at a time in a machine-readable format, so
+// long listings start printing before the last page is fetched
+type stream struct {
+	p      *printer
+	header []string
+	csv    *csv.Writer
+	count  int
+}
+
+// stream starts a list; CSV output uses header
+func (p *printer) stream(header []string) *stream {
+	s := &stream{p: p, header: header}
+	if p.format == outputCSV {
+		s.csv = csv.NewWriter(p.w)
+	}
+	return s
+}
+
+// write outputs the next page; items is a slice and rows its CSV rows
+func (s *stream) write(items any, rows [][]string) error {
+	switch s.p.format {
+	case outputJSON:
+		rv := reflect.ValueOf(items)
+		for i := 0; i < rv.Len(); i++ {
+			data, err := json.MarshalIndent(rv.Index(i).Interface(), "  ", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode output: %w", err)
+			}
+			sep := ",\n  "
+			if s.count == 0 {
+				sep = "[\n  "
+			}
+			if _, err := fmt.Fprintf(s.p.w, "%s%s", sep, data); err != nil {
+				return err
+			}
+			s.count++
+		}
+		return nil
+	case outputYAML:
+		// Top-level sequences concatenate, so each page is encoded on its own
+		if reflect.ValueOf(items).Len() == 0 {
+			return nil
+		}
+		s.count += reflect.ValueOf(items).Len()
+		return s.p.print(items, nil, nil)
+	case outputCSV:
+		s.writeHeader()
+		_ = s.csv.WriteAll(rows)
+		s.count += len(rows)
+		if err := s.csv.Error(); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+		return nil
+	case outputTemplate:
+		s.count += reflect.ValueOf(items).Len()
+		return s.p.execute(items)
+	}
+	return fmt.Errorf("output format %s has no table printer", s.p.format)
+}
+
+func (s *stream) writeHeader() {
+	if s.count == 0 && !noHeaders && s.header != nil {
+		_ = s.csv.Write(s.header)
+		s.header = nil
+	}
+}
+
+// close finishes the list, writing an empty one if nothing was written
+func (s *stream) close() error {
+	var err error
+	switch s.p.format {
+	case outputJSON:
+		if s.count == 0 {
+			_, err = fmt.Fprintln(s.p.w, "[]")
+		} else {
+			_, err = fmt.Fprintln(s.p.w, "\n]")
+		}
+	case outputYAML:
+		if s.count == 0 {
+			_, err = fmt.Fprintln(s.p.w, "[]")
+		}
+	case outputCSV:
+		s.writeHeader()
+		s.csv.Flush()
+		err = s.csv.Error()
+	}
+	return err
+}
+
+// caseRows returns the CSV header and rows of cases in the given columns,
+// untruncated and with full severities and RFC 3339 dates
+func caseRows(cases []api.Case, columns []api.Column) ([]string, [][]string) {
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.Name
+	}
+	now := time.Now()
+	rows := make([][]string, len(cases))
+	for i := range cases {
+		row := make([]string, len(columns))
+		for j, col := range columns {
+			row[j] = csvValue(col, &cases[i], now)
+		}
+		rows[i] = row
+	}
+	return header, rows
+}
+
+// csvValue is a column's value in a form spreadsheets and scripts can parse
+func csvValue(col api.Column, c *api.Case, now time.Time) string {
+	switch col.Name {
+	case "severity":
+		return c.Severity
+	case "created":
+		return csvTime(c.CreatedDate)
+	case "modified":
+		return csvTime(c.LastModified)
+	}
+	return col.Value(c, now)
+}
+
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// printCase writes a single case, e.g. after creating or updating it. The
+// table form is a one-line message.
+func printCase(c *api.Case, message string) error {
+	p, err := newPrinter()
+	if err != nil {
+		return err
+	}
+	if p.table() {
+		fmt.Println(message)
+		return nil
+	}
+	header, rows := caseRows([]api.Case{*c}, api.CaseColumns())
+	return p.print(c, header, rows)
+}
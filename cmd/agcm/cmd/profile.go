@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/green/agcm/internal/auth"
+	"github.com/green/agcm/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage portal profiles",
+	Long: `Manage named profiles, each with its own offline token, API URLs,
+default account and group, and filter presets.
+
+Create a profile by logging in to it:
+
+  agcm auth login --profile acme
+
+Select a profile for one command with --profile or AGCM_PROFILE, or make
+it the default with 'agcm profile use'. Profile settings live under
+"profiles:" in config.yaml.`,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles",
+	Args:  cobra.NoArgs,
+	RunE:  runProfileList,
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use <profile>",
+	Short: "Set the default profile",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runProfileUse,
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileUseCmd)
+	supportsOutput(profileListCmd)
+}
+
+// profileInfo describes a profile for profile list
+type profileInfo struct {
+	Name     string `json:"name"`
+	Active   bool   `json:"active"`
+	Account  string `json:"account,omitempty"`
+	LoggedIn bool   `json:"loggedIn"`
+	BaseURL  string `json:"baseUrl"`
+}
+
+func runProfileList(cmd *cobra.Command, args []string) error {
+	cfgDir, cm, err := authConfig()
+	if err != nil {
+		return err
+	}
+	active := selectedProfile(cm)
+
+	var profiles []profileInfo
+	for _, name := range cm.ProfileNames() {
+		if err := cm.UseProfile(name); err != nil {
+			return err
+		}
+		profiles = append(profiles, profileInfo{
+			Name:     name,
+			Active:   name == active,
+			Account:  cm.GetDefaults().AccountNumber,
+			LoggedIn: auth.NewStorage(cfgDir, name).HasCredentials(),
+			BaseURL:  cm.GetBaseURL(),
+		})
+	}
+
+	p, err := newPrinter()
+	if err != nil {
+		return err
+	}
+	if !p.table() {
+		rows := make([][]string, len(profiles))
+		for i, pi := range profiles {
+			rows[i] = []string{pi.Name, strconv.FormatBool(pi.Active), pi.Account, strconv.FormatBool(pi.LoggedIn), pi.BaseURL}
+		}
+		return p.print(profiles, []string{"name", "active", "account", "logged_in", "base_url"}, rows)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if !noHeaders {
+		_, _ = fmt.Fprintln(w, "  PROFILE\tACCOUNT\tLOGGED IN\tBASE URL")
+	}
+	for _, pi := range profiles {
+		marker := " "
+		if pi.Active {
+			marker = "*"
+		}
+		loggedIn := "no"
+		if pi.LoggedIn {
+			loggedIn = "yes"
+		}
+		account := pi.Account
+		if account == "" {
+			account = "-"
+		}
+		_, _ = fmt.Fprintf(w, "%s %s\t%s\t%s\t%s\n", marker, pi.Name, account, loggedIn, pi.BaseURL)
+	}
+	return w.Flush()
+}
+
+func runProfileUse(cmd *cobra.Command, args []string) error {
+	_, cm, err := authConfig()
+	if err != nil {
+		return err
+	}
+	name := args[0]
+	if err := cm.UseProfile(name); err != nil {
+		return err
+	}
+
+	cm.Get().Profile = name
+	if name == config.DefaultProfile {
+		cm.Get().Profile = ""
+	}
+	if err := cm.Save(); err != nil {
+		return err
+	}
+	fmt.Printf("Now using profile %s\n", name)
+	if os.Getenv("AGCM_PROFILE") != "" {
+		fmt.Println("Note: AGCM_PROFILE is set and takes precedence.")
+	}
+	return nil
+}
@@ -5,28 +5,50 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/green/agcm/internal/api"
+	"github.com/green/agcm/internal/apitest"
 	"github.com/green/agcm/internal/auth"
 	"github.com/green/agcm/internal/config"
+	"github.com/green/agcm/internal/logging"
+	"github.com/green/agcm/internal/store"
 	"github.com/green/agcm/internal/tui"
+	"github.com/green/agcm/internal/tui/styles"
 	"github.com/spf13/cobra"
 )
 
 var (
-	cfgDir        string
-	debugMode     bool
-	maskMode      bool
-	tuiAccounts   string
-	tuiGroup      string
-	tuiPreset     string
-	configMgr     *config.Manager
-	tokenMgr      *auth.TokenManager
-	storage       *auth.Storage
-	apiClient     *api.Client
-	version       string
+	cfgDir      string
+	debugMode   bool
+	maskMode    bool
+	offlineMode bool
+	profileName string
+	demoMode    bool
+	logLevel    string
+	logFile     string
+	logFormat   string
+	traceFile   string
+	tuiAccounts string
+	tuiGroup    string
+	tuiPreset   string
+	tuiTheme    string
+	configMgr   *config.Manager
+	tokenMgr    *auth.TokenManager
+	storage     *auth.Storage
+	apiClient   *api.Client
+	caseStore   *store.Store
+	demoServer  *apitest.Server
+	logger      *slog.Logger
+	closeLog    func() error
+	traceRec    *api.Trace
+	stopRefresh context.CancelFunc
+	tuiActive   bool   // The TUI owns the terminal, so nothing may prompt
+	passphrase  string // Encrypted token store passphrase, once entered
+	version     string
 )
 
 // SetVersion sets the application version (called from main)
@@ -42,8 +64,17 @@ var rootCmd = &cobra.Command{
 	Long: `A terminal user interface for browsing Red Hat support cases.
 Filter, sort, search, and export cases to markdown.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkOutput(cmd); err != nil {
+			return err
+		}
+
 		// Skip initialization for auth commands and update (doesn't need auth)
-		if cmd.Name() == "login" || cmd.Name() == "logout" || cmd.Name() == "status" || cmd.Name() == "update" {
+		// ("case update" shares the name but needs the API client)
+		isSelfUpdate := cmd.Name() == "update" && cmd.Parent() == cmd.Root()
+		isAuth := cmd.Parent() == authCmd
+		isProfile := cmd == profileCmd || cmd.Parent() == profileCmd
+		isConfig := cmd == configCmd || cmd.Parent() == configCmd
+		if isAuth || isSelfUpdate || isProfile || isConfig {
 			return nil
 		}
 
@@ -55,6 +86,25 @@ Filter, sort, search, and export cases to markdown.`,
 			GroupNumber: tuiGroup,
 			MaskMode:    maskMode,
 			Version:     version,
+			Offline:     offlineMode,
+			Store:       caseStore,
+			Profile:     configMgr.Profile(),
+			Theme:       tuiTheme,
+		}
+		if tuiTheme != "" {
+			if _, err := tui.LoadTheme(tuiTheme, configMgr.GetThemes()); err != nil {
+				return err
+			}
+		}
+		keys, err := styles.LoadKeyMap(configMgr.GetKeys())
+		if err != nil {
+			return fmt.Errorf("invalid ui.keys in config: %w", err)
+		}
+		opts.Keys = keys
+		if !demoMode {
+			opts.SwitchProfile = switchProfile
+			opts.TokenExpiresAt = offlineTokenExpiry()
+			startTokenRefresh()
 		}
 
 		// Handle preset flag
@@ -75,32 +125,53 @@ Filter, sort, search, and export cases to markdown.`,
 		}
 
 		// Use config defaults if not specified
-		if len(opts.Accounts) == 0 && configMgr.Get().Defaults.AccountNumber != "" {
-			opts.Accounts = []string{configMgr.Get().Defaults.AccountNumber}
+		if len(opts.Accounts) == 0 && configMgr.GetDefaults().AccountNumber != "" {
+			opts.Accounts = []string{configMgr.GetDefaults().AccountNumber}
 		}
 		if opts.GroupNumber == "" {
-			opts.GroupNumber = configMgr.Get().Defaults.GroupNumber
+			opts.GroupNumber = configMgr.GetDefaults().GroupNumber
 		}
 
 		// Launch TUI
+		tuiActive = true
 		return tui.Run(apiClient, opts, configMgr)
 	},
 	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
-		// Clean up resources
-		if apiClient != nil {
-			return apiClient.Close()
-		}
-		return nil
+		return cleanup()
 	},
 }
 
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
+		// Failed runs skip PersistentPostRunE; still write the trace and log
+		_ = cleanup()
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
 
+// cleanup releases resources opened by initApp. It is safe to call twice.
+func cleanup() error {
+	var err error
+	if stopRefresh != nil {
+		stopRefresh()
+		stopRefresh = nil
+	}
+	if demoServer != nil {
+		demoServer.Close()
+		_ = os.RemoveAll(cfgDir)
+		demoServer = nil
+	}
+	if apiClient != nil {
+		err = apiClient.Close()
+	}
+	if closeLog != nil {
+		_ = closeLog()
+		closeLog = nil
+	}
+	return err
+}
+
 func init() {
 	// Determine config directory
 	defaultCfgDir, err := config.DefaultConfigDir()
@@ -109,18 +180,32 @@ func init() {
 	}
 
 	rootCmd.PersistentFlags().StringVar(&cfgDir, "config", defaultCfgDir, "config directory")
-	rootCmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "enable debug output")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "portal profile to use (default $AGCM_PROFILE or 'agcm profile use')")
+	rootCmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "enable debug logging (same as --log-level debug)")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "", "log level: debug, info, warn, error")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "log file, or - for stderr (default "+logging.DefaultFile+")")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "", "log format: text, json")
+	rootCmd.PersistentFlags().StringVar(&traceFile, "trace", "", "record HTTP requests and responses to a HAR file for bug reports")
+	rootCmd.PersistentFlags().BoolVar(&offlineMode, "offline", false, "read cases from the local store (see 'agcm sync')")
+	rootCmd.PersistentFlags().BoolVar(&demoMode, "demo", false, "use a built-in fake portal with sample cases (no login needed)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputTable, "output format: table, json, yaml, csv, or template=TEXT (a Go template)")
+	rootCmd.PersistentFlags().BoolVar(&noHeaders, "no-headers", false, "omit table and CSV headers and footers")
 
 	// TUI-specific flags (on root command, not persistent)
 	rootCmd.Flags().StringVarP(&tuiAccounts, "account", "a", "", "filter by account number(s), comma-separated")
 	rootCmd.Flags().StringVarP(&tuiGroup, "group", "g", "", "filter by case group number")
 	rootCmd.Flags().StringVarP(&tuiPreset, "preset", "p", "", "load filter preset (1-9, 0)")
 	rootCmd.Flags().BoolVar(&maskMode, "mask", false, "mask sensitive text for screenshots")
+	rootCmd.Flags().StringVar(&tuiTheme, "theme", "", "color theme: auto, dark, light, high-contrast or a theme from config.yaml")
 }
 
 func initApp() error {
 	var err error
 
+	if demoMode {
+		return initDemo()
+	}
+
 	// Initialize config manager
 	if cfgDir == "" {
 		cfgDir, err = config.DefaultConfigDir()
@@ -134,39 +219,303 @@ func initApp() error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	// Initialize auth storage
-	storage = auth.NewStorage(cfgDir)
+	if err := initLogging(); err != nil {
+		return err
+	}
+
+	if err := configMgr.UseProfile(selectedProfile(configMgr)); err != nil {
+		return err
+	}
+	return connect()
+}
+
+// selectedProfile returns the profile named by --profile, then
+// AGCM_PROFILE, then the config file
+func selectedProfile(cm *config.Manager) string {
+	if profileName != "" {
+		return profileName
+	}
+	if env := os.Getenv("AGCM_PROFILE"); env != "" {
+		return env
+	}
+	if p := cm.Get().Profile; p != "" {
+		return p
+	}
+	return config.DefaultProfile
+}
+
+// loginHint returns the login command for a profile
+func loginHint(profile string) string {
+	if profile == config.DefaultProfile {
+		return "agcm auth login"
+	}
+	return "agcm auth login --profile " + profile
+}
+
+// credentials are what a profile authenticates with: service account
+// credentials or an offline token
+type credentials struct {
+	serviceAccount *auth.ServiceAccount
+	offlineToken   string
+	fromEnv        bool // Service account came from the environment
+}
+
+// loadCredentials returns service account credentials from the
+// environment, else the profile's stored credentials, else nil
+func loadCredentials(st *auth.Storage) (*credentials, error) {
+	sa, err := auth.ServiceAccountFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if sa != nil {
+		return &credentials{serviceAccount: sa, fromEnv: true}, nil
+	}
 
-	// Load token
-	token, err := storage.LoadToken()
+	sa, err = st.LoadServiceAccount()
 	if err != nil {
-		return fmt.Errorf("failed to load token: %w", err)
+		return nil, fmt.Errorf("failed to load service account: %w", err)
+	}
+	if sa != nil {
+		return &credentials{serviceAccount: sa}, nil
 	}
 
+	token, err := st.LoadToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load token: %w", err)
+	}
 	if token == "" {
-		return fmt.Errorf("not authenticated. Run 'agcm auth login' first")
+		return nil, nil
+	}
+	return &credentials{offlineToken: token}, nil
+}
+
+// tokenOptions returns the token manager options for the active profile's
+// SSO settings
+func tokenOptions(cm *config.Manager) []auth.TokenManagerOption {
+	var opts []auth.TokenManagerOption
+	a := cm.GetAuth()
+	if a.TokenURL != "" {
+		opts = append(opts, auth.WithTokenURL(a.TokenURL))
+	}
+	if a.ClientID != "" {
+		opts = append(opts, auth.WithClientID(a.ClientID))
+	}
+	return opts
+}
+
+// newTokenManager creates a token manager for a profile's credentials and
+// configured SSO. Nil credentials give a manager that cannot fetch tokens.
+func newTokenManager(cm *config.Manager, profile string, creds *credentials) *auth.TokenManager {
+	opts := tokenOptions(cm)
+	if creds == nil {
+		return auth.NewTokenManager("", opts...)
+	}
+	switch {
+	case creds.fromEnv:
+		// Logging in does not replace credentials set in the environment
+		opts = append(opts, auth.WithServiceAccount(creds.serviceAccount), auth.WithLoginCommand(""))
+	case creds.serviceAccount != nil:
+		opts = append(opts,
+			auth.WithServiceAccount(creds.serviceAccount),
+			auth.WithLoginCommand(loginHint(profile)+" --service-account"))
+	default:
+		opts = append(opts, auth.WithLoginCommand(loginHint(profile)))
+	}
+	return auth.NewTokenManager(creds.offlineToken, opts...)
+}
+
+// connect sets up the token, API client and local store of the config
+// manager's active profile. Nothing is replaced unless it succeeds.
+func connect() error {
+	profile := configMgr.Profile()
+	st := openStorage(cfgDir, profile)
+
+	creds, err := loadCredentials(st)
+	if err != nil {
+		return err
+	}
+
+	if creds == nil && !offlineMode {
+		return fmt.Errorf("not authenticated. Run '%s' first, or set %s and %s",
+			loginHint(profile), auth.EnvClientID, auth.EnvClientSecret)
 	}
 
 	// Initialize token manager
-	tokenMgr = auth.NewTokenManager(token)
+	tm := newTokenManager(configMgr, profile, creds)
 
 	// Initialize API client
-	apiClient = api.NewClient(
+	apiCfg := configMgr.Get().API
+	retry := configMgr.GetRetry()
+	opts, err := diagnosticOptions()
+	if err != nil {
+		return err
+	}
+	client := api.NewClient(append(opts,
 		api.WithBaseURL(configMgr.GetBaseURL()),
-		api.WithTokenRefresher(func(ctx context.Context) (string, error) {
-			return tokenMgr.GetAccessToken(ctx)
+		api.WithHydraURL(configMgr.GetHydraURL()),
+		api.WithRetryPolicy(api.RetryPolicy{
+			MaxRetries: retry.MaxRetries,
+			BaseDelay:  retry.BaseDelay,
+			MaxDelay:   retry.MaxDelay,
 		}),
-		api.WithDebug(debugMode),
-	)
+		api.WithRateLimit(apiCfg.MaxConcurrent, apiCfg.RequestsPerSecond),
+		api.WithTokenRefresher(tm.GetAccessToken),
+	)...)
 
+	storage = st
+	tokenMgr = tm
+	apiClient = client
+	caseStore = store.New(config.ProfileDir(cfgDir, profile))
 	return nil
 }
 
+// switchProfile reconnects to another profile for the TUI's profile
+// switcher, staying on the current one if that fails
+func switchProfile(name string) (*tui.Connection, error) {
+	prev := configMgr.Profile()
+	if err := configMgr.UseProfile(name); err != nil {
+		return nil, err
+	}
+	if err := connect(); err != nil {
+		_ = configMgr.UseProfile(prev)
+		return nil, err
+	}
+
+	startTokenRefresh()
+
+	conn := &tui.Connection{
+		Client:         apiClient,
+		Store:          caseStore,
+		GroupNumber:    configMgr.GetDefaults().GroupNumber,
+		TokenExpiresAt: offlineTokenExpiry(),
+	}
+	if acct := configMgr.GetDefaults().AccountNumber; acct != "" {
+		conn.Accounts = []string{acct}
+	}
+	return conn, nil
+}
+
+// startTokenRefresh keeps the current profile's access token fresh in the
+// background for long-running commands, replacing any earlier refresher
+func startTokenRefresh() {
+	if stopRefresh != nil {
+		stopRefresh()
+		stopRefresh = nil
+	}
+	if offlineMode || tokenMgr == nil {
+		return
+	}
+	var ctx context.Context
+	ctx, stopRefresh = context.WithCancel(context.Background())
+	tokenMgr.RefreshInBackground(ctx)
+}
+
+// offlineTokenExpiry returns the fixed expiry of the current offline token,
+// or the zero time if it has none or cannot be decoded
+func offlineTokenExpiry() time.Time {
+	if tokenMgr == nil {
+		return time.Time{}
+	}
+	claims, err := tokenMgr.OfflineTokenClaims()
+	if err != nil {
+		return time.Time{}
+	}
+	return claims.ExpiresAt
+}
+
+// initDemo points the API client at an in-process fake portal. Config and
+// the local store live in a temporary directory so a demo never touches
+// the real token, settings or synced cases.
+func initDemo() error {
+	if offlineMode {
+		return fmt.Errorf("--demo cannot be combined with --offline")
+	}
+
+	dir, err := os.MkdirTemp("", "agcm-demo-")
+	if err != nil {
+		return fmt.Errorf("failed to create demo directory: %w", err)
+	}
+	cfgDir = dir
+
+	configMgr = config.NewManager(cfgDir)
+	caseStore = store.New(cfgDir)
+	if err := initLogging(); err != nil {
+		return err
+	}
+	opts, err := diagnosticOptions()
+	if err != nil {
+		return err
+	}
+
+	demoServer = apitest.NewServer(apitest.DefaultFixtures(time.Now()))
+	apiClient = demoServer.NewClient(opts...)
+
+	return nil
+}
+
+// initLogging sets up the logger from flags, falling back to the config file.
+// Logging stays off unless a level is chosen.
+func initLogging() error {
+	cfg := configMgr.GetLog()
+	level := cfg.Level
+	if debugMode {
+		level = "debug"
+	}
+	if logLevel != "" {
+		level = logLevel
+	}
+	if level == "" {
+		logger = logging.Discard()
+		return nil
+	}
+
+	opts := logging.Options{Level: level, File: cfg.File, Format: cfg.Format}
+	if logFile != "" {
+		opts.File = logFile
+	}
+	if logFormat != "" {
+		opts.Format = logFormat
+	}
+
+	var err error
+	logger, closeLog, err = logging.New(opts)
+	if err != nil {
+		return fmt.Errorf("failed to set up logging: %w", err)
+	}
+	return nil
+}
+
+// diagnosticOptions returns the client options for logging and --trace
+func diagnosticOptions() ([]api.ClientOption, error) {
+	opts := []api.ClientOption{api.WithLogger(logger)}
+	if traceFile != "" {
+		// Clients made by a profile switch share one trace
+		if traceRec == nil {
+			var err error
+			if traceRec, err = api.NewTrace(traceFile, version); err != nil {
+				return nil, err
+			}
+		}
+		opts = append(opts, api.WithTrace(traceRec))
+	}
+	return opts, nil
+}
+
 // GetAPIClient returns the initialized API client
 func GetAPIClient() *api.Client {
 	return apiClient
 }
 
+// GetStore returns the local case store
+func GetStore() *store.Store {
+	return caseStore
+}
+
+// IsOffline returns whether commands should read from the local store
+func IsOffline() bool {
+	return offlineMode
+}
+
 // GetConfigDir returns the configuration directory
 func GetConfigDir() string {
 	return cfgDir
@@ -177,6 +526,11 @@ func IsDebugMode() bool {
 	return debugMode
 }
 
+// GetLogger returns the diagnostic logger
+func GetLogger() *slog.Logger {
+	return logger
+}
+
 // GetStorage returns the auth storage
 func GetStorage() *auth.Storage {
 	return storage
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/green/agcm/internal/api"
 	"github.com/spf13/cobra"
 )
 
@@ -16,128 +17,153 @@ var searchCmd = &cobra.Command{
 	Short: "Search for cases, solutions, and articles",
 	Long: `Search across cases, solutions, and articles.
 
+--limit, --page and --offset apply to cases and to solutions and
+articles separately; --all shows every result.
+
 Examples:
   agcm search "kernel panic"
-  agcm search "NVMe driver" --limit 20`,
+  agcm search "NVMe driver" --limit 20
+  agcm search "NVMe driver" --limit 20 --page 2
+  agcm search "kernel panic" -o json`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: runSearch,
 }
 
-var searchLimit int
+var (
+	searchLimit  int
+	searchPage   int
+	searchOffset int
+	searchAll    bool
+)
 
 func init() {
 	rootCmd.AddCommand(searchCmd)
-	searchCmd.Flags().IntVarP(&searchLimit, "limit", "n", 10, "maximum number of results")
+	searchCmd.Flags().IntVarP(&searchLimit, "limit", "n", 10, "maximum number of results of each kind")
+	searchCmd.Flags().BoolVar(&searchAll, "all", false, "show every result, ignoring --limit")
+	searchCmd.Flags().IntVar(&searchPage, "page", 0, "show page N of --limit results each")
+	searchCmd.Flags().IntVar(&searchOffset, "offset", 0, "skip the first N results of each kind")
+	searchCmd.MarkFlagsMutuallyExclusive("page", "offset")
+	searchCmd.MarkFlagsMutuallyExclusive("page", "all")
+	supportsOutput(searchCmd)
 }
 
 func runSearch(cmd *cobra.Command, args []string) error {
 	client := GetAPIClient()
 	query := strings.Join(args, " ")
+	p, err := newPrinter()
+	if err != nil {
+		return err
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	if searchLimit <= 0 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+	if searchPage < 0 || searchOffset < 0 {
+		return fmt.Errorf("--page and --offset cannot be negative")
+	}
+	timeout := 30 * time.Second
+	if searchAll {
+		timeout = 5 * time.Minute
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	// Search both cases and KCS (solutions/articles)
-	var results []struct {
-		Type  string
-		ID    string
-		Title string
+	// --limit applies to cases and to solutions and articles separately
+	start := searchOffset
+	if searchPage > 0 {
+		start = (searchPage - 1) * searchLimit
 	}
-
-	// Search cases
-	caseResults, err := client.SearchCases(ctx, query, searchLimit)
-	if err != nil {
-		// Log error but continue with KCS search
-		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: case search failed: %v\n", err)
-	} else {
-		for _, r := range caseResults {
-			results = append(results, struct {
-				Type  string
-				ID    string
-				Title string
-			}{r.Type, r.ID, r.Title})
-		}
+	pageSize := min(searchLimit, api.PageSize)
+	if searchAll {
+		pageSize = api.PageSize
+	}
+	iterators := []struct {
+		name string
+		it   *api.Iterator[api.SearchResult]
+	}{
+		{"case", client.IterateSearchCases(ctx, query, start, pageSize)},
+		{"KCS", client.IterateSearch(ctx, query, start, pageSize)},
 	}
 
-	// Search KCS (solutions and articles)
-	kcsResults, err := client.Search(ctx, query, searchLimit)
-	if err != nil {
-		// Log error but continue if we have case results
-		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: KCS search failed: %v\n", err)
-	} else {
-		for _, r := range kcsResults {
-			results = append(results, struct {
-				Type  string
-				ID    string
-				Title string
-			}{r.Type, r.ID, r.Title})
+	// Search both cases and KCS (solutions/articles). Machine-readable
+	// output streams; the table is grouped by type so it waits for all.
+	results := []api.SearchResult{}
+	var out *stream
+	if !p.table() {
+		out = p.stream([]string{"type", "id", "title", "uri"})
+	}
+	for _, search := range iterators {
+		if !searchAll {
+			search.it.Limit(searchLimit)
+		}
+		for search.it.Next() {
+			if out == nil {
+				results = append(results, search.it.Page()...)
+				continue
+			}
+			rows := make([][]string, len(search.it.Page()))
+			for i, r := range search.it.Page() {
+				rows[i] = []string{r.Type, r.ID, r.Title, r.URI}
+			}
+			if err := out.write(search.it.Page(), rows); err != nil {
+				return err
+			}
+		}
+		if err := search.it.Err(); err != nil {
+			// Log error but continue with the other search
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s search failed: %v\n", search.name, err)
 		}
 	}
+	if out != nil {
+		return out.close()
+	}
 
 	if len(results) == 0 {
-		fmt.Println("No results found.")
+		if !noHeaders {
+			fmt.Println("No results found.")
+		}
 		return nil
 	}
 
-	fmt.Printf("Found %d results for '%s':\n\n", len(results), query)
-
-	// Group by type
-	var cases, solutions, articles []struct {
-		id    string
-		title string
+	if !noHeaders {
+		fmt.Printf("Found %d results for '%s':\n\n", len(results), query)
 	}
 
+	// Group by type
+	var cases, solutions, articles []api.SearchResult
 	for _, r := range results {
-		item := struct {
-			id    string
-			title string
-		}{r.ID, r.Title}
-
 		switch r.Type {
 		case "case":
-			cases = append(cases, item)
+			cases = append(cases, r)
 		case "solution", "Solution":
-			solutions = append(solutions, item)
+			solutions = append(solutions, r)
 		case "article", "Article":
-			articles = append(articles, item)
+			articles = append(articles, r)
 		}
 	}
 
-	if len(cases) > 0 {
-		fmt.Println("CASES")
-		for _, c := range cases {
-			title := c.title
-			if len(title) > 60 {
-				title = title[:57] + "..."
-			}
-			fmt.Printf("  [C] %s - %s\n", c.id, title)
-		}
-		fmt.Println()
-	}
+	printSearchGroup("CASES", "C", cases)
+	printSearchGroup("SOLUTIONS", "S", solutions)
+	printSearchGroup("ARTICLES", "A", articles)
+	return nil
+}
 
-	if len(solutions) > 0 {
-		fmt.Println("SOLUTIONS")
-		for _, s := range solutions {
-			title := s.title
-			if len(title) > 60 {
-				title = title[:57] + "..."
-			}
-			fmt.Printf("  [S] %s - %s\n", s.id, title)
-		}
-		fmt.Println()
+// printSearchGroup prints one type of search result under a heading
+func printSearchGroup(heading, tag string, results []api.SearchResult) {
+	if len(results) == 0 {
+		return
 	}
-
-	if len(articles) > 0 {
-		fmt.Println("ARTICLES")
-		for _, a := range articles {
-			title := a.title
-			if len(title) > 60 {
-				title = title[:57] + "..."
-			}
-			fmt.Printf("  [A] %s - %s\n", a.id, title)
+	if !noHeaders {
+		fmt.Println(heading)
+	}
+	for _, r := range results {
+		title := r.Title
+		if len(title) > 60 {
+			title = title[:57] + "..."
 		}
+		fmt.Printf("  [%s] %s - %s\n", tag, r.ID, title)
+	}
+	if !noHeaders {
 		fmt.Println()
 	}
-
-	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/green/agcm/internal/api"
 	"github.com/green/agcm/internal/export"
 	"github.com/spf13/cobra"
 )
@@ -22,8 +23,11 @@ var showCaseCmd = &cobra.Command{
 	Short: "Show case details",
 	Long: `Show detailed information about a specific case.
 
+With --output, the case is printed with its comments and attachments.
+
 Examples:
-  agcm show case 01234567`,
+  agcm show case 01234567
+  agcm show case 01234567 -o json`,
 	Args: cobra.ExactArgs(1),
 	RunE: runShowCase,
 }
@@ -35,35 +39,32 @@ func init() {
 	showCmd.AddCommand(showCaseCmd)
 
 	showCaseCmd.Flags().BoolVar(&showComments, "comments", true, "include comments")
+	supportsOutput(showCaseCmd)
 }
 
 func runShowCase(cmd *cobra.Command, args []string) error {
-	client := GetAPIClient()
 	caseNumber := args[0]
+	p, err := newPrinter()
+	if err != nil {
+		return err
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Get case details
-	c, err := client.GetCase(ctx, caseNumber)
+	// Get case details, comments, and attachments
+	c, commentsResult, attachments, err := loadCaseDetail(ctx, caseNumber)
 	if err != nil {
-		return fmt.Errorf("failed to get case: %w", err)
+		return err
 	}
 
-	// Get comments
-	var comments []any
-	if showComments {
-		commentsResult, err := client.GetCaseComments(ctx, caseNumber)
-		if err == nil {
-			for _, c := range commentsResult {
-				comments = append(comments, c)
-			}
-		}
+	if !p.table() {
+		c.Comments = commentsResult
+		c.Attachments = attachments
+		header, rows := caseRows([]api.Case{*c}, api.CaseColumns())
+		return p.print(c, header, rows)
 	}
 
-	// Get attachments
-	attachments, _ := client.GetCaseAttachments(ctx, caseNumber)
-
 	// Use the export formatter to generate markdown
 	md, err := export.QuickFormat(c, nil, attachments)
 	if err != nil {
@@ -73,8 +74,7 @@ func runShowCase(cmd *cobra.Command, args []string) error {
 	fmt.Println(md)
 
 	// Print comments separately for better CLI output
-	if showComments && len(comments) > 0 {
-		commentsResult, _ := client.GetCaseComments(ctx, caseNumber)
+	if showComments && len(commentsResult) > 0 {
 		fmt.Print("\n## Comments\n\n")
 		for i, comment := range commentsResult {
 			fmt.Printf("### Comment %d\n", i+1)
@@ -93,3 +93,29 @@ func runShowCase(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// loadCaseDetail loads a case with its comments and attachments from the API,
+// or from the local store in offline mode. Comment and attachment errors are
+// ignored so the case itself can still be shown.
+func loadCaseDetail(ctx context.Context, caseNumber string) (*api.Case, []api.Comment, []api.Attachment, error) {
+	if offlineMode {
+		rec, err := caseStore.LoadCase(caseNumber)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to get case: %w (run 'agcm sync' while online)", err)
+		}
+		return &rec.Case, rec.Comments, rec.Attachments, nil
+	}
+
+	client := GetAPIClient()
+	c, err := client.GetCase(ctx, caseNumber)
+	if err != nil {
+		return nil, nil, nil, api.CaseError(caseNumber, err)
+	}
+
+	var comments []api.Comment
+	if showComments {
+		comments, _ = client.GetCaseComments(ctx, caseNumber)
+	}
+	attachments, _ := client.GetCaseAttachments(ctx, caseNumber)
+	return c, comments, attachments, nil
+}
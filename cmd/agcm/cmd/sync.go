@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/green/agcm/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync cases to the local store",
+	Long: `Download cases, comments, and attachment metadata to the local store
+so they can be browsed with --offline.
+
+Only cases modified since the last sync are fetched. Use --full to
+refresh every case.
+
+Examples:
+  agcm sync                    # Incremental sync
+  agcm sync -a 12345678        # Sync one account
+  agcm sync --full             # Refresh everything
+  agcm --offline               # Browse the synced cases`,
+	Args: cobra.NoArgs,
+	RunE: runSync,
+}
+
+var (
+	syncAccount string
+	syncGroup   string
+	syncFull    bool
+)
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+
+	syncCmd.Flags().StringVarP(&syncAccount, "account", "a", "", "account number(s), comma-separated (defaults to config)")
+	syncCmd.Flags().StringVarP(&syncGroup, "group", "g", "", "case group number (defaults to config)")
+	syncCmd.Flags().BoolVar(&syncFull, "full", false, "refresh every case, not just those changed since the last sync")
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	if offlineMode {
+		return fmt.Errorf("sync needs network access; drop --offline")
+	}
+
+	opts := store.SyncOptions{
+		GroupNumber: syncGroup,
+		Full:        syncFull,
+		Progress: func(caseNumber string, fetched int) {
+			fmt.Printf("  %s\n", caseNumber)
+		},
+	}
+	if syncAccount != "" {
+		for _, a := range strings.Split(syncAccount, ",") {
+			opts.Accounts = append(opts.Accounts, strings.TrimSpace(a))
+		}
+	} else if acct := configMgr.GetDefaults().AccountNumber; acct != "" {
+		opts.Accounts = []string{acct}
+	}
+	if opts.GroupNumber == "" {
+		opts.GroupNumber = configMgr.GetDefaults().GroupNumber
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	fmt.Printf("Syncing to %s\n", caseStore.Dir())
+	result, err := caseStore.Sync(ctx, GetAPIClient(), opts)
+	if err != nil {
+		if result != nil && result.Updated > 0 {
+			fmt.Printf("Stored %d cases before the error; re-run sync to continue\n", result.Updated)
+		}
+		return err
+	}
+
+	if result.Since.IsZero() {
+		fmt.Printf("Synced %d cases\n", result.Updated)
+	} else {
+		fmt.Printf("Synced %d changed cases since %s (%d unchanged)\n",
+			result.Updated, result.Since.Local().Format("2006-01-02 15:04"), result.Unchanged)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/green/agcm/internal/api"
+	"github.com/green/agcm/internal/watch"
+	"github.com/spf13/cobra"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch [preset]",
+	Short: "Watch cases for changes",
+	Long: `Poll for case changes and report them as they happen.
+
+Events are written to stdout as JSON lines, one per change:
+  case_opened, status_changed, severity_changed, comment_added
+
+The first poll only records a baseline. Failed polls back off
+exponentially up to --max-interval.
+
+With --exec, each event is also passed to a shell command with the
+event JSON on stdin and AGCM_EVENT, AGCM_CASE, AGCM_OLD, AGCM_NEW set
+in its environment. With --notify, a desktop notification is shown.
+
+Examples:
+  agcm watch                              # Watch default account
+  agcm watch 1                            # Watch cases matching preset 1
+  agcm watch --interval 2m --notify
+  agcm watch --exec 'jq -r .summary >> ~/case-log.txt'`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runWatch,
+}
+
+var (
+	watchInterval    time.Duration
+	watchMaxInterval time.Duration
+	watchAccount     string
+	watchGroup       string
+	watchExec        string
+	watchNotify      bool
+	watchQuiet       bool
+)
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", watch.DefaultInterval, "time between polls")
+	watchCmd.Flags().DurationVar(&watchMaxInterval, "max-interval", watch.DefaultMaxInterval, "longest delay when backing off after errors")
+	watchCmd.Flags().StringVarP(&watchAccount, "account", "a", "", "account number(s), comma-separated (defaults to config)")
+	watchCmd.Flags().StringVarP(&watchGroup, "group", "g", "", "case group number (defaults to config)")
+	watchCmd.Flags().StringVar(&watchExec, "exec", "", "shell command to run for each event")
+	watchCmd.Flags().BoolVar(&watchNotify, "notify", false, "show desktop notifications")
+	watchCmd.Flags().BoolVarP(&watchQuiet, "quiet", "q", false, "don't write events to stdout")
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	if offlineMode {
+		return fmt.Errorf("watch needs network access; drop --offline")
+	}
+	if watchInterval < 30*time.Second {
+		return fmt.Errorf("--interval must be at least 30s")
+	}
+
+	filter := &api.CaseFilter{GroupNumber: watchGroup}
+	if len(args) == 1 {
+		slot := args[0]
+		if len(slot) != 1 || slot[0] < '0' || slot[0] > '9' {
+			return fmt.Errorf("invalid preset: %s (must be 0-9)", slot)
+		}
+		preset := configMgr.GetPreset(slot)
+		if preset == nil {
+			return fmt.Errorf("no preset saved in slot %s", slot)
+		}
+		applyPreset(filter, preset)
+	}
+	if watchAccount != "" {
+		filter.Accounts = nil
+		for _, a := range strings.Split(watchAccount, ",") {
+			filter.Accounts = append(filter.Accounts, strings.TrimSpace(a))
+		}
+	} else if len(filter.Accounts) == 0 {
+		if acct := configMgr.GetDefaults().AccountNumber; acct != "" {
+			filter.Accounts = []string{acct}
+		}
+	}
+	if filter.GroupNumber == "" {
+		filter.GroupNumber = configMgr.GetDefaults().GroupNumber
+	}
+
+	var notifier *watch.Notifier
+	if watchNotify {
+		var err error
+		if notifier, err = watch.NewNotifier(); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	startTokenRefresh()
+
+	w := watch.New(GetAPIClient(), filter)
+	w.Interval = watchInterval
+	w.MaxInterval = max(watchMaxInterval, watchInterval)
+
+	enc := json.NewEncoder(os.Stdout)
+	handle := func(e watch.Event) {
+		if !watchQuiet {
+			_ = enc.Encode(e)
+		}
+		if watchExec != "" {
+			if err := runWatchHook(ctx, watchExec, e); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+		if notifier != nil {
+			if err := notifier.Notify(e); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+	}
+	onError := func(err error, retry time.Duration) {
+		fmt.Fprintf(os.Stderr, "Poll failed: %v (retrying in %s)\n", err, retry)
+	}
+
+	fmt.Fprintf(os.Stderr, "Watching cases every %s (Ctrl+C to stop)\n", watchInterval)
+	if err := w.Run(ctx, handle, onError); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+// runWatchHook runs the --exec command for one event
+func runWatchHook(ctx context.Context, command string, e watch.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		c = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	c.Stdin = bytes.NewReader(data)
+	c.Stdout = os.Stderr
+	c.Stderr = os.Stderr
+	c.Env = append(os.Environ(),
+		"AGCM_EVENT="+string(e.Type),
+		"AGCM_CASE="+e.CaseNumber,
+		"AGCM_OLD="+e.Old,
+		"AGCM_NEW="+e.New,
+	)
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("hook failed for case %s: %w", e.CaseNumber, err)
+	}
+	return nil
+}
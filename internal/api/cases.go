@@ -7,7 +7,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
+	"path"
 	"sort"
 	"strings"
 	"time"
@@ -93,71 +95,48 @@ func (c *Client) ListCases(ctx context.Context, filter *CaseFilter) (*ListRespon
 		}
 	}
 
+	// Parse the query first so a malformed one fails before any request
+	var parsed *Query
+	if filter != nil && filter.Query != "" {
+		var err error
+		if parsed, err = ParseQuery(filter.Query); err != nil {
+			return nil, fmt.Errorf("invalid query: %w", err)
+		}
+	}
+
 	// Build Solr filter query (fq) parts
 	var fqParts []string
+	var queryText string
 
 	if filter != nil {
 		// Status filter
 		if len(filter.Status) > 0 {
-			if len(filter.Status) == 1 {
-				fqParts = append(fqParts, fmt.Sprintf("case_status:%q", filter.Status[0]))
-			} else {
-				// Multiple statuses: case_status:("Open" OR "Closed")
-				quoted := make([]string, len(filter.Status))
-				for i, s := range filter.Status {
-					quoted[i] = fmt.Sprintf("%q", s)
-				}
-				fqParts = append(fqParts, fmt.Sprintf("case_status:(%s)", strings.Join(quoted, " OR ")))
-			}
+			fqParts = append(fqParts, fieldClause("case_status", filter.Status))
 		}
 
 		// Severity filter - use full severity strings like status filter
 		if len(filter.Severity) > 0 {
-			if len(filter.Severity) == 1 {
-				fqParts = append(fqParts, fmt.Sprintf("case_severity:%q", filter.Severity[0]))
-			} else {
-				quoted := make([]string, len(filter.Severity))
-				for i, s := range filter.Severity {
-					quoted[i] = fmt.Sprintf("%q", s)
-				}
-				fqParts = append(fqParts, fmt.Sprintf("case_severity:(%s)", strings.Join(quoted, " OR ")))
-			}
+			fqParts = append(fqParts, fieldClause("case_severity", filter.Severity))
 		}
 
 		// Product filter (supports multiple products)
 		if len(filter.Products) > 0 {
-			if len(filter.Products) == 1 {
-				fqParts = append(fqParts, fmt.Sprintf("case_product:%q", filter.Products[0]))
-			} else {
-				quoted := make([]string, len(filter.Products))
-				for i, p := range filter.Products {
-					quoted[i] = fmt.Sprintf("%q", p)
-				}
-				fqParts = append(fqParts, fmt.Sprintf("case_product:(%s)", strings.Join(quoted, " OR ")))
-			}
+			fqParts = append(fqParts, fieldClause("case_product", filter.Products))
 		}
 
 		// Account filter (supports multiple accounts)
 		if len(filter.Accounts) > 0 {
-			if len(filter.Accounts) == 1 {
-				fqParts = append(fqParts, fmt.Sprintf("case_accountNumber:%q", filter.Accounts[0]))
-			} else {
-				quoted := make([]string, len(filter.Accounts))
-				for i, a := range filter.Accounts {
-					quoted[i] = fmt.Sprintf("%q", a)
-				}
-				fqParts = append(fqParts, fmt.Sprintf("case_accountNumber:(%s)", strings.Join(quoted, " OR ")))
-			}
+			fqParts = append(fqParts, fieldClause("case_accountNumber", filter.Accounts))
 		}
 
 		// Group filter
 		if filter.GroupNumber != "" {
-			fqParts = append(fqParts, fmt.Sprintf("case_groupNumber:%q", filter.GroupNumber))
+			fqParts = append(fqParts, fieldClause("case_groupNumber", []string{filter.GroupNumber}))
 		}
 
 		// Owner filter
 		if filter.OwnerSSOName != "" {
-			fqParts = append(fqParts, fmt.Sprintf("case_owner:%q", filter.OwnerSSOName))
+			fqParts = append(fqParts, fieldClause("case_owner", []string{filter.OwnerSSOName}))
 		}
 
 		// Date range filters
@@ -169,8 +148,16 @@ func (c *Client) ListCases(ctx context.Context, filter *CaseFilter) (*ListRespon
 			fqParts = append(fqParts, fmt.Sprintf("case_createdDate:[* TO %s]", filter.EndDate.Format("2006-01-02T15:04:05Z")))
 		}
 
-		// Exclude closed by default unless IncludeClosed is true
-		if !filter.IncludeClosed && len(filter.Status) == 0 {
+		// Query language terms
+		if parsed != nil {
+			var fq []string
+			fq, queryText = parsed.Compile(time.Now())
+			fqParts = append(fqParts, fq...)
+		}
+
+		// Exclude closed by default unless IncludeClosed is true or the
+		// caller asked for particular statuses
+		if !filter.IncludeClosed && len(filter.Status) == 0 && (parsed == nil || !parsed.Has("status")) {
 			fqParts = append(fqParts, "-case_status:\"Closed\"")
 		}
 	}
@@ -190,6 +177,11 @@ func (c *Client) ListCases(ctx context.Context, filter *CaseFilter) (*ListRespon
 	query := "*:*"
 	if filter != nil && filter.Keyword != "" {
 		query = filter.Keyword
+		if queryText != "" {
+			query = "(" + query + ") AND " + queryText
+		}
+	} else if queryText != "" {
+		query = queryText
 	}
 
 	req := HydraSearchRequest{
@@ -227,6 +219,7 @@ func (c *Client) ListCases(ctx context.Context, filter *CaseFilter) (*ListRespon
 			Severity:      doc.CaseSeverity,
 			Product:       product,
 			Version:       version,
+			Owner:         doc.CaseOwner,
 			AccountNumber: doc.CaseAccountNum,
 			ContactName:   doc.CaseContactName,
 		}
@@ -263,6 +256,113 @@ func (c *Client) GetCase(ctx context.Context, caseNumber string) (*Case, error)
 	return &result, nil
 }
 
+// CreateCase opens a new support case and returns it as stored by the portal
+func (c *Client) CreateCase(ctx context.Context, req *CreateCaseRequest) (*Case, error) {
+	if req == nil {
+		return nil, fmt.Errorf("create case request is required")
+	}
+	if strings.TrimSpace(req.Summary) == "" {
+		return nil, fmt.Errorf("summary is required")
+	}
+	if strings.TrimSpace(req.Description) == "" {
+		return nil, fmt.Errorf("description is required")
+	}
+	if strings.TrimSpace(req.Product) == "" || strings.TrimSpace(req.Version) == "" {
+		return nil, fmt.Errorf("product and version are required")
+	}
+
+	// The portal answers 201 with the new case's URI, ending in the case
+	// number, in the Location header; the body is often empty. Some
+	// deployments also return the number or location in a JSON body.
+	var created struct {
+		CaseNumber string `json:"caseNumber"`
+		Location   string `json:"location"`
+	}
+	header, err := c.sendHeader(ctx, http.MethodPost, "/support/v1/cases", req, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	caseNumber := created.CaseNumber
+	location := header.Get("Location")
+	if location == "" {
+		location = created.Location
+	}
+	if caseNumber == "" && location != "" {
+		caseNumber = path.Base(strings.TrimRight(location, "/"))
+	}
+	if caseNumber == "" {
+		return nil, fmt.Errorf("case created but no case number was returned")
+	}
+
+	// Fetch the full case; fall back to what we submitted so the caller
+	// still learns the new case number if the follow-up read fails
+	cs, err := c.GetCase(ctx, caseNumber)
+	if err != nil {
+		return &Case{
+			CaseNumber:    caseNumber,
+			Summary:       req.Summary,
+			Description:   req.Description,
+			Product:       req.Product,
+			Version:       req.Version,
+			Severity:      req.Severity,
+			Type:          req.Type,
+			AccountNumber: req.AccountNumber,
+		}, nil
+	}
+	return cs, nil
+}
+
+// UpdateCase changes case fields and returns the updated case.
+// Severity and status are resolved against GetCaseValues before the request
+// is sent, so "1" or "closed" may be given for "1 (Urgent)" or "Closed".
+func (c *Client) UpdateCase(ctx context.Context, caseNumber string, update *CaseUpdate) (*Case, error) {
+	if update.IsEmpty() {
+		return nil, fmt.Errorf("no case fields to update")
+	}
+
+	resolved := *update
+	if update.Severity != "" || update.Status != "" {
+		values, err := c.GetCaseValues(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get case values: %w", err)
+		}
+		if update.Severity != "" {
+			if resolved.Severity, err = ResolveCaseValue("severity", update.Severity, values.Severities); err != nil {
+				return nil, err
+			}
+		}
+		if update.Status != "" {
+			if resolved.Status, err = ResolveCaseValue("status", update.Status, values.Statuses); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := c.put(ctx, fmt.Sprintf("/support/v1/cases/%s", caseNumber), &resolved, nil); err != nil {
+		return nil, err
+	}
+
+	return c.GetCase(ctx, caseNumber)
+}
+
+// ResolveCaseValue matches input against a field's allowed values,
+// ignoring case or by the leading number of values like "3 (Normal)"
+func ResolveCaseValue(field, input string, allowed []string) (string, error) {
+	input = strings.TrimSpace(input)
+	for _, v := range allowed {
+		if strings.EqualFold(v, input) {
+			return v, nil
+		}
+	}
+	for _, v := range allowed {
+		if num, _, ok := strings.Cut(v, " "); ok && num == input {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("invalid %s %q (valid: %s)", field, input, strings.Join(allowed, ", "))
+}
+
 // FilterCases performs advanced case filtering using POST
 // This is now the same as ListCases with the new API
 func (c *Client) FilterCases(ctx context.Context, filter *CaseFilter) (*ListResponse[Case], error) {
@@ -276,17 +376,6 @@ func (c *Client) GetCaseComments(ctx context.Context, caseNumber string) ([]Comm
 		return nil, err
 	}
 
-	// Debug: write first comment's raw JSON to see field names
-	if c.debug && c.debugFile != nil && len(body) > 0 {
-		// Parse as generic JSON to see actual structure
-		var raw []map[string]interface{}
-		if json.Unmarshal(body, &raw) == nil && len(raw) > 0 {
-			if sample, err := json.MarshalIndent(raw[0], "", "  "); err == nil {
-				_, _ = fmt.Fprintf(c.debugFile, "  Sample comment JSON:\n%s\n", string(sample))
-			}
-		}
-	}
-
 	// Try unwrapped array format first (API returns raw array)
 	var comments []Comment
 	if err := json.Unmarshal(body, &comments); err == nil {
@@ -303,6 +392,36 @@ func (c *Client) GetCaseComments(ctx context.Context, caseNumber string) ([]Comm
 	return wrapped.Comments, nil
 }
 
+// AddComment posts a public comment to a case and returns the stored comment
+func (c *Client) AddComment(ctx context.Context, caseNumber, text string) (*Comment, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("comment text is required")
+	}
+
+	req := struct {
+		CommentBody string `json:"commentBody"`
+		ContentType string `json:"contentType"`
+		IsDraft     bool   `json:"isDraft"`
+	}{
+		CommentBody: text,
+		ContentType: "plaintext",
+	}
+
+	var comment Comment
+	if err := c.post(ctx, fmt.Sprintf("/support/v1/cases/%s/comments", caseNumber), req, &comment); err != nil {
+		return nil, err
+	}
+
+	// Some deployments answer 201 with no body
+	if comment.GetText() == "" {
+		comment.CommentBody = text
+		comment.CreatedDate = time.Now()
+	}
+	comment.CaseNumber = caseNumber
+	comment.Public = true
+	return &comment, nil
+}
+
 // GetCaseAttachments retrieves all attachments for a case
 func (c *Client) GetCaseAttachments(ctx context.Context, caseNumber string) ([]Attachment, error) {
 	body, err := c.getRaw(ctx, fmt.Sprintf("/support/v1/cases/%s/attachments", caseNumber), nil)
@@ -0,0 +1,245 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package api_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/green/agcm/internal/api"
+	"github.com/green/agcm/internal/apitest"
+)
+
+// newTestPortal starts a fake portal with the default fixtures and returns
+// a client for it
+func newTestPortal(t *testing.T) (*apitest.Fixtures, *api.Client) {
+	t.Helper()
+	fx := apitest.DefaultFixtures(time.Now())
+	srv := apitest.NewServer(fx)
+	t.Cleanup(srv.Close)
+	return fx, srv.NewClient(api.WithRateLimit(0, 0))
+}
+
+func TestListCasesFilters(t *testing.T) {
+	fx, client := newTestPortal(t)
+	open := func(c apitest.Case) bool { return c.Status != "Closed" }
+
+	tests := []struct {
+		name   string
+		filter api.CaseFilter
+		want   func(c apitest.Case) bool
+	}{
+		{"default excludes closed", api.CaseFilter{}, open},
+		{"include closed", api.CaseFilter{IncludeClosed: true}, func(apitest.Case) bool { return true }},
+		{"status", api.CaseFilter{Status: []string{"Closed"}}, func(c apitest.Case) bool { return c.Status == "Closed" }},
+		{"severity", api.CaseFilter{Severity: []string{"1 (Urgent)", "2 (High)"}}, func(c apitest.Case) bool {
+			return open(c) && (c.Severity == "1 (Urgent)" || c.Severity == "2 (High)")
+		}},
+		{"account", api.CaseFilter{Accounts: []string{"5550202"}, IncludeClosed: true}, func(c apitest.Case) bool {
+			return c.AccountNumber == "5550202"
+		}},
+		{"product", api.CaseFilter{Products: []string{"Red Hat Enterprise Linux"}}, func(c apitest.Case) bool {
+			return open(c) && c.Product == "Red Hat Enterprise Linux"
+		}},
+		{"group", api.CaseFilter{GroupNumber: fx.Cases[1].GroupNumber}, func(c apitest.Case) bool {
+			return open(c) && c.GroupNumber == fx.Cases[1].GroupNumber
+		}},
+		{"query", api.CaseFilter{Query: "severity:1,2 -product:\"Red Hat Enterprise Linux\""}, func(c apitest.Case) bool {
+			return open(c) && (c.Severity == "1 (Urgent)" || c.Severity == "2 (High)") && c.Product != "Red Hat Enterprise Linux"
+		}},
+		{"query status replaces default", api.CaseFilter{Query: "status:closed"}, func(c apitest.Case) bool {
+			return c.Status == "Closed"
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var want []string
+			for _, c := range fx.Cases {
+				if tt.want(c) {
+					want = append(want, c.CaseNumber)
+				}
+			}
+			if len(want) == 0 {
+				t.Fatal("no fixture cases match; the test checks nothing")
+			}
+
+			filter := tt.filter
+			filter.Count = 100
+			result, err := client.ListCases(context.Background(), &filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, c := range result.Items {
+				got = append(got, c.CaseNumber)
+			}
+			slices.Sort(got)
+			slices.Sort(want)
+			if !slices.Equal(got, want) {
+				t.Errorf("got %d cases %v, want %d %v", len(got), got, len(want), want)
+			}
+			if result.TotalCount != len(want) {
+				t.Errorf("TotalCount = %d, want %d", result.TotalCount, len(want))
+			}
+		})
+	}
+}
+
+func TestListCasesPaging(t *testing.T) {
+	fx, client := newTestPortal(t)
+	ctx := context.Background()
+
+	first, err := client.ListCases(ctx, &api.CaseFilter{IncludeClosed: true, Count: 7, StartIndex: 14})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.Items) != 7 || first.StartIndex != 14 || first.TotalCount != len(fx.Cases) {
+		t.Errorf("page = %d items from %d of %d, want 7 from 14 of %d",
+			len(first.Items), first.StartIndex, first.TotalCount, len(fx.Cases))
+	}
+
+	it := client.IterateCases(ctx, &api.CaseFilter{IncludeClosed: true, Count: 7})
+	var all []api.Case
+	pages := 0
+	for it.Next() {
+		pages++
+		all = append(all, it.Page()...)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != len(fx.Cases) || pages != (len(fx.Cases)+6)/7 {
+		t.Fatalf("iterated %d cases in %d pages, want %d", len(all), pages, len(fx.Cases))
+	}
+	seen := make(map[string]bool)
+	for i, c := range all {
+		if seen[c.CaseNumber] {
+			t.Errorf("case %s listed twice", c.CaseNumber)
+		}
+		seen[c.CaseNumber] = true
+		if i > 0 && c.LastModified.After(all[i-1].LastModified) {
+			t.Errorf("case %s is out of order: newest modified should come first", c.CaseNumber)
+		}
+	}
+	if !slices.EqualFunc(all[14:21], first.Items, func(a, b api.Case) bool { return a.CaseNumber == b.CaseNumber }) {
+		t.Error("paged listing does not match the offset page")
+	}
+
+	limited, err := client.IterateCases(ctx, &api.CaseFilter{Count: 4}).Limit(10).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(limited) != 10 {
+		t.Errorf("Limit(10) returned %d cases", len(limited))
+	}
+}
+
+// TestListCasesColumns checks that listed cases carry every field a
+// column shows
+func TestListCasesColumns(t *testing.T) {
+	fx, client := newTestPortal(t)
+	owners := make(map[string]string)
+	for _, c := range fx.Cases {
+		owners[c.CaseNumber] = c.Owner
+	}
+
+	result, err := client.ListCases(context.Background(), &api.CaseFilter{IncludeClosed: true, Count: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Items) == 0 {
+		t.Fatal("no cases listed")
+	}
+	now := time.Now()
+	for _, c := range result.Items {
+		for _, col := range api.CaseColumns() {
+			if col.Value(&c, now) == "" {
+				t.Errorf("case %s has an empty %s column", c.CaseNumber, col.Name)
+			}
+		}
+		if c.Owner != owners[c.CaseNumber] {
+			t.Errorf("case %s owner = %q, want %q", c.CaseNumber, c.Owner, owners[c.CaseNumber])
+		}
+	}
+}
+
+func TestCaseRoundTrip(t *testing.T) {
+	_, client := newTestPortal(t)
+	ctx := context.Background()
+
+	created, err := client.CreateCase(ctx, &api.CreateCaseRequest{
+		Summary:     "Kernel oops on boot",
+		Description: "The host oopses in the NVMe driver after updating.",
+		Product:     "Red Hat Enterprise Linux",
+		Version:     "9.4",
+		Severity:    "3 (Normal)",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.CaseNumber == "" || created.Summary != "Kernel oops on boot" || created.Status != "Waiting on Red Hat" {
+		t.Fatalf("created case = %+v", created)
+	}
+
+	fetched, err := client.GetCase(ctx, created.CaseNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fetched.Description != created.Description || fetched.Version != "9.4" {
+		t.Errorf("fetched case = %+v", fetched)
+	}
+
+	updated, err := client.UpdateCase(ctx, created.CaseNumber, &api.CaseUpdate{Severity: "2", Status: "waiting on customer"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Severity != "2 (High)" || updated.Status != "Waiting on Customer" {
+		t.Errorf("updated severity %q, status %q", updated.Severity, updated.Status)
+	}
+	if _, err := client.UpdateCase(ctx, created.CaseNumber, &api.CaseUpdate{Severity: "7"}); err == nil || !strings.Contains(err.Error(), "invalid severity") {
+		t.Errorf("invalid severity error = %v", err)
+	}
+
+	if _, err := client.AddComment(ctx, created.CaseNumber, "Attached the vmcore."); err != nil {
+		t.Fatal(err)
+	}
+	comments, err := client.GetCaseComments(ctx, created.CaseNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.ContainsFunc(comments, func(c api.Comment) bool { return c.GetText() == "Attached the vmcore." }) {
+		t.Errorf("comment not returned: %+v", comments)
+	}
+
+	// The new case leads the listing, as the most recently modified
+	result, err := client.ListCases(ctx, &api.CaseFilter{Count: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Items) != 1 || result.Items[0].CaseNumber != created.CaseNumber {
+		t.Errorf("first listed case = %+v, want %s", result.Items, created.CaseNumber)
+	}
+}
+
+func TestCaseErrors(t *testing.T) {
+	fx, client := newTestPortal(t)
+	ctx := context.Background()
+
+	_, err := client.GetCase(ctx, "01234567")
+	if !api.IsNotFound(err) {
+		t.Errorf("missing case error = %v, want not found", err)
+	}
+	_, err = client.GetCase(ctx, fx.Forbidden[0])
+	if !api.IsForbidden(err) {
+		t.Errorf("forbidden case error = %v, want forbidden", err)
+	}
+	var apiErr *api.APIError
+	_, err = client.CreateCase(ctx, &api.CreateCaseRequest{Summary: "s", Description: "d", Product: "No Such Product", Version: "1"})
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 400 {
+		t.Errorf("unknown product error = %v, want a 400 APIError", err)
+	}
+}
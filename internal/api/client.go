@@ -8,27 +8,36 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/green/agcm/internal/logging"
 )
 
 const (
-	DefaultBaseURL = "https://api.access.redhat.com"
-	DefaultTimeout = 30 * time.Second
+	DefaultBaseURL  = "https://api.access.redhat.com"
+	DefaultHydraURL = "https://access.redhat.com"
+	DefaultTimeout  = 30 * time.Second
 )
 
 // Client is the Red Hat Customer Portal API client
 type Client struct {
 	baseURL    string
+	hydraURL   string
 	httpClient *http.Client
 	token      string
 	tokenMu    sync.RWMutex
-	debug      bool
-	debugFile  *os.File
+	logger     *slog.Logger
+	trace      *Trace
+	retry      RetryPolicy
+	limiter    *limiter
 
 	// TokenRefresher is called when a new access token is needed
 	TokenRefresher func(ctx context.Context) (string, error)
@@ -44,6 +53,15 @@ func WithBaseURL(url string) ClientOption {
 	}
 }
 
+// WithHydraURL sets the base URL of the Hydra search API used for case
+// listing and search. When unset, it follows a custom base URL so a staging
+// portal or local mock serves both APIs.
+func WithHydraURL(url string) ClientOption {
+	return func(c *Client) {
+		c.hydraURL = strings.TrimSuffix(url, "/")
+	}
+}
+
 // WithHTTPClient sets a custom HTTP client
 func WithHTTPClient(hc *http.Client) ClientOption {
 	return func(c *Client) {
@@ -65,16 +83,11 @@ func WithTokenRefresher(fn func(ctx context.Context) (string, error)) ClientOpti
 	}
 }
 
-// WithDebug enables debug output to /tmp/agcm-debug.log
-func WithDebug(debug bool) ClientOption {
+// WithLogger sets the logger for request timing, retries and failures.
+// Use logging.New so tokens and email addresses are redacted.
+func WithLogger(l *slog.Logger) ClientOption {
 	return func(c *Client) {
-		c.debug = debug
-		if debug {
-			f, err := os.OpenFile("/tmp/agcm-debug.log", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
-			if err == nil {
-				c.debugFile = f
-			}
-		}
+		c.logger = l
 	}
 }
 
@@ -85,18 +98,36 @@ func NewClient(opts ...ClientOption) *Client {
 		httpClient: &http.Client{
 			Timeout: DefaultTimeout,
 		},
+		logger:  logging.Discard(),
+		retry:   DefaultRetryPolicy(),
+		limiter: newLimiter(DefaultMaxConcurrent, DefaultRequestsPerSecond),
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
+
+	// Log and trace below the retry loop so every attempt is recorded
+	hc := *c.httpClient
+	base := hc.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	hc.Transport = &transport{base: base, logger: c.logger, trace: c.trace}
+	c.httpClient = &hc
+	if c.hydraURL == "" {
+		c.hydraURL = DefaultHydraURL
+		if c.baseURL != DefaultBaseURL {
+			c.hydraURL = c.baseURL
+		}
+	}
 	return c
 }
 
-// Close releases resources held by the client
+// Close writes the trace file, if one was requested
 func (c *Client) Close() error {
-	if c.debugFile != nil {
-		err := c.debugFile.Close()
-		c.debugFile = nil
+	if c.trace != nil {
+		err := c.trace.Close()
+		c.trace = nil
 		return err
 	}
 	return nil
@@ -128,12 +159,13 @@ func (c *Client) getToken(ctx context.Context) (string, error) {
 
 // do performs an HTTP request with authentication
 func (c *Client) do(ctx context.Context, method, path string, query url.Values, body io.Reader) (*http.Response, error) {
-	token, err := c.getToken(ctx)
-	if err != nil {
-		return nil, err
-	}
+	return c.doAt(ctx, c.baseURL, method, path, query, body, isIdempotent(method))
+}
 
-	u := c.baseURL + path
+// doAt performs an HTTP request against baseURL. idempotent marks requests
+// that are safe to repeat after a failure.
+func (c *Client) doAt(ctx context.Context, baseURL, method, path string, query url.Values, body io.Reader, idempotent bool) (*http.Response, error) {
+	u := baseURL + path
 	if len(query) > 0 {
 		u += "?" + query.Encode()
 	}
@@ -142,62 +174,83 @@ func (c *Client) do(ctx context.Context, method, path string, query url.Values,
 	var bodyBytes []byte
 	if body != nil {
 		bodyBytes, _ = io.ReadAll(body)
-		body = bytes.NewReader(bodyBytes)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, method, u, body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Accept", "application/json")
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-
-	if c.debug && c.debugFile != nil {
-		_, _ = fmt.Fprintf(c.debugFile, "[%s] %s %s\n", time.Now().Format("15:04:05"), method, u)
-		if len(bodyBytes) > 0 {
-			_, _ = fmt.Fprintf(c.debugFile, "  Request: %s\n", string(bodyBytes))
-		}
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
+	return c.roundTrip(ctx, method, u, bodyBytes, body != nil, idempotent)
+}
 
-	// Handle token expiration
-	if resp.StatusCode == http.StatusUnauthorized && c.TokenRefresher != nil {
-		_ = resp.Body.Close()
-		newToken, err := c.TokenRefresher(ctx)
+// roundTrip sends a request through the client's rate limiter. A 401 triggers
+// one token refresh, and transient failures are retried per the retry policy.
+func (c *Client) roundTrip(ctx context.Context, method, u string, bodyBytes []byte, hasBody, idempotent bool) (*http.Response, error) {
+	refreshed := false
+	for attempt := 0; ; {
+		token, err := c.getToken(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to refresh token: %w", err)
+			return nil, err
 		}
-		c.SetToken(newToken)
 
-		// Retry with new token - recreate body reader since it was consumed
-		var retryBody io.Reader
-		if len(bodyBytes) > 0 {
-			retryBody = bytes.NewReader(bodyBytes)
+		var body io.Reader
+		if hasBody {
+			body = bytes.NewReader(bodyBytes)
 		}
-		req, err = http.NewRequestWithContext(ctx, method, u, retryBody)
+		req, err := http.NewRequestWithContext(ctx, method, u, body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create retry request: %w", err)
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
 		}
-		req.Header.Set("Authorization", "Bearer "+newToken)
 		req.Header.Set("Accept", "application/json")
-		if body != nil {
+		if hasBody {
 			req.Header.Set("Content-Type", "application/json")
 		}
-		resp, err = c.httpClient.Do(req)
+
+		if err := c.limiter.acquire(ctx); err != nil {
+			return nil, err
+		}
+		resp, err := c.httpClient.Do(req)
+		c.limiter.release()
+
+		// Handle token expiration
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && c.TokenRefresher != nil && !refreshed {
+			_ = resp.Body.Close()
+			newToken, err := c.TokenRefresher(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to refresh token: %w", err)
+			}
+			c.SetToken(newToken)
+			refreshed = true
+			continue
+		}
+
+		delay, retry := c.retryDelay(attempt, idempotent, resp, err)
+		if !retry || ctx.Err() != nil {
+			if err != nil {
+				return nil, fmt.Errorf("request failed: %w", err)
+			}
+			return resp, nil
+		}
+		attempt++
+
+		var reason string
 		if err != nil {
-			return nil, fmt.Errorf("retry request failed: %w", err)
+			reason = err.Error()
+		} else {
+			reason = resp.Status
+			if resp.StatusCode == http.StatusTooManyRequests {
+				// Slow down every request on this client, not just this one
+				c.limiter.pause(delay)
+			}
+			discard(resp)
 		}
-	}
+		c.logger.Info("retrying request", "method", method, "url", logging.Redact(u),
+			"attempt", attempt, "max_retries", c.retry.MaxRetries, "delay", delay.Round(time.Millisecond), "reason", reason)
 
-	return resp, nil
+		if err := sleepCtx(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
 }
 
 // get performs a GET request and decodes the response
@@ -226,38 +279,52 @@ func (c *Client) getRaw(ctx context.Context, path string, query url.Values) ([]b
 	body, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
-		if c.debug && c.debugFile != nil {
-			_, _ = fmt.Fprintf(c.debugFile, "  Response: %d %s\n", resp.StatusCode, string(body))
-		}
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body)
 	}
-
-	if c.debug && c.debugFile != nil {
-		preview := string(body)
-		if len(preview) > 500 {
-			preview = preview[:500] + "..."
-		}
-		_, _ = fmt.Fprintf(c.debugFile, "  Response: %d (%d bytes): %s\n", resp.StatusCode, len(body), preview)
-	}
-
 	return body, nil
 }
 
 // post performs a POST request and decodes the response
 func (c *Client) post(ctx context.Context, path string, requestBody interface{}, result interface{}) error {
+	return c.send(ctx, http.MethodPost, path, requestBody, result)
+}
+
+// put performs a PUT request and decodes the response
+func (c *Client) put(ctx context.Context, path string, requestBody interface{}, result interface{}) error {
+	return c.send(ctx, http.MethodPut, path, requestBody, result)
+}
+
+// send performs a request with a JSON body and decodes the response
+func (c *Client) send(ctx context.Context, method, path string, requestBody interface{}, result interface{}) error {
+	_, err := c.sendHeader(ctx, method, path, requestBody, result)
+	return err
+}
+
+// sendHeader is send that also returns the response headers, for endpoints
+// that answer in a header such as Location
+func (c *Client) sendHeader(ctx context.Context, method, path string, requestBody interface{}, result interface{}) (http.Header, error) {
 	var body io.Reader
 	if requestBody != nil {
 		jsonBytes, err := json.Marshal(requestBody)
 		if err != nil {
-			return fmt.Errorf("failed to encode request body: %w", err)
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
 		}
 		body = bytes.NewReader(jsonBytes)
 	}
 
-	resp, err := c.do(ctx, http.MethodPost, path, nil, body)
+	resp, err := c.do(ctx, method, path, nil, body)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	if err := c.decodeResponse(resp, result); err != nil {
+		return nil, err
+	}
+	return resp.Header, nil
+}
+
+// decodeResponse reads a response, returning an APIError for non-2xx
+// statuses and decoding any body into result
+func (c *Client) decodeResponse(resp *http.Response, result interface{}) error {
 	defer func() { _ = resp.Body.Close() }()
 
 	respBody, err := io.ReadAll(resp.Body)
@@ -266,21 +333,11 @@ func (c *Client) post(ctx context.Context, path string, requestBody interface{},
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		if c.debug && c.debugFile != nil {
-			_, _ = fmt.Fprintf(c.debugFile, "  Response: %d %s\n", resp.StatusCode, string(respBody))
-		}
-		return fmt.Errorf("API error %d: %s", resp.StatusCode, string(respBody))
-	}
-
-	if c.debug && c.debugFile != nil {
-		preview := string(respBody)
-		if len(preview) > 500 {
-			preview = preview[:500] + "..."
-		}
-		_, _ = fmt.Fprintf(c.debugFile, "  Response: %d (%d bytes): %s\n", resp.StatusCode, len(respBody), preview)
+		return newAPIError(resp, respBody)
 	}
 
-	if result != nil {
+	// Write endpoints may answer 201/204 with an empty body
+	if result != nil && len(bytes.TrimSpace(respBody)) > 0 {
 		if err := json.Unmarshal(respBody, result); err != nil {
 			return fmt.Errorf("failed to decode response: %w", err)
 		}
@@ -288,102 +345,202 @@ func (c *Client) post(ctx context.Context, path string, requestBody interface{},
 	return nil
 }
 
-// postHydra performs a POST request to the Hydra API (different base URL)
+// postHydra performs a POST request to the Hydra search API, which has its
+// own base URL. Searches are read-only, so they are safe to retry.
 func (c *Client) postHydra(ctx context.Context, path string, body io.Reader, result interface{}) error {
-	// Hydra API uses access.redhat.com instead of api.access.redhat.com
-	hydraURL := "https://access.redhat.com" + path
-
-	var bodyBytes []byte
-	if body != nil && c.debug {
-		bodyBytes, _ = io.ReadAll(body)
-		body = bytes.NewReader(bodyBytes)
+	resp, err := c.doAt(ctx, c.hydraURL, http.MethodPost, path, nil, body, true)
+	if err != nil {
+		return err
 	}
+	return c.decodeResponse(resp, result)
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hydraURL, body)
+// DownloadAttachment downloads an attachment and returns the content
+func (c *Client) DownloadAttachment(ctx context.Context, caseNumber, uuid string) (io.ReadCloser, string, error) {
+	path := fmt.Sprintf("/support/v1/cases/%s/attachments/%s", caseNumber, uuid)
+	resp, err := c.do(ctx, http.MethodGet, path, nil, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, "", err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-
-	// Get token
-	c.tokenMu.RLock()
-	token := c.token
-	c.tokenMu.RUnlock()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		_ = resp.Body.Close()
+		return nil, "", fmt.Errorf("failed to download attachment: %w", newAPIError(resp, body))
+	}
 
-	if token == "" && c.TokenRefresher != nil {
-		var err error
-		token, err = c.TokenRefresher(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to refresh token: %w", err)
+	filename := ""
+	if cd := resp.Header.Get("Content-Disposition"); cd != "" {
+		if i := strings.Index(cd, "filename="); i != -1 {
+			filename = strings.Trim(cd[i+9:], `"`)
 		}
-		c.tokenMu.Lock()
-		c.token = token
-		c.tokenMu.Unlock()
 	}
 
-	if token != "" {
-		req.Header.Set("Authorization", "Bearer "+token)
-	}
+	return resp.Body, filename, nil
+}
 
-	if c.debug && c.debugFile != nil {
-		_, _ = fmt.Fprintf(c.debugFile, "[%s] POST %s\n", time.Now().Format("15:04:05"), hydraURL)
-		if len(bodyBytes) > 0 {
-			_, _ = fmt.Fprintf(c.debugFile, "  Request: %s\n", string(bodyBytes))
-		}
+// UploadProgress is called as attachment bytes are sent
+type UploadProgress func(sent, total int64)
+
+// UploadAttachment streams a file to a case as a multipart upload.
+// The file is read while the request is sent, so large sosreports and
+// must-gathers are never held in memory. Uploads do not resume: every
+// attempt sends the whole file.
+func (c *Client) UploadAttachment(ctx context.Context, caseNumber, filePath string, progress UploadProgress) (*Attachment, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", filePath, err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s is not a regular file", filePath)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	path := fmt.Sprintf("/support/v1/cases/%s/attachments", caseNumber)
+	resp, err := c.sendFile(ctx, path, filePath, info.Size(), progress)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	if c.debug && c.debugFile != nil {
-		preview := string(respBody)
-		if len(preview) > 500 {
-			preview = preview[:500] + "..."
-		}
-		_, _ = fmt.Fprintf(c.debugFile, "  Response: %d (%d bytes): %s\n", resp.StatusCode, len(respBody), preview)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, newAPIError(resp, respBody)
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("hydra API error %d: %s", resp.StatusCode, string(respBody))
+	// The portal answers with the attachment, a one-element list, or nothing
+	att := Attachment{}
+	var list []Attachment
+	if err := json.Unmarshal(respBody, &list); err == nil && len(list) > 0 {
+		att = list[0]
+	} else {
+		_ = json.Unmarshal(respBody, &att)
+	}
+	if att.Filename == "" {
+		att.Filename = filepath.Base(filePath)
 	}
+	if att.Length == 0 {
+		att.Length = info.Size()
+	}
+	return &att, nil
+}
 
-	if result != nil {
-		if err := json.Unmarshal(respBody, result); err != nil {
-			return fmt.Errorf("failed to decode response: %w", err)
+// sendFile uploads a file like roundTrip sends other requests: through the
+// rate limiter, refreshing the token once on a 401, and retrying per the
+// retry policy. As a POST only a rejected 429 is retried, never a failure
+// partway through. Each attempt re-reads the file from the start.
+func (c *Client) sendFile(ctx context.Context, path, filePath string, size int64, progress UploadProgress) (*http.Response, error) {
+	refreshed := false
+	for attempt := 0; ; {
+		token, err := c.getToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := c.limiter.acquire(ctx); err != nil {
+			return nil, err
+		}
+		resp, err := c.uploadFile(ctx, path, token, filePath, size, progress)
+		c.limiter.release()
+
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && c.TokenRefresher != nil && !refreshed {
+			_ = resp.Body.Close()
+			newToken, err := c.TokenRefresher(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to refresh token: %w", err)
+			}
+			c.SetToken(newToken)
+			refreshed = true
+			continue
+		}
+
+		delay, retry := c.retryDelay(attempt, false, resp, err)
+		if !retry || ctx.Err() != nil {
+			return resp, err
+		}
+		attempt++
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			c.limiter.pause(delay)
+		}
+		discard(resp)
+		c.logger.Info("retrying upload", "file", filepath.Base(filePath), "attempt", attempt,
+			"max_retries", c.retry.MaxRetries, "delay", delay.Round(time.Millisecond), "reason", resp.Status)
+
+		if err := sleepCtx(ctx, delay); err != nil {
+			return nil, err
 		}
 	}
-	return nil
 }
 
-// DownloadAttachment downloads an attachment and returns the content
-func (c *Client) DownloadAttachment(ctx context.Context, caseNumber, uuid string) (io.ReadCloser, string, error) {
-	path := fmt.Sprintf("/support/v1/cases/%s/attachments/%s", caseNumber, uuid)
-	resp, err := c.do(ctx, http.MethodGet, path, nil, nil)
+// uploadFile sends a single multipart request, streaming the file through a pipe
+func (c *Client) uploadFile(ctx context.Context, path, token, filePath string, size int64, progress UploadProgress) (*http.Response, error) {
+	f, err := os.Open(filePath)
 	if err != nil {
-		return nil, "", err
+		return nil, fmt.Errorf("failed to open %s: %w", filePath, err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		_ = resp.Body.Close()
-		return nil, "", fmt.Errorf("failed to download attachment: status %d", resp.StatusCode)
-	}
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
 
-	filename := ""
-	if cd := resp.Header.Get("Content-Disposition"); cd != "" {
-		if i := strings.Index(cd, "filename="); i != -1 {
-			filename = strings.Trim(cd[i+9:], `"`)
+	go func() {
+		defer func() { _ = f.Close() }()
+
+		part, err := mw.CreateFormFile("file", filepath.Base(filePath))
+		if err != nil {
+			_ = pw.CloseWithError(err)
+			return
+		}
+		var src io.Reader = f
+		if progress != nil {
+			src = &progressReader{r: f, total: size, progress: progress}
+		}
+		if _, err := io.Copy(part, src); err != nil {
+			_ = pw.CloseWithError(err)
+			return
 		}
+		_ = pw.CloseWithError(mw.Close())
+	}()
+
+	u := c.baseURL + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, pr)
+	if err != nil {
+		_ = pr.Close()
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", mw.FormDataContentType())
 
-	return resp.Body, filename, nil
+	c.logger.Debug("uploading attachment", "url", u, "file", filepath.Base(filePath), "bytes", size)
+
+	// Uploads can far outlast the client's default timeout; rely on ctx instead
+	hc := *c.httpClient
+	hc.Timeout = 0
+	resp, err := hc.Do(req)
+	if err != nil {
+		_ = pr.Close()
+		return nil, fmt.Errorf("upload failed: %w", err)
+	}
+	return resp, nil
+}
+
+// progressReader reports bytes read to an UploadProgress callback
+type progressReader struct {
+	r        io.Reader
+	sent     int64
+	total    int64
+	progress UploadProgress
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.sent += int64(n)
+		p.progress(p.sent, p.total)
+	}
+	return n, err
 }
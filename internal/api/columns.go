@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package api
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Column is a case field shown in the TUI case list and the list cases table
+type Column struct {
+	Name  string // Name used in config and --columns
+	Title string // Header
+	Width int    // Cells wide; 0 shares the space left by the other columns
+	Flex  int    // Share of the left-over space taken by a 0-width column
+	Min   int    // Narrowest a 0-width column is squeezed to
+
+	value func(c *Case, now time.Time) string
+}
+
+// Value renders the column for a case
+func (col Column) Value(c *Case, now time.Time) string {
+	return col.value(c, now)
+}
+
+// Spec returns the column as written in config: the name, with the width
+// when it differs from the default
+func (col Column) Spec() string {
+	if def, ok := LookupColumn(col.Name); ok && def.Width == col.Width {
+		return col.Name
+	}
+	return col.Name + ":" + strconv.Itoa(col.Width)
+}
+
+// caseColumns lists every column in the order the column chooser shows them
+var caseColumns = []Column{
+	{Name: "case", Title: "CASE", Width: 10, value: func(c *Case, _ time.Time) string { return c.CaseNumber }},
+	{Name: "modified", Title: "MODIFIED", Width: 12, value: func(c *Case, _ time.Time) string { return columnDate(c.LastModified) }},
+	{Name: "created", Title: "CREATED", Width: 12, value: func(c *Case, _ time.Time) string { return columnDate(c.CreatedDate) }},
+	{Name: "age", Title: "AGE", Width: 4, value: func(c *Case, now time.Time) string { return columnAge(c.CreatedDate, now) }},
+	{Name: "severity", Title: "SEV", Width: 4, value: func(c *Case, _ time.Time) string { return shortSeverity(c.Severity) }},
+	{Name: "status", Title: "STATUS", Width: 20, value: func(c *Case, _ time.Time) string { return c.Status }},
+	{Name: "product", Title: "PRODUCT", Flex: 1, Min: 15, value: func(c *Case, _ time.Time) string { return c.Product }},
+	{Name: "version", Title: "VERSION", Width: 8, value: func(c *Case, _ time.Time) string { return c.Version }},
+	{Name: "owner", Title: "OWNER", Width: 16, value: func(c *Case, _ time.Time) string { return c.Owner }},
+	{Name: "account", Title: "ACCOUNT", Width: 10, value: func(c *Case, _ time.Time) string { return c.AccountNumber }},
+	{Name: "contact", Title: "CONTACT", Width: 20, value: func(c *Case, _ time.Time) string { return c.ContactName }},
+	{Name: "summary", Title: "SUMMARY", Flex: 3, Min: 10, value: func(c *Case, _ time.Time) string { return c.Summary }},
+}
+
+// Other names accepted for some columns
+var columnAliases = map[string]string{
+	"number":  "case",
+	"sev":     "severity",
+	"updated": "modified",
+}
+
+// Default columns of the TUI case list and of list cases
+var (
+	DefaultListColumns  = []string{"case", "modified", "severity", "status", "summary"}
+	DefaultTableColumns = []string{"case", "severity", "status", "product", "summary"}
+)
+
+// CaseColumns returns every column
+func CaseColumns() []Column {
+	return slices.Clone(caseColumns)
+}
+
+// ColumnNames returns the names of every column
+func ColumnNames() []string {
+	names := make([]string, len(caseColumns))
+	for i, col := range caseColumns {
+		names[i] = col.Name
+	}
+	return names
+}
+
+// LookupColumn returns a column by name or alias
+func LookupColumn(name string) (Column, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if canonical, ok := columnAliases[name]; ok {
+		name = canonical
+	}
+	for _, col := range caseColumns {
+		if col.Name == name {
+			return col, true
+		}
+	}
+	return Column{}, false
+}
+
+// ParseColumns parses column specs such as "case", "owner:20" or
+// "case,owner:20,summary". A width fixes the column at that many cells.
+func ParseColumns(specs []string) ([]Column, error) {
+	var cols []Column
+	for _, spec := range specs {
+		for _, field := range strings.Split(spec, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			name, width, hasWidth := strings.Cut(field, ":")
+			col, ok := LookupColumn(name)
+			if !ok {
+				return nil, fmt.Errorf("unknown column %q (valid: %s)", name, strings.Join(ColumnNames(), ", "))
+			}
+			if hasWidth {
+				n, err := strconv.Atoi(strings.TrimSpace(width))
+				if err != nil || n < 1 {
+					return nil, fmt.Errorf("column %s: width %q is not a positive number", col.Name, width)
+				}
+				col.Width = n
+			}
+			if slices.ContainsFunc(cols, func(c Column) bool { return c.Name == col.Name }) {
+				return nil, fmt.Errorf("column %s listed twice", col.Name)
+			}
+			cols = append(cols, col)
+		}
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("no columns given (valid: %s)", strings.Join(ColumnNames(), ", "))
+	}
+	return cols, nil
+}
+
+// ColumnSpecs returns the specs that ParseColumns turns back into cols
+func ColumnSpecs(cols []Column) []string {
+	specs := make([]string, len(cols))
+	for i, col := range cols {
+		specs[i] = col.Spec()
+	}
+	return specs
+}
+
+// LayoutColumns sizes columns to fill width with gap cells between them.
+// Fixed columns keep their width; 0-width columns split what is left by
+// Flex, but never shrink below Min.
+func LayoutColumns(cols []Column, width, gap int) []int {
+	widths := make([]int, len(cols))
+	left := width - gap*max(len(cols)-1, 0)
+	flex := 0
+	for i, col := range cols {
+		if col.Width > 0 {
+			widths[i] = col.Width
+			left -= col.Width
+		} else {
+			flex += max(col.Flex, 1)
+		}
+	}
+	for i, col := range cols {
+		if col.Width > 0 {
+			continue
+		}
+		share := max(col.Flex, 1)
+		widths[i] = max(max(left, 0)*share/flex, col.Min, 1)
+		left -= widths[i]
+		flex -= share
+	}
+	return widths
+}
+
+// TruncateColumn shortens s to width cells, marking the cut with an ellipsis
+func TruncateColumn(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + "…"
+}
+
+func columnDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("Jan 02 2006")
+}
+
+// columnAge is a compact age such as 45m, 6h, 3d, 5w or 2y
+func columnAge(created, now time.Time) string {
+	if created.IsZero() {
+		return ""
+	}
+	age := max(now.Sub(created), 0)
+	switch {
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	case age < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	case age < 14*24*time.Hour:
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	case age < 365*24*time.Hour:
+		return fmt.Sprintf("%dw", int(age.Hours()/(24*7)))
+	}
+	return fmt.Sprintf("%dy", int(age.Hours()/(24*365)))
+}
+
+// shortSeverity is the severity number, e.g. "1" for "1 (Urgent)"
+func shortSeverity(severity string) string {
+	if severity == "" {
+		return ""
+	}
+	return severity[:1]
+}
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBody caps how much of an error response is kept
+const maxErrorBody = 4096
+
+// requestIDHeaders are the response headers that may carry a request ID
+var requestIDHeaders = []string{"X-Request-Id", "X-Rh-Request-Id", "X-Correlation-Id", "Trace-Id"}
+
+// APIError is returned when the portal answers with a non-success status
+type APIError struct {
+	StatusCode int
+	Method     string
+	Endpoint   string // Request path without the query string
+	RequestID  string // Portal request ID, useful when contacting support
+	Message    string // Error message parsed from the response body
+	Body       string // Raw response body, truncated
+}
+
+// Error implements error
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	s := fmt.Sprintf("API error %d: %s", e.StatusCode, msg)
+	if e.RequestID != "" {
+		s += " (request ID " + e.RequestID + ")"
+	}
+	return s
+}
+
+// newAPIError builds an APIError from a response and its body
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	e := &APIError{StatusCode: resp.StatusCode}
+	if req := resp.Request; req != nil {
+		e.Method = req.Method
+		if req.URL != nil {
+			e.Endpoint = req.URL.Path
+		}
+	}
+	e.RequestID = requestID(resp)
+	if len(body) > maxErrorBody {
+		body = body[:maxErrorBody]
+	}
+	e.Body = string(body)
+	e.Message = parseErrorMessage(body)
+	return e
+}
+
+// parseErrorMessage extracts a human readable message from the error bodies
+// the portal and Hydra return
+func parseErrorMessage(body []byte) string {
+	var payload struct {
+		Message          string `json:"message"`
+		Detail           string `json:"detail"`
+		Title            string `json:"title"`
+		Error            any    `json:"error"`
+		ErrorDescription string `json:"error_description"`
+		Errors           []struct {
+			Message string `json:"message"`
+			Detail  string `json:"detail"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		for _, s := range []string{payload.Message, payload.Detail, payload.ErrorDescription, payload.Title} {
+			if s != "" {
+				return s
+			}
+		}
+		for _, e := range payload.Errors {
+			if e.Message != "" {
+				return e.Message
+			}
+			if e.Detail != "" {
+				return e.Detail
+			}
+		}
+		switch v := payload.Error.(type) {
+		case string:
+			return v
+		case map[string]any:
+			if msg, ok := v["message"].(string); ok {
+				return msg
+			}
+		}
+		return ""
+	}
+
+	// Plain text errors are kept when short; HTML error pages are not useful
+	text := strings.TrimSpace(string(body))
+	if text == "" || strings.HasPrefix(text, "<") || len(text) > 200 || strings.Contains(text, "\n") {
+		return ""
+	}
+	return text
+}
+
+// StatusCode returns the HTTP status of an APIError in err's chain, or 0
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is a 404 from the portal
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsForbidden reports whether err is a 403 from the portal
+func IsForbidden(err error) bool {
+	return StatusCode(err) == http.StatusForbidden
+}
+
+// IsRateLimited reports whether err is a 429 from the portal
+func IsRateLimited(err error) bool {
+	return StatusCode(err) == http.StatusTooManyRequests
+}
+
+// IsAuth reports whether err is a 401 from the portal, meaning the token was rejected
+func IsAuth(err error) bool {
+	return StatusCode(err) == http.StatusUnauthorized
+}
+
+// caseError carries a user-facing message while keeping the original error
+type caseError struct {
+	msg string
+	err error
+}
+
+func (e *caseError) Error() string { return e.msg }
+func (e *caseError) Unwrap() error { return e.err }
+
+// CaseError describes a failure to fetch caseNumber in terms a user can act on
+func CaseError(caseNumber string, err error) error {
+	var msg string
+	switch code := StatusCode(err); {
+	case code == http.StatusNotFound:
+		msg = fmt.Sprintf("case %s not found", caseNumber)
+	case code == http.StatusForbidden:
+		msg = fmt.Sprintf("case %s not visible to your account", caseNumber)
+	case code == http.StatusUnauthorized:
+		msg = fmt.Sprintf("not authorized to read case %s; run 'agcm auth login'", caseNumber)
+	case code == http.StatusTooManyRequests:
+		msg = fmt.Sprintf("rate limited fetching case %s; try again shortly", caseNumber)
+	case code >= 500:
+		msg = fmt.Sprintf("Customer Portal unavailable fetching case %s (status %d); try again later", caseNumber, code)
+	default:
+		return fmt.Errorf("failed to get case %s: %w", caseNumber, err)
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RequestID != "" {
+		msg += " (request ID " + apiErr.RequestID + ")"
+	}
+	return &caseError{msg: msg, err: err}
+}
@@ -90,10 +90,19 @@ func (c *Client) Search(ctx context.Context, keyword string, limit int) ([]Searc
 	if limit <= 0 {
 		limit = 10
 	}
+	result, err := c.searchPage(ctx, keyword, 0, limit)
+	if err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
 
+// searchPage returns rows KCS results starting at start
+func (c *Client) searchPage(ctx context.Context, keyword string, start, rows int) (*ListResponse[SearchResult], error) {
 	reqBody := KCSSearchRequest{
 		Query: keyword,
-		Rows:  limit,
+		Rows:  rows,
+		Start: start,
 	}
 
 	var result KCSSearchResponse
@@ -128,7 +137,12 @@ func (c *Client) Search(ctx context.Context, keyword string, limit int) ([]Searc
 		searchResults = append(searchResults, sr)
 	}
 
-	return searchResults, nil
+	return &ListResponse[SearchResult]{
+		Items:      searchResults,
+		TotalCount: result.Response.NumFound,
+		StartIndex: result.Response.Start,
+		Count:      len(searchResults),
+	}, nil
 }
 
 // SearchCases searches for cases by keyword
@@ -136,15 +150,23 @@ func (c *Client) SearchCases(ctx context.Context, keyword string, limit int) ([]
 	if limit <= 0 {
 		limit = 10
 	}
+	result, err := c.searchCasesPage(ctx, keyword, 0, limit)
+	if err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
 
+// searchCasesPage returns rows case search results starting at start
+func (c *Client) searchCasesPage(ctx context.Context, keyword string, start, rows int) (*ListResponse[SearchResult], error) {
 	// Build the expression for case search
 	fieldList := "case_number,case_summary,case_status,case_severity"
 	expression := "sort=case_lastModifiedDate desc&fl=" + url.QueryEscape(fieldList)
 
 	req := HydraSearchRequest{
 		Query:         keyword,
-		Start:         0,
-		Rows:          limit,
+		Start:         start,
+		Rows:          rows,
 		PartnerSearch: false,
 		Expression:    expression,
 	}
@@ -169,5 +191,10 @@ func (c *Client) SearchCases(ctx context.Context, keyword string, limit int) ([]
 		})
 	}
 
-	return results, nil
+	return &ListResponse[SearchResult]{
+		Items:      results,
+		TotalCount: resp.Response.NumFound,
+		StartIndex: resp.Response.Start,
+		Count:      len(results),
+	}, nil
 }
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package api
+
+import "context"
+
+// PageSize is how many items an Iterator fetches per request by default
+const PageSize = 100
+
+// PageFunc fetches up to count items starting at offset start
+type PageFunc[T any] func(ctx context.Context, start, count int) (*ListResponse[T], error)
+
+// Iterator pages lazily through a paginated list: each call to Next fetches
+// one more page, so callers can stream results or stop early.
+//
+//	it := client.IterateCases(ctx, filter)
+//	for it.Next() {
+//		for _, c := range it.Page() { ... }
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iterator[T any] struct {
+	ctx      context.Context
+	fetch    PageFunc[T]
+	start    int // Offset of the next page
+	pageSize int
+	limit    int // Most items to return; 0 for no limit
+	seen     int
+	total    int
+	page     []T
+	done     bool
+	err      error
+}
+
+// NewIterator pages through fetch from offset start, pageSize items at a
+// time (PageSize if 0)
+func NewIterator[T any](ctx context.Context, fetch PageFunc[T], start, pageSize int) *Iterator[T] {
+	if pageSize <= 0 {
+		pageSize = PageSize
+	}
+	return &Iterator[T]{
+		ctx:      ctx,
+		fetch:    fetch,
+		start:    max(start, 0),
+		pageSize: pageSize,
+		total:    -1,
+	}
+}
+
+// Limit stops the iterator after n items; 0 means no limit
+func (it *Iterator[T]) Limit(n int) *Iterator[T] {
+	it.limit = max(n, 0)
+	return it
+}
+
+// Next fetches the next page. It returns false when the list is exhausted,
+// the limit is reached, or a request fails; check Err afterwards.
+func (it *Iterator[T]) Next() bool {
+	if it.done {
+		return false
+	}
+	count := it.pageSize
+	if it.limit > 0 {
+		count = min(count, it.limit-it.seen)
+	}
+	if count <= 0 || (it.total >= 0 && it.start >= it.total) {
+		it.finish()
+		return false
+	}
+
+	result, err := it.fetch(it.ctx, it.start, count)
+	if err != nil {
+		it.err = err
+		it.finish()
+		return false
+	}
+	it.total = result.TotalCount
+	it.page = result.Items
+	if len(it.page) > count {
+		it.page = it.page[:count]
+	}
+	if len(it.page) == 0 {
+		it.finish()
+		return false
+	}
+	it.seen += len(it.page)
+	it.start += len(it.page)
+	// A short page is the last one, whatever the total says
+	if len(it.page) < count {
+		it.done = true
+	}
+	return true
+}
+
+func (it *Iterator[T]) finish() {
+	it.page = nil
+	it.done = true
+}
+
+// Page returns the items fetched by the last call to Next
+func (it *Iterator[T]) Page() []T {
+	return it.page
+}
+
+// Total returns the number of items in the whole list as last reported,
+// or -1 before the first page
+func (it *Iterator[T]) Total() int {
+	return it.total
+}
+
+// Err returns the error that stopped the iterator, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All fetches every remaining item
+func (it *Iterator[T]) All() ([]T, error) {
+	items := []T{}
+	for it.Next() {
+		items = append(items, it.Page()...)
+	}
+	return items, it.Err()
+}
+
+// ListCasesFunc lists one page of cases, like Client.ListCases or a local
+// store's QueryCases
+type ListCasesFunc func(ctx context.Context, filter *CaseFilter) (*ListResponse[Case], error)
+
+// IterateCasesWith pages through the cases matching filter using list.
+// Paging starts at filter.StartIndex, filter.Count cases at a time.
+func IterateCasesWith(ctx context.Context, list ListCasesFunc, filter *CaseFilter) *Iterator[Case] {
+	var base CaseFilter
+	if filter != nil {
+		base = *filter
+	}
+	fetch := func(ctx context.Context, start, count int) (*ListResponse[Case], error) {
+		page := base
+		page.StartIndex = start
+		page.Count = count
+		return list(ctx, &page)
+	}
+	return NewIterator(ctx, fetch, base.StartIndex, base.Count)
+}
+
+// IterateCases pages through the cases matching filter, starting at
+// filter.StartIndex and fetching filter.Count cases (PageSize if 0) per
+// request
+func (c *Client) IterateCases(ctx context.Context, filter *CaseFilter) *Iterator[Case] {
+	return IterateCasesWith(ctx, c.ListCases, filter)
+}
+
+// IterateSearchCases pages through the cases matching a keyword search
+func (c *Client) IterateSearchCases(ctx context.Context, keyword string, start, pageSize int) *Iterator[SearchResult] {
+	fetch := func(ctx context.Context, start, count int) (*ListResponse[SearchResult], error) {
+		return c.searchCasesPage(ctx, keyword, start, count)
+	}
+	return NewIterator(ctx, fetch, start, pageSize)
+}
+
+// IterateSearch pages through the solutions and articles matching a
+// keyword search
+func (c *Client) IterateSearch(ctx context.Context, keyword string, start, pageSize int) *Iterator[SearchResult] {
+	fetch := func(ctx context.Context, start, count int) (*ListResponse[SearchResult], error) {
+		return c.searchPage(ctx, keyword, start, count)
+	}
+	return NewIterator(ctx, fetch, start, pageSize)
+}
@@ -0,0 +1,582 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package api
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query is a case search written in agcm's query language, e.g.
+//
+//	product:"OpenShift" severity:1,2 owner:jdoe modified:>7d -status:closed text:"kernel panic"
+//
+// Terms are ANDed. A term is field:value, field:v1,v2 (any of the values),
+// or bare words and "quoted phrases" searched as text. A leading - negates
+// a term. Dates take >, <, >=, <= with an absolute date (2026-01-02) or an
+// age (7d = seven days ago; units h, d, w, y), or a range a..b.
+type Query struct {
+	Terms []QueryTerm
+}
+
+// QueryTerm is one term of a Query
+type QueryTerm struct {
+	Field  string   // Canonical field name; "text" for free text
+	Values []string // Any of these matches; normalized, e.g. "1 (Urgent)"
+	Negate bool
+	Pos    int // Byte offset of the term in the query
+	End    int
+
+	dates []dateRange // Parsed date values
+}
+
+// QueryError reports where a query is malformed
+type QueryError struct {
+	Msg string
+	Pos int // Byte offsets of the offending text
+	End int
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("%s (at column %d)", e.Msg, e.Pos+1)
+}
+
+type fieldKind int
+
+const (
+	kindString fieldKind = iota
+	kindDate
+	kindText
+)
+
+type queryField struct {
+	solr      string
+	kind      fieldKind
+	normalize func(string) string
+}
+
+// queryFields maps query field names to Hydra fields
+var queryFields = map[string]queryField{
+	"status":   {solr: "case_status", normalize: normalizeStatus},
+	"severity": {solr: "case_severity", normalize: normalizeSeverity},
+	"product":  {solr: "case_product"},
+	"version":  {solr: "case_version"},
+	"owner":    {solr: "case_owner"},
+	"account":  {solr: "case_accountNumber"},
+	"group":    {solr: "case_groupNumber"},
+	"contact":  {solr: "case_contactName"},
+	"case":     {solr: "case_number"},
+	"created":  {solr: "case_createdDate", kind: kindDate},
+	"modified": {solr: "case_lastModifiedDate", kind: kindDate},
+	"text":     {kind: kindText},
+}
+
+// Shorter names accepted for some fields
+var queryAliases = map[string]string{
+	"sev":     "severity",
+	"updated": "modified",
+}
+
+// QueryFields returns the field names a query may use
+func QueryFields() []string {
+	names := make([]string, 0, len(queryFields))
+	for name := range queryFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseQuery parses a query. Dates relative to now are resolved when the
+// query is compiled or matched.
+func ParseQuery(s string) (*Query, error) {
+	p := &queryParser{src: s}
+	q := &Query{}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			return q, nil
+		}
+		term, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		q.Terms = append(q.Terms, term)
+	}
+}
+
+// Has reports whether the query has a term on field
+func (q *Query) Has(field string) bool {
+	for _, t := range q.Terms {
+		if t.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Compile turns the query into Hydra filter queries and a main query,
+// which is empty if the query has no text terms
+func (q *Query) Compile(now time.Time) (fq []string, text string) {
+	var textParts []string
+	positive := false
+	for _, t := range q.Terms {
+		f := queryFields[t.Field]
+		switch f.kind {
+		case kindText:
+			quoted := make([]string, len(t.Values))
+			for i, v := range t.Values {
+				quoted[i] = solrText(v)
+			}
+			clause := strings.Join(quoted, " OR ")
+			if len(quoted) > 1 {
+				clause = "(" + clause + ")"
+			}
+			if t.Negate {
+				clause = "-" + clause
+			} else {
+				positive = true
+			}
+			textParts = append(textParts, clause)
+		case kindDate:
+			ranges := make([]string, len(t.dates))
+			for i, d := range t.dates {
+				ranges[i] = d.solr(now)
+			}
+			fq = append(fq, negate(t.Negate, fieldRanges(f.solr, ranges)))
+		default:
+			fq = append(fq, negate(t.Negate, fieldClause(f.solr, t.Values)))
+		}
+	}
+	if len(textParts) > 0 && !positive {
+		// Solr cannot answer a purely negative query
+		textParts = append([]string{"*:*"}, textParts...)
+	}
+	// Solr ORs clauses by default
+	return fq, strings.Join(textParts, " AND ")
+}
+
+// Match reports whether a case satisfies the query, for filtering cases
+// held locally. Group terms always match as cases do not carry their group.
+func (q *Query) Match(c *Case, now time.Time) bool {
+	for _, t := range q.Terms {
+		if t.match(c, now) == t.Negate {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *QueryTerm) match(c *Case, now time.Time) bool {
+	var value string
+	switch t.Field {
+	case "text":
+		for _, v := range t.Values {
+			v = strings.ToLower(v)
+			if strings.Contains(strings.ToLower(c.Summary), v) ||
+				strings.Contains(strings.ToLower(c.Description), v) ||
+				strings.Contains(c.CaseNumber, v) {
+				return true
+			}
+		}
+		return false
+	case "created", "modified":
+		at := c.CreatedDate
+		if t.Field == "modified" {
+			at = c.LastModified
+		}
+		for _, d := range t.dates {
+			if d.contains(at, now) {
+				return true
+			}
+		}
+		return false
+	case "group":
+		return !t.Negate
+	case "severity":
+		num, _, _ := strings.Cut(c.Severity, " ")
+		for _, v := range t.Values {
+			if strings.EqualFold(v, c.Severity) || strings.HasPrefix(v, num+" ") || v == num {
+				return true
+			}
+		}
+		return false
+	case "status":
+		value = c.Status
+	case "product":
+		value = c.Product
+	case "version":
+		value = c.Version
+	case "owner":
+		value = c.Owner
+	case "account":
+		value = c.AccountNumber
+	case "contact":
+		value = c.ContactName
+	case "case":
+		value = c.CaseNumber
+	}
+	return slices.ContainsFunc(t.Values, func(v string) bool { return strings.EqualFold(v, value) })
+}
+
+// quoteSolr quotes a value as a Solr phrase
+func quoteSolr(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// solrSpecial are the characters Solr's query parser treats as syntax
+const solrSpecial = `+-&|!(){}[]^"~*?:\/`
+
+// escapeSolr escapes query syntax in a bare Solr term
+func escapeSolr(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(solrSpecial, r) || r == ' ' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// solrText renders free text: words escaped, phrases quoted
+func solrText(s string) string {
+	if strings.ContainsAny(s, " \t") {
+		return quoteSolr(s)
+	}
+	return escapeSolr(s)
+}
+
+// fieldClause matches a Solr field against any of values
+func fieldClause(field string, values []string) string {
+	if len(values) == 1 {
+		return field + ":" + quoteSolr(values[0])
+	}
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = quoteSolr(v)
+	}
+	return field + ":(" + strings.Join(quoted, " OR ") + ")"
+}
+
+// fieldRanges matches a Solr field against any of the ranges
+func fieldRanges(field string, ranges []string) string {
+	if len(ranges) == 1 {
+		return field + ":" + ranges[0]
+	}
+	return field + ":(" + strings.Join(ranges, " OR ") + ")"
+}
+
+func negate(neg bool, clause string) string {
+	if neg {
+		return "-" + clause
+	}
+	return clause
+}
+
+// normalizeStatus maps status names and abbreviations to portal values
+func normalizeStatus(s string) string {
+	switch strings.ToLower(strings.Join(strings.Fields(s), " ")) {
+	case "open":
+		return "Open"
+	case "closed":
+		return "Closed"
+	case "waiting on red hat", "waitrh", "wor":
+		return "Waiting on Red Hat"
+	case "waiting on customer", "waitcust", "woc":
+		return "Waiting on Customer"
+	}
+	return s
+}
+
+// normalizeSeverity maps severity numbers and names to portal values
+func normalizeSeverity(s string) string {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1", "urgent":
+		return "1 (Urgent)"
+	case "2", "high":
+		return "2 (High)"
+	case "3", "normal":
+		return "3 (Normal)"
+	case "4", "low":
+		return "4 (Low)"
+	}
+	return s
+}
+
+// dateRange is a date bound parsed from a query. Ages are kept relative
+// so a query can be reused as time passes.
+type dateRange struct {
+	from, to dateBound
+}
+
+type dateBound struct {
+	set  bool
+	open bool // Excludes the bound itself
+	abs  time.Time
+	age  time.Duration // Used when abs is zero
+}
+
+func (b dateBound) at(now time.Time) time.Time {
+	if !b.abs.IsZero() {
+		return b.abs
+	}
+	return now.Add(-b.age)
+}
+
+func (d dateRange) solr(now time.Time) string {
+	bound := func(b dateBound) string {
+		if !b.set {
+			return "*"
+		}
+		return b.at(now).UTC().Format("2006-01-02T15:04:05Z")
+	}
+	lo, hi := "[", "]"
+	if d.from.open {
+		lo = "{"
+	}
+	if d.to.open {
+		hi = "}"
+	}
+	return lo + bound(d.from) + " TO " + bound(d.to) + hi
+}
+
+func (d dateRange) contains(t, now time.Time) bool {
+	if d.from.set {
+		from := d.from.at(now)
+		if t.Before(from) || d.from.open && t.Equal(from) {
+			return false
+		}
+	}
+	if d.to.set {
+		to := d.to.at(now)
+		if t.After(to) || d.to.open && t.Equal(to) {
+			return false
+		}
+	}
+	return true
+}
+
+type queryParser struct {
+	src string
+	pos int
+}
+
+func (p *queryParser) skipSpace() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *queryParser) errorf(pos, end int, format string, args ...any) error {
+	return &QueryError{Msg: fmt.Sprintf(format, args...), Pos: pos, End: max(end, pos+1)}
+}
+
+func (p *queryParser) parseTerm() (QueryTerm, error) {
+	t := QueryTerm{Pos: p.pos}
+	if p.src[p.pos] == '-' {
+		t.Negate = true
+		p.pos++
+		if p.pos >= len(p.src) || p.src[p.pos] == ' ' {
+			return t, p.errorf(t.Pos, p.pos, "nothing to exclude after -")
+		}
+	}
+
+	// A quoted phrase is free text
+	if p.src[p.pos] == '"' {
+		v, err := p.parseQuoted()
+		if err != nil {
+			return t, err
+		}
+		t.Field, t.Values, t.End = "text", []string{v}, p.pos
+		return t, nil
+	}
+
+	// field:value, or a bare word
+	start := p.pos
+	for p.pos < len(p.src) && p.src[p.pos] != ':' && p.src[p.pos] != ' ' && p.src[p.pos] != '\t' {
+		p.pos++
+	}
+	if p.pos >= len(p.src) || p.src[p.pos] != ':' {
+		t.Field, t.Values, t.End = "text", []string{p.src[start:p.pos]}, p.pos
+		return t, nil
+	}
+
+	name := strings.ToLower(p.src[start:p.pos])
+	if alias, ok := queryAliases[name]; ok {
+		name = alias
+	}
+	f, ok := queryFields[name]
+	if !ok {
+		return t, p.errorf(start, p.pos, "unknown field %q (valid: %s)", p.src[start:p.pos], strings.Join(QueryFields(), ", "))
+	}
+	t.Field = name
+	p.pos++ // ':'
+
+	for {
+		vstart := p.pos
+		var v string
+		if p.pos < len(p.src) && p.src[p.pos] == '"' {
+			var err error
+			if v, err = p.parseQuoted(); err != nil {
+				return t, err
+			}
+		} else {
+			for p.pos < len(p.src) && !strings.ContainsRune(" \t,", rune(p.src[p.pos])) {
+				p.pos++
+			}
+			v = p.src[vstart:p.pos]
+		}
+		if strings.TrimSpace(v) == "" {
+			return t, p.errorf(vstart, p.pos, "missing value for %s", name)
+		}
+
+		switch f.kind {
+		case kindDate:
+			d, err := parseDateValue(v)
+			if err != nil {
+				return t, p.errorf(vstart, p.pos, "%s: %v", name, err)
+			}
+			t.dates = append(t.dates, d)
+			t.Values = append(t.Values, v)
+		default:
+			if f.normalize != nil {
+				v = f.normalize(v)
+			}
+			t.Values = append(t.Values, v)
+		}
+
+		if p.pos < len(p.src) && p.src[p.pos] == ',' {
+			p.pos++
+			continue
+		}
+		break
+	}
+	t.End = p.pos
+	return t, nil
+}
+
+// parseQuoted reads a "quoted string" with backslash escapes
+func (p *queryParser) parseQuoted() (string, error) {
+	start := p.pos
+	p.pos++ // opening quote
+	var b strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == '\\' && p.pos+1 < len(p.src):
+			b.WriteByte(p.src[p.pos+1])
+			p.pos += 2
+		case c == '"':
+			p.pos++
+			return b.String(), nil
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	return "", p.errorf(start, len(p.src), "unterminated quote")
+}
+
+// parseDateValue parses >7d, <=2026-01-02, 2026-01-01..2026-02-01 and the like
+func parseDateValue(s string) (dateRange, error) {
+	if from, to, ok := strings.Cut(s, ".."); ok {
+		var d dateRange
+		var err error
+		if from != "" {
+			if d.from, err = parseDateBound(from, false); err != nil {
+				return d, err
+			}
+		}
+		if to != "" {
+			if d.to, err = parseDateBound(to, true); err != nil {
+				return d, err
+			}
+		}
+		return d, nil
+	}
+
+	op := ""
+	for _, prefix := range []string{">=", "<=", ">", "<"} {
+		if strings.HasPrefix(s, prefix) {
+			op, s = prefix, s[len(prefix):]
+			break
+		}
+	}
+
+	switch op {
+	case ">=":
+		b, err := parseDateBound(s, false)
+		return dateRange{from: b}, err
+	case ">":
+		// Starts at the end of a date's day, which is not part of it, or
+		// just after an instant
+		b, err := parseDateBound(s, true)
+		b.open = !b.open
+		return dateRange{from: b}, err
+	case "<=":
+		b, err := parseDateBound(s, true)
+		return dateRange{to: b}, err
+	case "<":
+		b, err := parseDateBound(s, false)
+		b.open = true
+		return dateRange{to: b}, err
+	}
+
+	// A bare age means "within"; a bare date means that whole day
+	if _, err := parseAge(s); err == nil {
+		b, err := parseDateBound(s, false)
+		return dateRange{from: b}, err
+	}
+	from, err := parseDateBound(s, false)
+	if err != nil {
+		return dateRange{}, err
+	}
+	to, _ := parseDateBound(s, true)
+	return dateRange{from: from, to: to}, nil
+}
+
+// parseDateBound parses an age or a date. A date's end bound is the
+// following midnight, excluded.
+func parseDateBound(s string, end bool) (dateBound, error) {
+	if age, err := parseAge(s); err == nil {
+		return dateBound{set: true, age: age}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return dateBound{set: true, abs: t}, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return dateBound{}, fmt.Errorf("invalid date %q (use 2006-01-02 or an age like 7d)", s)
+	}
+	if end {
+		return dateBound{set: true, open: true, abs: t.AddDate(0, 0, 1)}, nil
+	}
+	return dateBound{set: true, abs: t}, nil
+}
+
+// parseAge parses an age such as 12h, 7d, 2w or 1y
+func parseAge(s string) (time.Duration, error) {
+	if len(s) < 2 {
+		return 0, fmt.Errorf("invalid age %q", s)
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid age %q", s)
+	}
+	unit := map[byte]time.Duration{
+		'h': time.Hour,
+		'd': 24 * time.Hour,
+		'w': 7 * 24 * time.Hour,
+		'y': 365 * 24 * time.Hour,
+	}[s[len(s)-1]]
+	if unit == 0 {
+		return 0, fmt.Errorf("invalid age %q (units: h, d, w, y)", s)
+	}
+	return time.Duration(n) * unit, nil
+}
@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package api
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// solrDate formats local midnight of a day as Compile renders range bounds
+func solrDate(year int, month time.Month, day int) string {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local).UTC().Format("2006-01-02T15:04:05Z")
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []QueryTerm
+	}{
+		{"", nil},
+		{"kernel", []QueryTerm{{Field: "text", Values: []string{"kernel"}, Pos: 0, End: 6}}},
+		{`  "kernel panic" -oops`, []QueryTerm{
+			{Field: "text", Values: []string{"kernel panic"}, Pos: 2, End: 16},
+			{Field: "text", Values: []string{"oops"}, Negate: true, Pos: 17, End: 22},
+		}},
+		{"sev:1,high Status:woc", []QueryTerm{
+			{Field: "severity", Values: []string{"1 (Urgent)", "2 (High)"}, Pos: 0, End: 10},
+			{Field: "status", Values: []string{"Waiting on Customer"}, Pos: 11, End: 21},
+		}},
+		{`-product:"Red Hat \"Enterprise\" Linux",OpenShift`, []QueryTerm{
+			{Field: "product", Values: []string{`Red Hat "Enterprise" Linux`, "OpenShift"}, Negate: true, Pos: 0, End: 49},
+		}},
+		{"updated:>7d", []QueryTerm{{Field: "modified", Values: []string{">7d"}, Pos: 0, End: 11}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := slices.Clone(q.Terms)
+			for i := range got {
+				got[i].dates = nil
+			}
+			if !slices.EqualFunc(got, tt.want, func(a, b QueryTerm) bool {
+				return a.Field == b.Field && slices.Equal(a.Values, b.Values) && a.Negate == b.Negate && a.Pos == b.Pos && a.End == b.End
+			}) {
+				t.Errorf("ParseQuery(%q) =\n%+v\nwant\n%+v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		query    string
+		msg      string
+		pos, end int
+	}{
+		{"kernel colour:red", `unknown field "colour" (valid: account, case, contact, created, group, modified, owner, product, severity, status, text, version)`, 7, 13},
+		{"status:", "missing value for status", 7, 8},
+		{"severity:1,", "missing value for severity", 11, 12},
+		{`text:"kernel panic`, "unterminated quote", 5, 18},
+		{"kernel -", "nothing to exclude after -", 7, 8},
+		{"kernel - panic", "nothing to exclude after -", 7, 8},
+		{"modified:>yesterday", `modified: invalid date "yesterday" (use 2006-01-02 or an age like 7d)`, 9, 19},
+		{"created:2026-01-01..soon", `created: invalid date "soon" (use 2006-01-02 or an age like 7d)`, 8, 24},
+		{"modified:7x", `modified: invalid date "7x" (use 2006-01-02 or an age like 7d)`, 9, 11},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseQuery(tt.query)
+			var qerr *QueryError
+			if !errors.As(err, &qerr) {
+				t.Fatalf("ParseQuery(%q) error = %v, want a QueryError", tt.query, err)
+			}
+			if qerr.Msg != tt.msg || qerr.Pos != tt.pos || qerr.End != tt.end {
+				t.Errorf("ParseQuery(%q) error = %q at %d-%d, want %q at %d-%d",
+					tt.query, qerr.Msg, qerr.Pos, qerr.End, tt.msg, tt.pos, tt.end)
+			}
+		})
+	}
+}
+
+func TestCompileQuery(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		query string
+		fq    []string
+		text  string
+	}{
+		{"kernel panic", nil, "kernel AND panic"},
+		{"text:a text:b", nil, "a AND b"},
+		{`"kernel panic" -text:oops,oom`, nil, `"kernel panic" AND -(oops OR oom)`},
+		{"-crash", nil, "*:* AND -crash"},
+		{"severity:1,2 -status:closed", []string{
+			`case_severity:("1 (Urgent)" OR "2 (High)")`,
+			`-case_status:"Closed"`,
+		}, ""},
+		{`product:"Red Hat \"Enterprise\" Linux"`, []string{`case_product:"Red Hat \"Enterprise\" Linux"`}, ""},
+		{"modified:>7d", []string{"case_lastModifiedDate:{2026-03-03T12:00:00Z TO *]"}, ""},
+		{"modified:>=7d", []string{"case_lastModifiedDate:[2026-03-03T12:00:00Z TO *]"}, ""},
+		{"created:<2026-01-02", []string{"case_createdDate:[* TO " + solrDate(2026, 1, 2) + "}"}, ""},
+		{"created:<=2026-01-02", []string{"case_createdDate:[* TO " + solrDate(2026, 1, 3) + "}"}, ""},
+		{"created:>2026-01-02", []string{"case_createdDate:[" + solrDate(2026, 1, 3) + " TO *]"}, ""},
+		{"created:>=2026-01-02", []string{"case_createdDate:[" + solrDate(2026, 1, 2) + " TO *]"}, ""},
+		{"created:2026-01-02", []string{"case_createdDate:[" + solrDate(2026, 1, 2) + " TO " + solrDate(2026, 1, 3) + "}"}, ""},
+		{"created:2026-01-01..2026-01-31,>1w", []string{
+			"case_createdDate:([" + solrDate(2026, 1, 1) + " TO " + solrDate(2026, 2, 1) + "} OR {2026-03-03T12:00:00Z TO *])",
+		}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fq, text := q.Compile(now)
+			if !slices.Equal(fq, tt.fq) || text != tt.text {
+				t.Errorf("Compile(%q) = %q, %q; want %q, %q", tt.query, fq, text, tt.fq, tt.text)
+			}
+		})
+	}
+}
+
+func TestCompileQueryEscapesText(t *testing.T) {
+	q, err := ParseQuery(`c++ (x) a/b "say \"hi\""`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, text := q.Compile(time.Now())
+	if want := `c\+\+ AND \(x\) AND a\/b AND "say \"hi\""`; text != want {
+		t.Errorf("text = %q, want %q", text, want)
+	}
+}
+
+func TestQueryMatch(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	day := func(d int, hour int) time.Time { return time.Date(2026, 1, d, hour, 0, 0, 0, time.Local) }
+	c := &Case{
+		CaseNumber:   "04012345",
+		Summary:      "Kernel panic on boot",
+		Description:  "The host panics in the NVMe driver.",
+		Status:       "Waiting on Red Hat",
+		Severity:     "2 (High)",
+		Product:      "Red Hat Enterprise Linux",
+		Version:      "9.4",
+		Owner:        "jdoe",
+		CreatedDate:  day(2, 0),
+		LastModified: now.Add(-3 * 24 * time.Hour),
+	}
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"", true},
+		{"kernel panic", true},
+		{"kernel oops", false},
+		{`"kernel panic"`, true},
+		{"text:nvme,oops", true},
+		{"-panic", false},
+		{"04012345", true},
+		{"severity:high", true},
+		{"severity:1,2", true},
+		{"severity:3", false},
+		{"status:wor", true},
+		{"-status:closed", true},
+		{`product:"red hat enterprise linux" version:9.4`, true},
+		{"owner:jsmith", false},
+		{"group:42", true},
+		{"-group:42", true},
+		{"modified:>7d", true},
+		{"modified:<7d", false},
+		{"modified:1w..1d", true},
+		{"created:2026-01-02", true},
+		{"created:<=2026-01-02", true},
+		{"created:>=2026-01-02", true},
+		{"created:<2026-01-02", false},
+		{"created:>2026-01-02", false},
+		{"created:<2026-01-03", true},
+		{"created:>2026-01-01", true},
+		{"created:2026-01-03..", false},
+		{"created:..2026-01-01", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := q.Match(c, now); got != tt.want {
+				t.Errorf("Match(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+
+	// Strict bounds exclude the instant itself
+	c.CreatedDate = day(2, 6)
+	for query, want := range map[string]bool{
+		"created:>" + c.CreatedDate.Format(time.RFC3339):  false,
+		"created:>=" + c.CreatedDate.Format(time.RFC3339): true,
+		"created:<" + c.CreatedDate.Format(time.RFC3339):  false,
+		"created:<=" + c.CreatedDate.Format(time.RFC3339): true,
+	} {
+		q, err := ParseQuery(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := q.Match(c, now); got != want {
+			t.Errorf("Match(%q) = %v, want %v", query, got, want)
+		}
+	}
+}
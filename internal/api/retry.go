@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package api
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultMaxRetries        = 3
+	DefaultRetryBaseDelay    = 500 * time.Millisecond
+	DefaultRetryMaxDelay     = 30 * time.Second
+	DefaultMaxConcurrent     = 6
+	DefaultRequestsPerSecond = 10
+)
+
+// RetryPolicy controls how transient failures are retried. Rate-limited
+// requests (429) are retried for any method since the server did not act on
+// them; connection errors and 502/503/504 are only retried for idempotent
+// methods so a POST is never sent twice.
+type RetryPolicy struct {
+	MaxRetries int           // Retries after the first attempt; 0 disables retries
+	BaseDelay  time.Duration // Delay before the first retry, doubled for each one after
+	MaxDelay   time.Duration // Longest delay, and longest Retry-After that is honored
+}
+
+// DefaultRetryPolicy returns the policy used when none is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: DefaultMaxRetries,
+		BaseDelay:  DefaultRetryBaseDelay,
+		MaxDelay:   DefaultRetryMaxDelay,
+	}
+}
+
+// WithRetryPolicy sets how transient failures are retried
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	return func(c *Client) {
+		if p.BaseDelay <= 0 {
+			p.BaseDelay = DefaultRetryBaseDelay
+		}
+		if p.MaxDelay <= 0 {
+			p.MaxDelay = DefaultRetryMaxDelay
+		}
+		c.retry = p
+	}
+}
+
+// WithRateLimit limits requests across the whole client to maxConcurrent in
+// flight and requestsPerSecond started. Zero disables either limit.
+func WithRateLimit(maxConcurrent int, requestsPerSecond float64) ClientOption {
+	return func(c *Client) {
+		c.limiter = newLimiter(maxConcurrent, requestsPerSecond)
+	}
+}
+
+// backoff returns the jittered delay before retry number attempt (from 0)
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay
+	for i := 0; i < attempt && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	// Equal jitter: keep half the delay, randomize the rest
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+// retryDelay decides whether a failed attempt should be retried and how long
+// to wait first
+func (c *Client) retryDelay(attempt int, idempotent bool, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt >= c.retry.MaxRetries {
+		return 0, false
+	}
+	if err != nil {
+		if !idempotent {
+			return 0, false
+		}
+		return c.retry.backoff(attempt), true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if !idempotent {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		// Don't stall for minutes; let the caller see the error instead
+		if d > c.retry.MaxDelay {
+			return 0, false
+		}
+		return d, true
+	}
+	return c.retry.backoff(attempt), true
+}
+
+// isIdempotent reports whether repeating a request with method is safe
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(h string, now time.Time) (time.Duration, bool) {
+	h = strings.TrimSpace(h)
+	if h == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(h); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(h); err == nil {
+		d := t.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// discard drains and closes a response body so its connection can be reused
+func discard(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	_ = resp.Body.Close()
+}
+
+// sleepCtx waits for d or until ctx is done
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// limiter bounds in-flight requests and spaces out request starts. It is
+// shared by every caller of a Client, so parallel exports and TUI loads
+// together stay under the portal's rate limits.
+type limiter struct {
+	sem      chan struct{}
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time // Earliest start for the next request
+}
+
+// newLimiter returns nil when both limits are disabled
+func newLimiter(maxConcurrent int, requestsPerSecond float64) *limiter {
+	if maxConcurrent <= 0 && requestsPerSecond <= 0 {
+		return nil
+	}
+	l := &limiter{}
+	if maxConcurrent > 0 {
+		l.sem = make(chan struct{}, maxConcurrent)
+	}
+	if requestsPerSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+	return l
+}
+
+// acquire blocks until a request may start
+func (l *limiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if wait := l.reserve(); wait > 0 {
+		if err := sleepCtx(ctx, wait); err != nil {
+			l.release()
+			return err
+		}
+	}
+	return nil
+}
+
+// release marks a request as finished
+func (l *limiter) release() {
+	if l == nil || l.sem == nil {
+		return
+	}
+	<-l.sem
+}
+
+// reserve claims the next start slot and returns how long to wait for it
+func (l *limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	return slot.Sub(now)
+}
+
+// pause holds back every request for d, after the server asked us to slow down
+func (l *limiter) pause(d time.Duration) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := time.Now().Add(d); l.next.Before(until) {
+		l.next = until
+	}
+}
@@ -0,0 +1,288 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+		ok     bool
+	}{
+		{"", 0, false},
+		{"0", 0, true},
+		{" 5 ", 5 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.header, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.header, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{MaxRetries: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for attempt, full := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		full *= time.Millisecond
+		for range 20 {
+			if d := p.backoff(attempt); d < full/2 || d > full {
+				t.Fatalf("backoff(%d) = %v, want between %v and %v", attempt, d, full/2, full)
+			}
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	c := &Client{retry: RetryPolicy{MaxRetries: 2, BaseDelay: 100 * time.Millisecond, MaxDelay: 10 * time.Second}}
+	response := func(status int, retryAfter string) *http.Response {
+		resp := &http.Response{StatusCode: status, Header: make(http.Header)}
+		if retryAfter != "" {
+			resp.Header.Set("Retry-After", retryAfter)
+		}
+		return resp
+	}
+	netErr := errors.New("connection reset")
+
+	tests := []struct {
+		name       string
+		attempt    int
+		idempotent bool
+		resp       *http.Response
+		err        error
+		retry      bool
+		delay      time.Duration // Exact delay expected, if non-zero
+	}{
+		{"429 POST", 0, false, response(http.StatusTooManyRequests, ""), nil, true, 0},
+		{"429 Retry-After", 0, false, response(http.StatusTooManyRequests, "3"), nil, true, 3 * time.Second},
+		{"429 Retry-After too long", 0, true, response(http.StatusTooManyRequests, "60"), nil, false, 0},
+		{"503 GET", 0, true, response(http.StatusServiceUnavailable, ""), nil, true, 0},
+		{"503 POST", 0, false, response(http.StatusServiceUnavailable, ""), nil, false, 0},
+		{"502 POST", 1, false, response(http.StatusBadGateway, ""), nil, false, 0},
+		{"500 GET", 0, true, response(http.StatusInternalServerError, ""), nil, false, 0},
+		{"404 GET", 0, true, response(http.StatusNotFound, ""), nil, false, 0},
+		{"network error GET", 0, true, nil, netErr, true, 0},
+		{"network error POST", 0, false, nil, netErr, false, 0},
+		{"retries exhausted", 2, true, response(http.StatusTooManyRequests, ""), nil, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, retry := c.retryDelay(tt.attempt, tt.idempotent, tt.resp, tt.err)
+			if retry != tt.retry {
+				t.Fatalf("retry = %v, want %v", retry, tt.retry)
+			}
+			if tt.delay != 0 && delay != tt.delay {
+				t.Errorf("delay = %v, want %v", delay, tt.delay)
+			}
+		})
+	}
+}
+
+func TestIsIdempotent(t *testing.T) {
+	for method, want := range map[string]bool{
+		http.MethodGet:    true,
+		http.MethodPut:    true,
+		http.MethodDelete: true,
+		http.MethodPost:   false,
+		http.MethodPatch:  false,
+	} {
+		if got := isIdempotent(method); got != want {
+			t.Errorf("isIdempotent(%s) = %v, want %v", method, got, want)
+		}
+	}
+}
+
+func TestLimiterSpacesRequests(t *testing.T) {
+	l := newLimiter(0, 20) // One start every 50ms
+	ctx := context.Background()
+	start := time.Now()
+	for range 4 {
+		if err := l.acquire(ctx); err != nil {
+			t.Fatal(err)
+		}
+		l.release()
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("4 requests at 20/s started within %v", elapsed)
+	}
+}
+
+func TestLimiterBoundsConcurrency(t *testing.T) {
+	l := newLimiter(2, 0)
+	ctx := context.Background()
+	for range 2 {
+		if err := l.acquire(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	short, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := l.acquire(short); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("third acquire = %v, want deadline exceeded", err)
+	}
+
+	l.release()
+	if err := l.acquire(ctx); err != nil {
+		t.Fatalf("acquire after release: %v", err)
+	}
+}
+
+func TestLimiterDisabled(t *testing.T) {
+	if l := newLimiter(0, 0); l != nil {
+		t.Fatalf("newLimiter(0, 0) = %+v, want nil", l)
+	}
+	var l *limiter
+	if err := l.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	l.release()
+	l.pause(time.Second)
+}
+
+// TestRateLimitedPausesClient checks that a 429 holds back other requests
+// on the same client, not just the one that was rejected
+func TestRateLimitedPausesClient(t *testing.T) {
+	const retryAfter = 1
+	var (
+		mu       sync.Mutex
+		limited  time.Time
+		arrivals = make(map[string]time.Time)
+		hits     atomic.Int32
+	)
+	rejected := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/limited" && hits.Add(1) == 1 {
+			limited = time.Now()
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			w.WriteHeader(http.StatusTooManyRequests)
+			close(rejected)
+			return
+		}
+		arrivals[r.URL.Path] = time.Now()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithToken("token"), WithRateLimit(4, 0))
+	ctx := context.Background()
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- c.post(ctx, "/limited", map[string]string{}, nil)
+	}()
+	<-rejected
+	// Give the client a moment to see the 429 and pause
+	time.Sleep(50 * time.Millisecond)
+	if err := c.get(ctx, "/other", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("rate-limited POST was not retried: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if got := hits.Load(); got != 2 {
+		t.Errorf("POST sent %d times, want 2", got)
+	}
+	if wait := arrivals["/other"].Sub(limited); wait < retryAfter*time.Second-100*time.Millisecond {
+		t.Errorf("other request went out %v after the 429, want about %ds", wait, retryAfter)
+	}
+}
+
+func TestServerErrorNotRetriedForPost(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	policy := RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond}
+	c := NewClient(WithBaseURL(srv.URL), WithToken("token"), WithRetryPolicy(policy), WithRateLimit(0, 0))
+	ctx := context.Background()
+
+	var apiErr *APIError
+	if err := c.post(ctx, "/cases", map[string]string{}, nil); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("POST error = %v, want a 503 APIError", err)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("POST sent %d times, want 1", got)
+	}
+
+	hits.Store(0)
+	if err := c.get(ctx, "/cases", nil, nil); err == nil {
+		t.Fatal("GET succeeded against a failing server")
+	}
+	if got := hits.Load(); got != 3 {
+		t.Errorf("GET sent %d times, want 3", got)
+	}
+}
+
+func TestUploadUsesLimiterAndRetries(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		if hits.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	file := filepath.Join(t.TempDir(), "sosreport.tar.xz")
+	if err := os.WriteFile(file, []byte("report"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c := NewClient(WithBaseURL(srv.URL), WithToken("token"), WithRateLimit(1, 0))
+	ctx := context.Background()
+
+	// With the only slot taken, the upload waits for it
+	if err := c.limiter.acquire(ctx); err != nil {
+		t.Fatal(err)
+	}
+	short, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.UploadAttachment(short, "01234567", file, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("upload with the limiter full = %v, want deadline exceeded", err)
+	}
+	if got := hits.Load(); got != 0 {
+		t.Fatalf("upload reached the server %d times while the limiter was full", got)
+	}
+	c.limiter.release()
+
+	att, err := c.UploadAttachment(ctx, "01234567", file, nil)
+	if err != nil {
+		t.Fatalf("rate-limited upload was not retried: %v", err)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("upload sent %d times, want 2", got)
+	}
+	if att.Filename != "sosreport.tar.xz" || att.Length != 6 {
+		t.Errorf("attachment = %+v", att)
+	}
+}
@@ -0,0 +1,280 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/green/agcm/internal/logging"
+)
+
+// maxTraceBody caps how much of a text body a trace records
+const maxTraceBody = 1 << 20
+
+// Trace records HTTP exchanges to a HAR 1.2 file that can be attached to a
+// bug report. Tokens and email addresses are redacted; binary bodies such as
+// attachment downloads and uploads are recorded by size only.
+type Trace struct {
+	path    string
+	version string
+
+	mu      sync.Mutex
+	entries []harEntry
+}
+
+// NewTrace creates a trace written to path when closed. The file is created
+// immediately so a bad path is reported up front.
+func NewTrace(path, version string) (*Trace, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace file: %w", err)
+	}
+	_ = f.Close()
+	return &Trace{path: path, version: version}, nil
+}
+
+// WithTrace records every request the client makes to t
+func WithTrace(t *Trace) ClientOption {
+	return func(c *Client) {
+		c.trace = t
+	}
+}
+
+// Close writes the recorded exchanges to the trace file
+func (t *Trace) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var doc struct {
+		Log struct {
+			Version string `json:"version"`
+			Creator struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+			} `json:"creator"`
+			Entries []harEntry `json:"entries"`
+		} `json:"log"`
+	}
+	doc.Log.Version = "1.2"
+	doc.Log.Creator.Name = "agcm"
+	doc.Log.Creator.Version = t.version
+	doc.Log.Entries = t.entries
+	if doc.Log.Entries == nil {
+		doc.Log.Entries = []harEntry{}
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode trace: %w", err)
+	}
+	if err := os.WriteFile(t.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write trace: %w", err)
+	}
+	return nil
+}
+
+func (t *Trace) add(e harEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries = append(t.entries, e)
+}
+
+type harEntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"` // Milliseconds
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string       `json:"method"`
+	URL         string       `json:"url"`
+	HTTPVersion string       `json:"httpVersion"`
+	Headers     []harNameVal `json:"headers"`
+	QueryString []harNameVal `json:"queryString"`
+	PostData    *harPostData `json:"postData,omitempty"`
+	HeadersSize int          `json:"headersSize"`
+	BodySize    int64        `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int          `json:"status"`
+	StatusText  string       `json:"statusText"`
+	HTTPVersion string       `json:"httpVersion"`
+	Headers     []harNameVal `json:"headers"`
+	Content     harContent   `json:"content"`
+	RedirectURL string       `json:"redirectURL"`
+	HeadersSize int          `json:"headersSize"`
+	BodySize    int64        `json:"bodySize"`
+}
+
+type harNameVal struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// transport logs each HTTP exchange and records it to the trace
+type transport struct {
+	base   http.RoundTripper
+	logger *slog.Logger
+	trace  *Trace
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if t.trace != nil && req.GetBody != nil && isText(req.Header.Get("Content-Type")) {
+		if body, err := req.GetBody(); err == nil {
+			reqBody, _ = io.ReadAll(io.LimitReader(body, maxTraceBody))
+			_ = body.Close()
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start)
+
+	url := logging.Redact(req.URL.String())
+	if err != nil {
+		t.logger.Warn("http request failed", "method", req.Method, "url", url, "duration", elapsed, "error", err)
+		if t.trace != nil {
+			e := t.entry(req, reqBody, start, elapsed)
+			e.Comment = logging.Redact(err.Error())
+			t.trace.add(e)
+		}
+		return nil, err
+	}
+
+	level := slog.LevelDebug
+	if resp.StatusCode >= 400 {
+		level = slog.LevelWarn
+	}
+	t.logger.Log(req.Context(), level, "http request",
+		"method", req.Method, "url", url, "status", resp.StatusCode,
+		"duration", elapsed, "request_id", requestID(resp))
+
+	if t.trace != nil {
+		e := t.entry(req, reqBody, start, elapsed)
+		e.Response = harResponse{
+			Status:      resp.StatusCode,
+			StatusText:  http.StatusText(resp.StatusCode),
+			HTTPVersion: resp.Proto,
+			Headers:     harHeaders(resp.Header),
+			Content: harContent{
+				Size:     resp.ContentLength,
+				MimeType: resp.Header.Get("Content-Type"),
+			},
+			HeadersSize: -1,
+			BodySize:    resp.ContentLength,
+		}
+		if isText(resp.Header.Get("Content-Type")) {
+			// API responses are small JSON documents; buffer them so the
+			// caller still reads the full body
+			body, readErr := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			if readErr == nil {
+				e.Response.Content.Size = int64(len(body))
+				e.Response.BodySize = int64(len(body))
+				e.Response.Content.Text = logging.Redact(string(body[:min(len(body), maxTraceBody)]))
+			}
+		}
+		t.trace.add(e)
+	}
+	return resp, nil
+}
+
+// entry starts a HAR entry describing req
+func (t *transport) entry(req *http.Request, body []byte, start time.Time, elapsed time.Duration) harEntry {
+	ms := float64(elapsed) / float64(time.Millisecond)
+	e := harEntry{
+		StartedDateTime: start,
+		Time:            ms,
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         logging.Redact(req.URL.String()),
+			HTTPVersion: req.Proto,
+			Headers:     harHeaders(req.Header),
+			QueryString: []harNameVal{},
+			HeadersSize: -1,
+			BodySize:    req.ContentLength,
+		},
+		Response: harResponse{Headers: []harNameVal{}, HeadersSize: -1, BodySize: -1},
+		Timings:  harTimings{Send: -1, Wait: ms, Receive: -1},
+	}
+	for name, values := range req.URL.Query() {
+		for _, v := range values {
+			e.Request.QueryString = append(e.Request.QueryString, harNameVal{name, logging.Redact(v)})
+		}
+	}
+	if req.Body != nil {
+		e.Request.PostData = &harPostData{MimeType: req.Header.Get("Content-Type")}
+		if body != nil {
+			e.Request.PostData.Text = logging.Redact(string(body))
+		}
+	}
+	return e
+}
+
+// harHeaders converts headers, redacting credentials
+func harHeaders(h http.Header) []harNameVal {
+	out := []harNameVal{}
+	for name, values := range h {
+		for _, v := range values {
+			if strings.EqualFold(name, "Authorization") || strings.EqualFold(name, "Cookie") || strings.EqualFold(name, "Set-Cookie") {
+				v = "[REDACTED]"
+			}
+			out = append(out, harNameVal{name, logging.Redact(v)})
+		}
+	}
+	return out
+}
+
+// isText reports whether a content type is worth recording in a trace
+func isText(contentType string) bool {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mt, "text/") || mt == "application/json" ||
+		strings.HasSuffix(mt, "+json") || mt == "application/x-www-form-urlencoded"
+}
+
+// requestID returns the portal request ID of a response, if any
+func requestID(resp *http.Response) string {
+	for _, h := range requestIDHeaders {
+		if id := resp.Header.Get(h); id != "" {
+			return id
+		}
+	}
+	return ""
+}
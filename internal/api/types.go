@@ -2,7 +2,10 @@
 // Copyright (C) 2026 Anthony Green <green@redhat.com>
 package api
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // Case represents a Red Hat support case
 type Case struct {
@@ -74,6 +77,33 @@ type Attachment struct {
 	URI          string    `json:"uri,omitempty"`
 }
 
+// GetSize returns the attachment size (checks multiple field names)
+func (a *Attachment) GetSize() int64 {
+	switch {
+	case a.Length > 0:
+		return a.Length
+	case a.Size > 0:
+		return a.Size
+	case a.FileSize > 0:
+		return a.FileSize
+	}
+	return a.ContentLength
+}
+
+// FormatSize formats a byte count for display, e.g. 1.5 MB
+func FormatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
 // Solution represents a knowledge base solution
 type Solution struct {
 	ID           string    `json:"id"`
@@ -133,6 +163,32 @@ type CaseFilter struct {
 	Accounts      []string   `json:"accounts,omitempty"`    // Filter by account number(s)
 	GroupNumber   string     `json:"groupNumber,omitempty"` // Filter by case group
 	OwnerSSOName  string     `json:"ownerSSOName,omitempty"` // Filter by owner
+	Query         string     `json:"query,omitempty"`        // Query language, see ParseQuery
+}
+
+// CreateCaseRequest contains the fields used to open a new support case
+type CreateCaseRequest struct {
+	Summary       string `json:"summary"`
+	Description   string `json:"description"`
+	Product       string `json:"product"`
+	Version       string `json:"version"`
+	Severity      string `json:"severity,omitempty"`
+	Type          string `json:"caseType,omitempty"`
+	AccountNumber string `json:"accountNumber,omitempty"`
+	GroupNumber   string `json:"groupNumber,omitempty"`
+}
+
+// CaseUpdate contains the case fields to change; empty fields are left unchanged
+type CaseUpdate struct {
+	Severity       string `json:"severity,omitempty"`
+	Status         string `json:"status,omitempty"`
+	ContactSSOName string `json:"contactSSOName,omitempty"`
+	Summary        string `json:"summary,omitempty"`
+}
+
+// IsEmpty returns true if the update changes nothing
+func (u *CaseUpdate) IsEmpty() bool {
+	return u == nil || *u == CaseUpdate{}
 }
 
 // SearchResult represents a search result item
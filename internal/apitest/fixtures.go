@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package apitest
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/green/agcm/internal/api"
+)
+
+// Fixtures is the data served by a fake portal
+type Fixtures struct {
+	User      string              // Full name of the signed-in user, used for new comments
+	Accounts  []Account           // Accounts visible to the user; the first is the default
+	Cases     []Case              // Cases visible to the user
+	Forbidden []string            // Case numbers that exist but belong to another account
+	Products  map[string][]string // Product name to versions
+	KCS       []KCSDoc            // Knowledgebase solutions and articles
+}
+
+// Account is a customer account
+type Account struct {
+	Number string
+	Name   string
+}
+
+// Case is a case with its conversation and files
+type Case struct {
+	api.Case
+	GroupNumber string
+	Comments    []api.Comment
+	Attachments []Attachment
+}
+
+// Attachment is an attachment with its content
+type Attachment struct {
+	api.Attachment
+	Content []byte
+}
+
+// KCSDoc is a knowledgebase document returned by KCS search
+type KCSDoc struct {
+	ID       string
+	Kind     string // "Solution" or "Article"
+	Title    string
+	Abstract string
+}
+
+var (
+	fixtureAccounts = []Account{
+		{Number: "5550101", Name: "Acme Corporation"},
+		{Number: "5550202", Name: "Globex Industries"},
+	}
+	fixtureGroups   = []string{"", "7701", "7702"}
+	fixtureContacts = []struct{ name, email string }{
+		{"Dana Whitfield", "dana.whitfield@acme.example"},
+		{"Priya Raman", "priya.raman@acme.example"},
+		{"Tomás Ortega", "tomas.ortega@globex.example"},
+	}
+	fixtureEngineers = []string{"Alex Moreau", "Jun Takahashi", "Rosa Lindqvist", "Samir Haddad", "Kate Oduya"}
+	fixtureProducts  = map[string][]string{
+		"Red Hat Enterprise Linux":             {"8.10", "9.4", "9.5", "10.0"},
+		"Red Hat OpenShift Container Platform": {"4.15", "4.16", "4.17"},
+		"Red Hat Ansible Automation Platform":  {"2.4", "2.5"},
+		"Red Hat Satellite":                    {"6.15", "6.16"},
+		"Red Hat OpenShift Data Foundation":    {"4.16", "4.17"},
+		"Red Hat Enterprise Linux AI":          {"1.2", "1.3"},
+	}
+	fixtureIssues = []struct {
+		product, summary, description string
+	}{
+		{"Red Hat Enterprise Linux", "Kernel panic after update to latest kernel on Dell R750", "After applying the latest errata, two of our database hosts panic during boot with a NULL pointer dereference in the megaraid_sas driver.\n\nThe previous kernel boots fine. A vmcore is attached."},
+		{"Red Hat Enterprise Linux", "NFS mounts hang intermittently under heavy load", "Clients mounting our NetApp exports with NFSv4.2 hang for several minutes when nightly backups run. dmesg shows 'server not responding, still trying'."},
+		{"Red Hat Enterprise Linux", "SELinux denials for custom systemd service", "Our in-house agent fails to start in enforcing mode. ausearch shows AVC denials for name_connect on port 8443."},
+		{"Red Hat Enterprise Linux", "Leapp upgrade inhibited by unsupported network configuration", "leapp preupgrade reports an inhibitor for legacy network-scripts ifcfg files on our bonded interfaces."},
+		{"Red Hat OpenShift Container Platform", "Cluster upgrade stuck at 84% on machine-config operator", "The upgrade from 4.15 to 4.16 has been stuck for six hours. The machine-config operator reports a degraded worker pool."},
+		{"Red Hat OpenShift Container Platform", "Ingress router pods restarting with OOMKilled", "Router pods in openshift-ingress restart every few hours with OOMKilled since we onboarded a large number of routes."},
+		{"Red Hat OpenShift Container Platform", "etcd reporting slow fdatasync on control plane nodes", "etcd logs warn about slow fdatasync above 1s and the API server becomes unresponsive during peaks."},
+		{"Red Hat Ansible Automation Platform", "Job templates fail with 'Failed to JSON parse a line from worker stream'", "Since upgrading the execution environment image, long running jobs fail near the end with a receptor stream error."},
+		{"Red Hat Ansible Automation Platform", "Automation hub sync from console.redhat.com times out", "Remote repository sync for certified collections fails after 30 minutes with a timeout."},
+		{"Red Hat Satellite", "Content view publish takes over 12 hours", "Publishing our composite content view has slowed dramatically after adding the RHEL 9 AppStream repository."},
+		{"Red Hat Satellite", "Hosts not reporting facts after capsule migration", "After moving hosts to the new capsule, subscription-manager facts stop updating and hosts show as out of sync."},
+		{"Red Hat OpenShift Data Foundation", "Ceph health warning: 1 pool(s) nearfull", "ODF reports a nearfull pool even though the dashboard shows 40% used capacity."},
+		{"Red Hat Enterprise Linux AI", "InstructLab training fails with CUDA out of memory", "Multi-phase training fails on 8x A100 nodes with CUDA OOM during the knowledge phase."},
+		{"Red Hat Enterprise Linux", "Request for hotfix: glibc regression in getaddrinfo", "A regression in getaddrinfo causes lookups to fail when /etc/hosts contains IPv6 link-local entries."},
+		{"Red Hat OpenShift Container Platform", "Documentation request: disconnected mirroring with oc-mirror v2", "We need guidance on migrating ImageSetConfiguration from oc-mirror v1 to v2 for our air-gapped clusters."},
+	}
+	fixtureCustomerReplies = []string{
+		"Thanks. I have attached a fresh sosreport from the affected host.",
+		"We reproduced the issue again this morning at 09:40 UTC.",
+		"The workaround helped, but the problem returned after the maintenance window.",
+		"Can we get an update? This is impacting our production rollout.",
+		"Confirmed, the test package resolves the issue on our staging systems.",
+	}
+	fixtureSupportReplies = []string{
+		"Hello,\n\nThank you for contacting Red Hat support. I am reviewing the data you provided and will update you shortly.",
+		"I have analysed the logs. The failure matches a known issue; please try the workaround in the linked solution and let me know the result.",
+		"Could you please collect a sosreport from the affected system and attach it to this case?",
+		"Engineering has confirmed a fix is planned for the next batch update. I will keep this case open until it is released.",
+		"Based on your confirmation, I will go ahead and close this case. Feel free to reopen it if the issue comes back.",
+	}
+	fixtureKCS = []KCSDoc{
+		{"3418891", "Solution", "Kernel panic in megaraid_sas after kernel update", "Systems using PERC controllers may panic on boot after updating the kernel. Boot the previous kernel while a fix is released."},
+		{"6960923", "Solution", "NFS client hangs with 'server not responding'", "Tune the NFS timeo and retrans options and verify network congestion between client and server."},
+		{"5467241", "Solution", "OpenShift upgrade stuck on machine-config operator", "A degraded MachineConfigPool blocks upgrades. Check for nodes with unexpected on-disk state."},
+		{"4960831", "Article", "Understanding etcd performance requirements", "etcd is sensitive to disk latency. This article describes how to measure fdatasync latency with fio."},
+		{"7001876", "Solution", "Automation controller jobs fail parsing worker stream", "Increase the receptor work buffer or update the execution environment to the latest image."},
+		{"5952541", "Article", "Troubleshooting slow content view publishing in Satellite", "Steps to diagnose Pulp task performance and database bloat in Satellite 6."},
+		{"7012345", "Solution", "Leapp inhibitor for legacy network-scripts", "Convert ifcfg files to NetworkManager keyfiles with nmcli before running the upgrade."},
+		{"3109161", "Article", "How to collect a sosreport", "Instructions for generating a sosreport on RHEL and uploading it to a support case."},
+	}
+)
+
+// DefaultFixtures returns a deterministic set of cases with dates relative to now
+func DefaultFixtures(now time.Time) *Fixtures {
+	now = now.UTC().Truncate(time.Minute)
+	rng := rand.New(rand.NewPCG(2026, 1))
+
+	fx := &Fixtures{
+		User:      fixtureContacts[0].name,
+		Accounts:  fixtureAccounts,
+		Forbidden: []string{"03999999"},
+		Products:  fixtureProducts,
+		KCS:       fixtureKCS,
+	}
+
+	statuses := []string{"Waiting on Red Hat", "Waiting on Customer", "Waiting on Red Hat", "Closed"}
+	severities := []string{"1 (Urgent)", "2 (High)", "3 (Normal)", "3 (Normal)", "4 (Low)"}
+	types := []string{"Standard", "Standard", "Bug", "Feature Request", "Documentation"}
+
+	const numCases = 36
+	for i := 0; i < numCases; i++ {
+		issue := fixtureIssues[i%len(fixtureIssues)]
+		account := fixtureAccounts[0]
+		contact := fixtureContacts[i%2]
+		if i%5 == 4 {
+			account = fixtureAccounts[1]
+			contact = fixtureContacts[2]
+		}
+		versions := fixtureProducts[issue.product]
+
+		created := now.Add(-time.Duration(2+i*i*3+rng.IntN(48)) * time.Hour)
+		status := statuses[rng.IntN(len(statuses))]
+		if i < 4 {
+			status = "Waiting on Red Hat"
+		}
+
+		c := Case{
+			Case: api.Case{
+				CaseNumber:    fmt.Sprintf("0395%04d", 1000-i*17),
+				Summary:       issue.summary,
+				Description:   issue.description,
+				Status:        status,
+				Severity:      severities[rng.IntN(len(severities))],
+				Product:       issue.product,
+				Version:       versions[rng.IntN(len(versions))],
+				Type:          types[rng.IntN(len(types))],
+				AccountNumber: account.Number,
+				AccountName:   account.Name,
+				ContactName:   contact.name,
+				ContactEmail:  contact.email,
+				Owner:         fixtureEngineers[rng.IntN(len(fixtureEngineers))],
+				CreatedBy:     contact.name,
+				CreatedDate:   created,
+			},
+			GroupNumber: fixtureGroups[i%len(fixtureGroups)],
+		}
+		c.URI = "https://access.redhat.com/support/cases/#/case/" + c.CaseNumber
+
+		// Alternate support and customer replies after the case opens
+		last := created
+		for j, n := 0, 1+rng.IntN(7); j < n; j++ {
+			at := last.Add(time.Duration(30+rng.IntN(600)) * time.Minute)
+			if at.After(now) {
+				break
+			}
+			last = at
+			cm := api.Comment{
+				ID:          fmt.Sprintf("a0a%s%03d", c.CaseNumber, j),
+				CaseNumber:  c.CaseNumber,
+				CreatedDate: at,
+				Public:      true,
+			}
+			if j%2 == 0 {
+				cm.Author = c.Owner
+				cm.CommentBody = fixtureSupportReplies[rng.IntN(len(fixtureSupportReplies))]
+			} else {
+				cm.Author = contact.name
+				cm.AuthorEmail = contact.email
+				cm.CommentBody = fixtureCustomerReplies[rng.IntN(len(fixtureCustomerReplies))]
+			}
+			c.Comments = append(c.Comments, cm)
+		}
+		c.LastModified = last
+		if status == "Closed" {
+			closed := last
+			c.ClosedDate = &closed
+		}
+
+		if i%3 == 0 {
+			content := []byte(fmt.Sprintf("sosreport placeholder for case %s\n", c.CaseNumber))
+			c.Attachments = append(c.Attachments, Attachment{
+				Attachment: api.Attachment{
+					UUID:        fmt.Sprintf("5e0d7c1a-0000-4000-8000-%012d", i),
+					Filename:    fmt.Sprintf("sosreport-host%02d-%s.tar.xz", i, c.CaseNumber),
+					Length:      int64(len(content)),
+					MimeType:    "application/x-xz",
+					CreatedBy:   contact.name,
+					CreatedDate: created.Add(time.Hour),
+				},
+				Content: content,
+			})
+		}
+
+		fx.Cases = append(fx.Cases, c)
+	}
+
+	return fx
+}
@@ -0,0 +1,739 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package apitest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/green/agcm/internal/api"
+	"github.com/green/agcm/internal/auth"
+)
+
+const (
+	// OfflineToken is the offline token the fake SSO endpoint accepts
+	OfflineToken = "agcm-demo-offline-token"
+
+	// Service account credentials the fake SSO endpoint accepts
+	ServiceAccountID     = "agcm-demo-client"
+	ServiceAccountSecret = "agcm-demo-secret"
+
+	// TokenPath is the SSO token endpoint path, relative to the server URL
+	TokenPath = "/auth/realms/redhat-external/protocol/openid-connect/token"
+
+	// DefaultTokenLifetime is how long issued access tokens stay valid
+	DefaultTokenLifetime = 15 * time.Minute
+)
+
+// Server is a fake Customer Portal serving the case, search and SSO
+// endpoints agcm uses from fixture data. Writes change the fixtures, so a
+// created case or posted comment is visible to later requests.
+type Server struct {
+	// URL is the base URL of the portal, Hydra and SSO endpoints
+	URL string
+
+	// TokenLifetime is the expiry reported for newly issued access tokens
+	TokenLifetime time.Duration
+
+	srv    *httptest.Server
+	mu     sync.Mutex
+	fx     *Fixtures
+	tokens map[string]time.Time // Access token to expiry
+	nextID int
+}
+
+// NewServer starts a fake portal serving fx. Call Close when done.
+func NewServer(fx *Fixtures) *Server {
+	s := &Server{
+		TokenLifetime: DefaultTokenLifetime,
+		fx:            fx,
+		tokens:        make(map[string]time.Time),
+		nextID:        1,
+	}
+	s.srv = httptest.NewServer(s.Handler())
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// TokenURL returns the URL of the fake SSO token endpoint
+func (s *Server) TokenURL() string {
+	return s.URL + TokenPath
+}
+
+// AccessToken issues an access token directly, bypassing the SSO exchange
+func (s *Server) AccessToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.issueToken()
+}
+
+// NewClient returns an API client that authenticates against this server
+// with OfflineToken. Options are applied after the server's own.
+func (s *Server) NewClient(opts ...api.ClientOption) *api.Client {
+	tm := auth.NewTokenManager(OfflineToken, auth.WithTokenURL(s.TokenURL()))
+	base := []api.ClientOption{
+		api.WithBaseURL(s.URL),
+		api.WithTokenRefresher(tm.GetAccessToken),
+	}
+	return api.NewClient(append(base, opts...)...)
+}
+
+// Handler returns the HTTP handler, for mounting the fake portal elsewhere
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+TokenPath, s.handleToken)
+
+	mux.HandleFunc("POST /support/v1/cases", s.authed(s.handleCreateCase))
+	mux.HandleFunc("GET /support/v1/cases/{number}", s.authed(s.handleGetCase))
+	mux.HandleFunc("PUT /support/v1/cases/{number}", s.authed(s.handleUpdateCase))
+	mux.HandleFunc("GET /support/v1/cases/{number}/comments", s.authed(s.handleListComments))
+	mux.HandleFunc("POST /support/v1/cases/{number}/comments", s.authed(s.handleAddComment))
+	mux.HandleFunc("GET /support/v1/cases/{number}/attachments", s.authed(s.handleListAttachments))
+	mux.HandleFunc("POST /support/v1/cases/{number}/attachments", s.authed(s.handleUploadAttachment))
+	mux.HandleFunc("GET /support/v1/cases/{number}/attachments/{uuid}", s.authed(s.handleDownloadAttachment))
+
+	mux.HandleFunc("POST /hydra/rest/search/v2/cases", s.authed(s.handleCaseSearch))
+	mux.HandleFunc("POST /support/search/v2/kcs", s.authed(s.handleKCSSearch))
+
+	mux.HandleFunc("GET /rs/products", s.authed(s.handleListProducts))
+	mux.HandleFunc("GET /rs/products/{product}/versions", s.authed(s.handleProductVersions))
+	mux.HandleFunc("GET /rs/groups", s.authed(s.handleListGroups))
+	return mux
+}
+
+// issueToken creates a new access token; s.mu must be held
+func (s *Server) issueToken() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	token := "fake-" + hex.EncodeToString(b)
+	s.tokens[token] = time.Now().Add(s.TokenLifetime)
+	return token
+}
+
+// authed rejects requests without a current access token, as the portal does
+func (s *Server) authed(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		s.mu.Lock()
+		expiry, known := s.tokens[token]
+		s.mu.Unlock()
+		if !ok || !known || time.Now().After(expiry) {
+			writeError(w, http.StatusUnauthorized, "invalid or expired access token")
+			return
+		}
+		w.Header().Set("X-Request-Id", requestID())
+		h(w, r)
+	}
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request", "error_description": err.Error()})
+		return
+	}
+	switch r.PostForm.Get("grant_type") {
+	case "refresh_token":
+		if r.PostForm.Get("refresh_token") != OfflineToken {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "Invalid refresh token"})
+			return
+		}
+	case "client_credentials":
+		if r.PostForm.Get("client_id") != ServiceAccountID || r.PostForm.Get("client_secret") != ServiceAccountSecret {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client", "error_description": "Invalid client or Invalid client credentials"})
+			return
+		}
+	default:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type", "error_description": "Unsupported grant_type"})
+		return
+	}
+
+	s.mu.Lock()
+	token := s.issueToken()
+	lifetime := s.TokenLifetime
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, auth.TokenResponse{
+		AccessToken: token,
+		ExpiresIn:   int(lifetime / time.Second),
+		TokenType:   "Bearer",
+		Scope:       "offline_access",
+	})
+}
+
+// lookupCase finds a case and writes a 404 or 403 when it is not visible;
+// s.mu must be held
+func (s *Server) lookupCase(w http.ResponseWriter, number string) *Case {
+	for i := range s.fx.Cases {
+		if s.fx.Cases[i].CaseNumber == number {
+			return &s.fx.Cases[i]
+		}
+	}
+	if slices.Contains(s.fx.Forbidden, number) {
+		writeError(w, http.StatusForbidden, "You do not have access to case "+number)
+	} else {
+		writeError(w, http.StatusNotFound, "Case "+number+" not found")
+	}
+	return nil
+}
+
+func (s *Server) handleGetCase(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.lookupCase(w, r.PathValue("number"))
+	if c == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, c.Case)
+}
+
+func (s *Server) handleCreateCase(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateCaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	versions, ok := s.fx.Products[req.Product]
+	switch {
+	case req.Summary == "" || req.Description == "":
+		writeError(w, http.StatusBadRequest, "summary and description are required")
+		return
+	case !ok:
+		writeError(w, http.StatusBadRequest, "unknown product "+strconv.Quote(req.Product))
+		return
+	case !slices.Contains(versions, req.Version):
+		writeError(w, http.StatusBadRequest, "unknown version "+strconv.Quote(req.Version)+" for "+req.Product)
+		return
+	}
+
+	account := s.fx.Accounts[0]
+	if req.AccountNumber != "" {
+		i := slices.IndexFunc(s.fx.Accounts, func(a Account) bool { return a.Number == req.AccountNumber })
+		if i < 0 {
+			writeError(w, http.StatusForbidden, "You do not have access to account "+req.AccountNumber)
+			return
+		}
+		account = s.fx.Accounts[i]
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	number := fmt.Sprintf("0400%04d", s.nextID)
+	s.nextID++
+	c := Case{
+		Case: api.Case{
+			CaseNumber:    number,
+			Summary:       req.Summary,
+			Description:   req.Description,
+			Status:        "Waiting on Red Hat",
+			Severity:      req.Severity,
+			Product:       req.Product,
+			Version:       req.Version,
+			Type:          req.Type,
+			AccountNumber: account.Number,
+			AccountName:   account.Name,
+			ContactName:   s.fx.User,
+			CreatedBy:     s.fx.User,
+			CreatedDate:   now,
+			LastModified:  now,
+			URI:           "https://access.redhat.com/support/cases/#/case/" + number,
+		},
+		GroupNumber: req.GroupNumber,
+	}
+	if c.Severity == "" {
+		c.Severity = "3 (Normal)"
+	}
+	if c.Type == "" {
+		c.Type = "Standard"
+	}
+	s.fx.Cases = append(s.fx.Cases, c)
+
+	// Like the portal, answer with only a Location header
+	w.Header().Set("Location", s.URL+"/support/v1/cases/"+number)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) handleUpdateCase(w http.ResponseWriter, r *http.Request) {
+	var update api.CaseUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.lookupCase(w, r.PathValue("number"))
+	if c == nil {
+		return
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	if update.Severity != "" {
+		c.Severity = update.Severity
+	}
+	if update.Summary != "" {
+		c.Summary = update.Summary
+	}
+	if update.ContactSSOName != "" {
+		c.ContactName = update.ContactSSOName
+	}
+	if update.Status != "" {
+		c.Status = update.Status
+		if update.Status == "Closed" {
+			c.ClosedDate = &now
+		} else {
+			c.ClosedDate = nil
+		}
+	}
+	c.LastModified = now
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleListComments(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.lookupCase(w, r.PathValue("number"))
+	if c == nil {
+		return
+	}
+	comments := c.Comments
+	if comments == nil {
+		comments = []api.Comment{}
+	}
+	writeJSON(w, http.StatusOK, comments)
+}
+
+func (s *Server) handleAddComment(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		CommentBody string `json:"commentBody"`
+		IsDraft     bool   `json:"isDraft"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if strings.TrimSpace(req.CommentBody) == "" {
+		writeError(w, http.StatusBadRequest, "commentBody is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.lookupCase(w, r.PathValue("number"))
+	if c == nil {
+		return
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	cm := api.Comment{
+		ID:          fmt.Sprintf("a0a%s%03d", c.CaseNumber, len(c.Comments)),
+		CaseNumber:  c.CaseNumber,
+		CommentBody: req.CommentBody,
+		Author:      s.fx.User,
+		CreatedDate: now,
+		Public:      true,
+		Draft:       req.IsDraft,
+	}
+	c.Comments = append(c.Comments, cm)
+	c.LastModified = now
+	writeJSON(w, http.StatusCreated, cm)
+}
+
+func (s *Server) handleListAttachments(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.lookupCase(w, r.PathValue("number"))
+	if c == nil {
+		return
+	}
+	atts := make([]api.Attachment, 0, len(c.Attachments))
+	for _, a := range c.Attachments {
+		atts = append(atts, a.Attachment)
+	}
+	writeJSON(w, http.StatusOK, atts)
+}
+
+func (s *Server) handleUploadAttachment(w http.ResponseWriter, r *http.Request) {
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "missing file part: "+err.Error())
+		return
+	}
+	defer func() { _ = file.Close() }()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "failed to read upload: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.lookupCase(w, r.PathValue("number"))
+	if c == nil {
+		return
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	att := Attachment{
+		Attachment: api.Attachment{
+			UUID:        fmt.Sprintf("5e0d7c1a-0000-4000-9000-%012d", s.nextID),
+			Filename:    header.Filename,
+			Length:      int64(len(content)),
+			MimeType:    header.Header.Get("Content-Type"),
+			CreatedBy:   s.fx.User,
+			CreatedDate: now,
+		},
+		Content: content,
+	}
+	s.nextID++
+	c.Attachments = append(c.Attachments, att)
+	c.LastModified = now
+	writeJSON(w, http.StatusCreated, att.Attachment)
+}
+
+func (s *Server) handleDownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.lookupCase(w, r.PathValue("number"))
+	if c == nil {
+		return
+	}
+	uuid := r.PathValue("uuid")
+	for _, a := range c.Attachments {
+		if a.UUID == uuid {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", a.Filename))
+			w.Header().Set("Content-Length", strconv.Itoa(len(a.Content)))
+			_, _ = w.Write(a.Content)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Attachment "+uuid+" not found")
+}
+
+// hydraDoc converts a case to the Hydra search document form
+func hydraDoc(c *Case) doc {
+	d := doc{
+		"case_number":             c.CaseNumber,
+		"case_summary":            c.Summary,
+		"case_description":        c.Description,
+		"case_status":             c.Status,
+		"case_severity":           c.Severity,
+		"case_product":            []string{c.Product},
+		"case_version":            c.Version,
+		"case_type":               c.Type,
+		"case_owner":              c.Owner,
+		"case_accountNumber":      c.AccountNumber,
+		"case_contactName":        c.ContactName,
+		"case_createdDate":        c.CreatedDate.UTC().Format(time.RFC3339),
+		"case_createdByName":      c.CreatedBy,
+		"case_lastModifiedDate":   c.LastModified.UTC().Format(time.RFC3339),
+		"case_lastModifiedByName": c.ContactName,
+		"uri":                     c.URI,
+	}
+	if c.GroupNumber != "" {
+		d["case_groupNumber"] = c.GroupNumber
+	}
+	if c.ClosedDate != nil {
+		d["case_closedDate"] = c.ClosedDate.UTC().Format(time.RFC3339)
+	}
+	if n := len(c.Comments); n > 0 {
+		d["case_lastModifiedByName"] = c.Comments[n-1].Author
+	}
+	return d
+}
+
+func (s *Server) handleCaseSearch(w http.ResponseWriter, r *http.Request) {
+	var req api.HydraSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	params, err := url.ParseQuery(req.Expression)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid expression: "+err.Error())
+		return
+	}
+
+	q, err := parseQuery(req.Query)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid query: "+err.Error())
+		return
+	}
+	filters := andQuery{q}
+	for _, fq := range params["fq"] {
+		m, err := parseQuery(fq)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid fq "+strconv.Quote(fq)+": "+err.Error())
+			return
+		}
+		filters = append(filters, m)
+	}
+
+	s.mu.Lock()
+	var docs []doc
+	for i := range s.fx.Cases {
+		if d := hydraDoc(&s.fx.Cases[i]); filters.match(d) {
+			docs = append(docs, d)
+		}
+	}
+	s.mu.Unlock()
+
+	if err := sortDocs(docs, params.Get("sort")); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var resp struct {
+		Response struct {
+			NumFound int   `json:"numFound"`
+			Start    int   `json:"start"`
+			Docs     []doc `json:"docs"`
+		} `json:"response"`
+		FacetCounts *struct {
+			FacetFields map[string][]any `json:"facet_fields"`
+		} `json:"facet_counts,omitempty"`
+	}
+	resp.Response.NumFound = len(docs)
+	resp.Response.Start = req.Start
+	resp.Response.Docs = selectFields(page(docs, req.Start, req.Rows), params.Get("fl"))
+
+	if params.Get("facet") == "on" || params.Get("facet") == "true" {
+		limit := 100
+		if l := params.Get("facet.limit"); l != "" {
+			if limit, err = strconv.Atoi(l); err != nil {
+				writeError(w, http.StatusBadRequest, "invalid facet.limit "+strconv.Quote(l))
+				return
+			}
+		}
+		resp.FacetCounts = &struct {
+			FacetFields map[string][]any `json:"facet_fields"`
+		}{FacetFields: make(map[string][]any)}
+		for _, field := range params["facet.field"] {
+			resp.FacetCounts.FacetFields[field] = facet(docs, field, limit)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// sortDocs orders docs by a Solr sort spec such as "case_lastModifiedDate desc"
+func sortDocs(docs []doc, spec string) error {
+	type key struct {
+		field string
+		desc  bool
+	}
+	var keys []key
+	for _, part := range strings.Split(spec, ",") {
+		f := strings.Fields(part)
+		switch {
+		case len(f) == 0:
+			continue
+		case len(f) != 2 || (f[1] != "asc" && f[1] != "desc"):
+			return fmt.Errorf("invalid sort %q", strings.TrimSpace(part))
+		}
+		keys = append(keys, key{f[0], f[1] == "desc"})
+	}
+	sort.SliceStable(docs, func(i, j int) bool {
+		for _, k := range keys {
+			a, b := first(docs[i].values(k.field)), first(docs[j].values(k.field))
+			if c := compareValues(a, b); c != 0 {
+				return (c < 0) != k.desc
+			}
+		}
+		return false
+	})
+	return nil
+}
+
+// selectFields keeps only the comma-separated fields in fl, or all when empty
+func selectFields(docs []doc, fl string) []doc {
+	out := make([]doc, 0, len(docs))
+	if fl == "" || fl == "*" {
+		return append(out, docs...)
+	}
+	fields := strings.Split(fl, ",")
+	for _, d := range docs {
+		sel := doc{}
+		for _, f := range fields {
+			if v, ok := d[strings.TrimSpace(f)]; ok {
+				sel[strings.TrimSpace(f)] = v
+			}
+		}
+		out = append(out, sel)
+	}
+	return out
+}
+
+// facet counts field values in Solr's flat [value, count, ...] form, most
+// frequent first. A negative limit returns every value.
+func facet(docs []doc, field string, limit int) []any {
+	counts := make(map[string]int)
+	for _, d := range docs {
+		for _, v := range d.values(field) {
+			counts[v]++
+		}
+	}
+	values := make([]string, 0, len(counts))
+	for v := range counts {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if counts[values[i]] != counts[values[j]] {
+			return counts[values[i]] > counts[values[j]]
+		}
+		return values[i] < values[j]
+	})
+	if limit >= 0 && len(values) > limit {
+		values = values[:limit]
+	}
+	out := make([]any, 0, 2*len(values))
+	for _, v := range values {
+		out = append(out, v, counts[v])
+	}
+	return out
+}
+
+func (s *Server) handleKCSSearch(w http.ResponseWriter, r *http.Request) {
+	var req api.KCSSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	q, err := parseQuery(req.Query)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid query: "+err.Error())
+		return
+	}
+
+	var docs []doc
+	for _, k := range s.fx.KCS {
+		d := doc{
+			"id":           k.ID,
+			"allTitle":     k.Title,
+			"abstract":     k.Abstract,
+			"documentKind": k.Kind,
+			"view_uri":     kcsURI(k),
+		}
+		if q.match(d) {
+			docs = append(docs, d)
+		}
+	}
+
+	var resp struct {
+		Response struct {
+			NumFound int   `json:"numFound"`
+			Start    int   `json:"start"`
+			Docs     []doc `json:"docs"`
+		} `json:"response"`
+	}
+	resp.Response.NumFound = len(docs)
+	resp.Response.Start = req.Start
+	rows := req.Rows
+	if rows == 0 {
+		rows = 10
+	}
+	resp.Response.Docs = page(docs, req.Start, rows)
+	if resp.Response.Docs == nil {
+		resp.Response.Docs = []doc{}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// kcsURI returns the portal URL of a knowledgebase document
+func kcsURI(k KCSDoc) string {
+	if k.Kind == "Solution" {
+		return "https://access.redhat.com/solutions/" + k.ID
+	}
+	return "https://access.redhat.com/articles/" + k.ID
+}
+
+func (s *Server) handleListProducts(w http.ResponseWriter, r *http.Request) {
+	names := make([]string, 0, len(s.fx.Products))
+	for name := range s.fx.Products {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	products := make([]api.Product, 0, len(names))
+	for _, name := range names {
+		products = append(products, api.Product{Name: name, Versions: s.fx.Products[name]})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"product": products})
+}
+
+func (s *Server) handleProductVersions(w http.ResponseWriter, r *http.Request) {
+	versions, ok := s.fx.Products[r.PathValue("product")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Product "+r.PathValue("product")+" not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"version": versions})
+}
+
+func (s *Server) handleListGroups(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := make(map[string]bool)
+	groups := []api.Group{}
+	for _, c := range s.fx.Cases {
+		if c.GroupNumber != "" && !seen[c.GroupNumber] {
+			seen[c.GroupNumber] = true
+			groups = append(groups, api.Group{Number: c.GroupNumber, Name: "Group " + c.GroupNumber})
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Number < groups[j].Number })
+	writeJSON(w, http.StatusOK, map[string]any{"group": groups})
+}
+
+// page returns the rows starting at start
+func page[T any](items []T, start, rows int) []T {
+	if start < 0 || start >= len(items) || rows <= 0 {
+		return nil
+	}
+	return items[start:min(start+rows, len(items))]
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError answers with the portal's JSON error shape
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"message": msg})
+}
+
+func requestID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
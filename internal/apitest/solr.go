@@ -0,0 +1,409 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package apitest
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// doc is a Hydra search document: field name to string or []string value
+type doc map[string]any
+
+// values returns a document field as a list of strings
+func (d doc) values(field string) []string {
+	switch v := d[field].(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	}
+	return nil
+}
+
+// matcher is a parsed Solr query
+type matcher interface {
+	match(d doc) bool
+}
+
+type (
+	andQuery  []matcher
+	orQuery   []matcher
+	notQuery  struct{ m matcher }
+	allQuery  struct{}
+	termQuery struct {
+		field string // Empty for free text
+		value string
+		exact bool // Quoted phrase rather than a term that may contain wildcards
+	}
+	rangeQuery struct {
+		field          string
+		lo, hi         string // "*" for open ends
+		loExcl, hiExcl bool   // {lo and hi} bounds
+	}
+)
+
+func (q andQuery) match(d doc) bool {
+	for _, m := range q {
+		if !m.match(d) {
+			return false
+		}
+	}
+	return true
+}
+
+func (q orQuery) match(d doc) bool {
+	for _, m := range q {
+		if m.match(d) {
+			return true
+		}
+	}
+	return false
+}
+
+func (q notQuery) match(d doc) bool { return !q.m.match(d) }
+
+func (allQuery) match(doc) bool { return true }
+
+// freeTextFields are searched by terms without a field, in case and KCS documents
+var freeTextFields = []string{"case_number", "case_summary", "case_description", "allTitle", "abstract"}
+
+func (q termQuery) match(d doc) bool {
+	fields := []string{q.field}
+	if q.field == "" {
+		fields = freeTextFields
+	}
+	for _, f := range fields {
+		for _, v := range d.values(f) {
+			if q.matchValue(v, q.field == "") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchValue compares case-insensitively. Free text matches substrings,
+// field values must match whole, and unquoted terms may use * and ? wildcards.
+func (q termQuery) matchValue(v string, substring bool) bool {
+	v, want := strings.ToLower(v), strings.ToLower(q.value)
+	if !q.exact && strings.ContainsAny(want, "*?") {
+		if substring {
+			want = "*" + want + "*"
+		}
+		ok, _ := path.Match(want, v)
+		return ok
+	}
+	if substring {
+		return strings.Contains(v, want)
+	}
+	return v == want
+}
+
+func (q rangeQuery) match(d doc) bool {
+	for _, v := range d.values(q.field) {
+		if q.lo != "*" {
+			if c := compareValues(v, q.lo); c < 0 || c == 0 && q.loExcl {
+				continue
+			}
+		}
+		if q.hi != "*" {
+			if c := compareValues(v, q.hi); c > 0 || c == 0 && q.hiExcl {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// compareValues orders dates chronologically and everything else as strings
+func compareValues(a, b string) int {
+	ta, errA := time.Parse(time.RFC3339, a)
+	tb, errB := time.Parse(time.RFC3339, b)
+	if errA == nil && errB == nil {
+		return ta.Compare(tb)
+	}
+	return strings.Compare(a, b)
+}
+
+// parseQuery parses the subset of Solr syntax agcm sends: field:value,
+// field:"phrase", field:(a OR b), field:[lo TO hi] or {lo TO hi}, free text, -/NOT, AND, OR,
+// and parentheses
+func parseQuery(s string) (matcher, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "*:*" || s == "*" {
+		return allQuery{}, nil
+	}
+	p := &queryParser{src: []rune(s)}
+	m, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return nil, fmt.Errorf("unexpected %q at offset %d", string(p.src[p.pos]), p.pos)
+	}
+	return m, nil
+}
+
+type queryParser struct {
+	src []rune
+	pos int
+}
+
+func (p *queryParser) skipSpace() {
+	for p.pos < len(p.src) && unicode.IsSpace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *queryParser) peek() rune {
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+	return 0
+}
+
+// keyword consumes word if it appears next as a whole word
+func (p *queryParser) keyword(word string) bool {
+	p.skipSpace()
+	end := p.pos + len(word)
+	if end > len(p.src) || string(p.src[p.pos:end]) != word {
+		return false
+	}
+	if end < len(p.src) && !unicode.IsSpace(p.src[end]) && p.src[end] != '(' {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+func (p *queryParser) parseOr() (matcher, error) {
+	var alts orQuery
+	for {
+		m, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		alts = append(alts, m)
+		if !p.keyword("OR") {
+			break
+		}
+	}
+	if len(alts) == 1 {
+		return alts[0], nil
+	}
+	return alts, nil
+}
+
+func (p *queryParser) parseAnd() (matcher, error) {
+	var all andQuery
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.src) || p.peek() == ')' {
+			break
+		}
+		if len(all) > 0 {
+			// "OR" ends this conjunction; "AND" is implied between clauses
+			save := p.pos
+			if p.keyword("OR") {
+				p.pos = save
+				break
+			}
+			p.keyword("AND")
+		}
+		m, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, m)
+	}
+	switch len(all) {
+	case 0:
+		return nil, fmt.Errorf("expected a query at offset %d", p.pos)
+	case 1:
+		return all[0], nil
+	}
+	return all, nil
+}
+
+func (p *queryParser) parseUnary() (matcher, error) {
+	p.skipSpace()
+	switch {
+	case p.peek() == '-':
+		p.pos++
+		m, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notQuery{m}, nil
+	case p.peek() == '+':
+		p.pos++
+		return p.parseUnary()
+	case p.keyword("NOT"):
+		m, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notQuery{m}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (matcher, error) {
+	p.skipSpace()
+	if p.peek() == '(' {
+		p.pos++
+		m, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("missing ) at offset %d", p.pos)
+		}
+		p.pos++
+		return m, nil
+	}
+
+	if p.peek() == '"' {
+		phrase, err := p.parseQuoted()
+		if err != nil {
+			return nil, err
+		}
+		return termQuery{value: phrase, exact: true}, nil
+	}
+
+	word := p.parseBare()
+	if word == "" {
+		return nil, fmt.Errorf("unexpected %q at offset %d", string(p.peek()), p.pos)
+	}
+	if p.peek() != ':' {
+		return termQuery{value: word}, nil
+	}
+	p.pos++
+	if word == "*" && p.peek() == '*' {
+		p.pos++
+		return allQuery{}, nil
+	}
+	return p.parseFieldValue(word)
+}
+
+// parseFieldValue parses what follows "field:"
+func (p *queryParser) parseFieldValue(field string) (matcher, error) {
+	switch p.peek() {
+	case '"':
+		v, err := p.parseQuoted()
+		if err != nil {
+			return nil, err
+		}
+		return termQuery{field: field, value: v, exact: true}, nil
+	case '[', '{':
+		loExcl := p.peek() == '{'
+		p.pos++
+		p.skipSpace()
+		lo := p.parseBound()
+		if !p.keyword("TO") {
+			return nil, fmt.Errorf("expected TO in range at offset %d", p.pos)
+		}
+		p.skipSpace()
+		hi := p.parseBound()
+		p.skipSpace()
+		if (p.peek() != ']' && p.peek() != '}') || lo == "" || hi == "" {
+			return nil, fmt.Errorf("malformed range at offset %d", p.pos)
+		}
+		hiExcl := p.peek() == '}'
+		p.pos++
+		return rangeQuery{field: field, lo: lo, hi: hi, loExcl: loExcl, hiExcl: hiExcl}, nil
+	case '(':
+		// field:(a OR b) applies the field to every term inside
+		p.pos++
+		var alts orQuery
+		for {
+			p.skipSpace()
+			if p.peek() == ')' {
+				p.pos++
+				break
+			}
+			if p.pos >= len(p.src) {
+				return nil, fmt.Errorf("missing ) at offset %d", p.pos)
+			}
+			if p.keyword("OR") {
+				continue
+			}
+			var t termQuery
+			if p.peek() == '"' {
+				v, err := p.parseQuoted()
+				if err != nil {
+					return nil, err
+				}
+				t = termQuery{field: field, value: v, exact: true}
+			} else {
+				v := p.parseBare()
+				if v == "" {
+					return nil, fmt.Errorf("unexpected %q at offset %d", string(p.peek()), p.pos)
+				}
+				t = termQuery{field: field, value: v}
+			}
+			alts = append(alts, t)
+		}
+		return alts, nil
+	}
+	v := p.parseBare()
+	if v == "" {
+		return nil, fmt.Errorf("missing value for %s at offset %d", field, p.pos)
+	}
+	return termQuery{field: field, value: v}, nil
+}
+
+// parseQuoted parses a double-quoted string with backslash escapes
+func (p *queryParser) parseQuoted() (string, error) {
+	start := p.pos
+	p.pos++ // opening quote
+	var b strings.Builder
+	for p.pos < len(p.src) {
+		r := p.src[p.pos]
+		p.pos++
+		switch r {
+		case '\\':
+			if p.pos < len(p.src) {
+				b.WriteRune(p.src[p.pos])
+				p.pos++
+			}
+		case '"':
+			return b.String(), nil
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return "", fmt.Errorf("unterminated quote at offset %d", start)
+}
+
+// parseBare parses an unquoted term, stopping at syntax characters
+func (p *queryParser) parseBare() string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		r := p.src[p.pos]
+		if unicode.IsSpace(r) || strings.ContainsRune(`:()[]"`, r) {
+			break
+		}
+		if r == '\\' && p.pos+1 < len(p.src) {
+			p.pos++
+		}
+		p.pos++
+	}
+	return strings.ReplaceAll(string(p.src[start:p.pos]), `\`, "")
+}
+
+// parseBound parses a range endpoint, which may be a date containing colons
+func (p *queryParser) parseBound() string {
+	start := p.pos
+	for p.pos < len(p.src) && !unicode.IsSpace(p.src[p.pos]) && p.src[p.pos] != ']' && p.src[p.pos] != '}' {
+		p.pos++
+	}
+	return string(p.src[start:p.pos])
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OfflineIdleTimeout is how long Red Hat SSO keeps an unused offline token
+const OfflineIdleTimeout = 30 * 24 * time.Hour
+
+// Claims holds the informational claims of a token. They are decoded
+// without verifying the signature, so they are only fit for display.
+type Claims struct {
+	Issuer    string
+	Subject   string
+	Type      string    // e.g. "Offline" or "Refresh"
+	IssuedAt  time.Time // Zero if absent
+	ExpiresAt time.Time // Zero if the token has no fixed expiry
+}
+
+// ParseClaims decodes the payload of a JWT
+func ParseClaims(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("token is not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode token payload: %w", err)
+	}
+
+	var raw struct {
+		Iss string `json:"iss"`
+		Sub string `json:"sub"`
+		Typ string `json:"typ"`
+		Iat int64  `json:"iat"`
+		Exp int64  `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse token claims: %w", err)
+	}
+
+	c := &Claims{Issuer: raw.Iss, Subject: raw.Sub, Type: raw.Typ}
+	if raw.Iat > 0 {
+		c.IssuedAt = time.Unix(raw.Iat, 0)
+	}
+	if raw.Exp > 0 {
+		c.ExpiresAt = time.Unix(raw.Exp, 0)
+	}
+	return c, nil
+}
+
+// Expired reports whether the token's fixed expiry has passed
+func (c *Claims) Expired() bool {
+	return !c.ExpiresAt.IsZero() && time.Now().After(c.ExpiresAt)
+}
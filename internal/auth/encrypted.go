@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// EnvPassphrase supplies the passphrase of the encrypted token store
+const EnvPassphrase = "AGCM_PASSPHRASE"
+
+// Argon2id parameters for new files (RFC 9106 second recommended option)
+const (
+	argonTime    = 3
+	argonMemory  = 64 * 1024 // KiB
+	argonThreads = 4
+	keyLen       = 32
+	saltLen      = 16
+)
+
+// Largest Argon2id parameters accepted from a file, so a corrupt or
+// tampered one cannot exhaust memory or CPU
+const (
+	maxArgonTime   = 10
+	maxArgonMemory = 1024 * 1024 // KiB
+)
+
+// ErrWrongPassphrase means an encrypted credential could not be decrypted
+var ErrWrongPassphrase = errors.New("wrong passphrase")
+
+// encryptedFile is the on-disk form of an encrypted credential. The KDF
+// parameters are stored so they can be raised without breaking old files.
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Time       uint32 `json:"time"`
+	Memory     uint32 `json:"memory"`
+	Threads    uint8  `json:"threads"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// encrypt seals plaintext with AES-256-GCM under a key derived from the
+// passphrase with Argon2id
+func encrypt(plaintext []byte, passphrase string) ([]byte, error) {
+	f := encryptedFile{
+		Version: 1,
+		KDF:     "argon2id",
+		Time:    argonTime,
+		Memory:  argonMemory,
+		Threads: argonThreads,
+		Salt:    make([]byte, saltLen),
+	}
+	if _, err := rand.Read(f.Salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	gcm, err := f.cipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	f.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(f.Nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	f.Ciphertext = gcm.Seal(nil, f.Nonce, plaintext, nil)
+
+	return json.MarshalIndent(f, "", "  ")
+}
+
+// decrypt opens data written by encrypt
+func decrypt(data []byte, passphrase string) ([]byte, error) {
+	var f encryptedFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse encrypted file: %w", err)
+	}
+	if f.Version != 1 || f.KDF != "argon2id" {
+		return nil, fmt.Errorf("unsupported encrypted file (version %d, kdf %q)", f.Version, f.KDF)
+	}
+	if f.Time < 1 || f.Time > maxArgonTime || f.Memory > maxArgonMemory || f.Threads < 1 {
+		return nil, fmt.Errorf("corrupt encrypted file")
+	}
+
+	gcm, err := f.cipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if len(f.Nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("corrupt encrypted file")
+	}
+	plaintext, err := gcm.Open(nil, f.Nonce, f.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plaintext, nil
+}
+
+// cipher derives the file's key from the passphrase
+func (f *encryptedFile) cipher(passphrase string) (cipher.AEAD, error) {
+	key := argon2.IDKey([]byte(passphrase), f.Salt, f.Time, f.Memory, f.Threads, keyLen)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
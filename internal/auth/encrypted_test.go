@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestEncryptRoundTrip(t *testing.T) {
+	data, err := encrypt([]byte("offline-token"), "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext, err := decrypt(data, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plaintext) != "offline-token" {
+		t.Errorf("decrypted %q", plaintext)
+	}
+	if _, err := decrypt(data, "wrong"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("wrong passphrase error = %v", err)
+	}
+}
+
+func TestDecryptRejectsCostlyParameters(t *testing.T) {
+	data, err := encrypt([]byte("offline-token"), "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		tamper func(f *encryptedFile)
+	}{
+		{"time", func(f *encryptedFile) { f.Time = maxArgonTime + 1 }},
+		{"memory", func(f *encryptedFile) { f.Memory = 4 * 1024 * 1024 }},
+		{"no time", func(f *encryptedFile) { f.Time = 0 }},
+		{"no threads", func(f *encryptedFile) { f.Threads = 0 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var f encryptedFile
+			if err := json.Unmarshal(data, &f); err != nil {
+				t.Fatal(err)
+			}
+			tt.tamper(&f)
+			tampered, err := json.Marshal(&f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := decrypt(tampered, "correct horse"); err == nil || err.Error() != "corrupt encrypted file" {
+				t.Errorf("decrypt error = %v, want corrupt encrypted file", err)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -21,8 +22,33 @@ const (
 
 	// Token validity buffer - refresh before expiry
 	TokenExpiryBuffer = 60 * time.Second
+
+	// RefreshAhead is how long before TokenExpiryBuffer the background
+	// refresher replaces the access token
+	RefreshAhead = 2 * time.Minute
+
+	// Retry delay after a failed background refresh
+	refreshRetryDelay = 30 * time.Second
+
+	// Shortest wait between background refreshes, however briefly SSO
+	// tokens live
+	minRefreshInterval = 5 * time.Second
 )
 
+// ErrLoginRequired means SSO rejected the stored credentials: an offline
+// token that expired after going unused or was revoked, or a service
+// account whose secret changed
+var ErrLoginRequired = errors.New("credentials rejected by Red Hat SSO")
+
+// loginError explains why SSO rejected the credentials and matches
+// ErrLoginRequired
+type loginError struct {
+	msg string
+}
+
+func (e *loginError) Error() string { return e.msg }
+func (e *loginError) Unwrap() error { return ErrLoginRequired }
+
 // TokenResponse represents the OAuth token response
 type TokenResponse struct {
 	AccessToken      string `json:"access_token"`
@@ -36,20 +62,57 @@ type TokenResponse struct {
 // TokenManager handles OAuth token lifecycle
 type TokenManager struct {
 	offlineToken string
+	clientID     string
+	clientSecret string // Set for the client-credentials grant
 	accessToken  string
 	expiresAt    time.Time
+	lifetime     time.Duration // How long the current access token was issued for
+	tokenURL     string
+	loginCommand string
 	httpClient   *http.Client
 	mu           sync.RWMutex
 }
 
+// TokenManagerOption configures the TokenManager
+type TokenManagerOption func(*TokenManager)
+
+// WithTokenURL sets a custom SSO token endpoint
+func WithTokenURL(url string) TokenManagerOption {
+	return func(tm *TokenManager) {
+		tm.tokenURL = url
+	}
+}
+
+// WithClientID sets the OAuth client the offline token was issued to
+func WithClientID(id string) TokenManagerOption {
+	return func(tm *TokenManager) {
+		tm.clientID = id
+	}
+}
+
+// WithLoginCommand sets the command suggested when the credentials are
+// rejected; an empty command suggests none
+func WithLoginCommand(command string) TokenManagerOption {
+	return func(tm *TokenManager) {
+		tm.loginCommand = command
+	}
+}
+
 // NewTokenManager creates a new TokenManager
-func NewTokenManager(offlineToken string) *TokenManager {
-	return &TokenManager{
+func NewTokenManager(offlineToken string, opts ...TokenManagerOption) *TokenManager {
+	tm := &TokenManager{
 		offlineToken: offlineToken,
+		clientID:     ClientID,
+		tokenURL:     TokenURL,
+		loginCommand: "agcm auth login",
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
 	}
+	for _, opt := range opts {
+		opt(tm)
+	}
+	return tm
 }
 
 // SetOfflineToken updates the offline token
@@ -64,36 +127,113 @@ func (tm *TokenManager) SetOfflineToken(token string) {
 // GetAccessToken returns a valid access token, refreshing if necessary
 func (tm *TokenManager) GetAccessToken(ctx context.Context) (string, error) {
 	tm.mu.RLock()
-	if tm.accessToken != "" && time.Now().Add(TokenExpiryBuffer).Before(tm.expiresAt) {
+	if tm.validFor(TokenExpiryBuffer) {
 		token := tm.accessToken
 		tm.mu.RUnlock()
 		return token, nil
 	}
 	tm.mu.RUnlock()
 
-	return tm.refreshToken(ctx)
+	return tm.refreshToken(ctx, TokenExpiryBuffer)
+}
+
+// AccessTokenExpiresAt returns when the current access token expires, or
+// the zero time if there is none
+func (tm *TokenManager) AccessTokenExpiresAt() time.Time {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	if tm.accessToken == "" {
+		return time.Time{}
+	}
+	return tm.expiresAt
+}
+
+// OfflineTokenClaims decodes the claims of the offline token
+func (tm *TokenManager) OfflineTokenClaims() (*Claims, error) {
+	tm.mu.RLock()
+	token := tm.offlineToken
+	tm.mu.RUnlock()
+	return ParseClaims(token)
+}
+
+// RefreshInBackground keeps the access token fresh until ctx is done, so
+// requests never wait on SSO. It stops early if the credentials are
+// rejected; the next request then reports why.
+func (tm *TokenManager) RefreshInBackground(ctx context.Context) {
+	go func() {
+		for {
+			wait := time.Duration(0)
+			ahead := TokenExpiryBuffer + RefreshAhead
+			tm.mu.RLock()
+			if tm.accessToken != "" {
+				ahead = tm.refreshLead()
+				wait = max(time.Until(tm.expiresAt)-ahead, minRefreshInterval)
+			}
+			tm.mu.RUnlock()
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			_, err := tm.refreshToken(ctx, ahead)
+			if errors.Is(err, ErrLoginRequired) || ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				timer := time.NewTimer(refreshRetryDelay)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+			}
+		}
+	}()
+}
+
+// validFor reports whether the access token is good for at least d.
+// Callers must hold tm.mu.
+func (tm *TokenManager) validFor(d time.Duration) bool {
+	return tm.accessToken != "" && time.Now().Add(d).Before(tm.expiresAt)
+}
+
+// refreshLead returns how long before expiry the background refresher
+// replaces the access token: TokenExpiryBuffer plus RefreshAhead, or half
+// the token lifetime for short-lived tokens. Callers must hold tm.mu.
+func (tm *TokenManager) refreshLead() time.Duration {
+	return min(TokenExpiryBuffer+RefreshAhead, tm.lifetime/2)
 }
 
-// refreshToken exchanges the offline token for a new access token
-func (tm *TokenManager) refreshToken(ctx context.Context) (string, error) {
+// refreshToken exchanges the offline token for a new access token unless
+// the current one is good for at least ahead
+func (tm *TokenManager) refreshToken(ctx context.Context, ahead time.Duration) (string, error) {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
 
 	// Double-check after acquiring write lock
-	if tm.accessToken != "" && time.Now().Add(TokenExpiryBuffer).Before(tm.expiresAt) {
+	if tm.validFor(ahead) {
 		return tm.accessToken, nil
 	}
 
-	if tm.offlineToken == "" {
-		return "", fmt.Errorf("no offline token configured")
-	}
-
 	data := url.Values{}
-	data.Set("grant_type", "refresh_token")
-	data.Set("client_id", ClientID)
-	data.Set("refresh_token", tm.offlineToken)
+	data.Set("client_id", tm.clientID)
+	if tm.clientSecret != "" {
+		data.Set("grant_type", "client_credentials")
+		data.Set("client_secret", tm.clientSecret)
+	} else {
+		if tm.offlineToken == "" {
+			return "", fmt.Errorf("no offline token configured")
+		}
+		data.Set("grant_type", "refresh_token")
+		data.Set("refresh_token", tm.offlineToken)
+	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, TokenURL, strings.NewReader(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tm.tokenURL, strings.NewReader(data.Encode()))
 	if err != nil {
 		return "", fmt.Errorf("failed to create token request: %w", err)
 	}
@@ -111,7 +251,7 @@ func (tm *TokenManager) refreshToken(ctx context.Context) (string, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("token request failed with status %d: %s", resp.StatusCode, string(body))
+		return "", tm.tokenError(resp.StatusCode, body)
 	}
 
 	var tokenResp TokenResponse
@@ -120,14 +260,50 @@ func (tm *TokenManager) refreshToken(ctx context.Context) (string, error) {
 	}
 
 	tm.accessToken = tokenResp.AccessToken
-	tm.expiresAt = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	tm.lifetime = time.Duration(tokenResp.ExpiresIn) * time.Second
+	tm.expiresAt = time.Now().Add(tm.lifetime)
 
 	return tm.accessToken, nil
 }
 
+// tokenError describes a failed token request. SSO answers an expired or
+// revoked offline token with invalid_grant, and unknown service account
+// credentials with invalid_client; only a new login fixes either. For
+// offline tokens, invalid_client instead means a misconfigured client ID.
+func (tm *TokenManager) tokenError(status int, body []byte) error {
+	var oauthErr struct {
+		Error       string `json:"error"`
+		Description string `json:"error_description"`
+	}
+	_ = json.Unmarshal(body, &oauthErr)
+
+	detail := ""
+	if oauthErr.Description != "" {
+		detail = " (" + oauthErr.Description + ")"
+	}
+	clientRejected := oauthErr.Error == "invalid_client" || oauthErr.Error == "unauthorized_client"
+
+	var what string
+	switch {
+	case oauthErr.Error == "invalid_grant" && tm.clientSecret == "":
+		what = "offline token is expired or revoked"
+	case clientRejected && tm.clientSecret != "":
+		what = "service account credentials were rejected"
+	case clientRejected:
+		// A new offline token will not help while the client is misconfigured
+		return fmt.Errorf("SSO rejected client ID %q%s; check the auth client_id setting", tm.clientID, detail)
+	default:
+		return fmt.Errorf("token request failed with status %d: %s", status, string(body))
+	}
+	if tm.loginCommand == "" {
+		return &loginError{msg: what + detail}
+	}
+	return &loginError{msg: fmt.Sprintf("%s%s; re-run '%s' to log in again", what, detail, tm.loginCommand)}
+}
+
 // ValidateOfflineToken checks if an offline token is valid
-func ValidateOfflineToken(ctx context.Context, offlineToken string) error {
-	tm := NewTokenManager(offlineToken)
+func ValidateOfflineToken(ctx context.Context, offlineToken string, opts ...TokenManagerOption) error {
+	tm := NewTokenManager(offlineToken, opts...)
 	_, err := tm.GetAccessToken(ctx)
 	return err
 }
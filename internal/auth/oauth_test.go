@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTokenServer starts an SSO token endpoint issuing tokens that live for
+// expiresIn seconds, and counts the requests it serves
+func newTokenServer(t *testing.T, expiresIn int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","expires_in":%d,"token_type":"Bearer"}`, n, expiresIn)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func TestRefreshInBackgroundShortLivedTokens(t *testing.T) {
+	// Tokens living less than TokenExpiryBuffer plus RefreshAhead
+	for _, expiresIn := range []int{0, 1, 120, 180} {
+		t.Run(fmt.Sprint(expiresIn), func(t *testing.T) {
+			srv, hits := newTokenServer(t, expiresIn)
+			tm := NewTokenManager("offline", WithTokenURL(srv.URL))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+			defer cancel()
+			tm.RefreshInBackground(ctx)
+			<-ctx.Done()
+
+			if got := hits.Load(); got != 1 {
+				t.Errorf("SSO received %d token requests, want 1", got)
+			}
+		})
+	}
+}
+
+func TestRefreshLead(t *testing.T) {
+	tests := []struct {
+		lifetime time.Duration
+		want     time.Duration
+	}{
+		{0, 0},
+		{3 * time.Minute, 90 * time.Second},
+		{6 * time.Minute, TokenExpiryBuffer + RefreshAhead},
+		{15 * time.Minute, TokenExpiryBuffer + RefreshAhead},
+	}
+	for _, tt := range tests {
+		tm := &TokenManager{lifetime: tt.lifetime}
+		if got := tm.refreshLead(); got != tt.want {
+			t.Errorf("refreshLead() for %v tokens = %v, want %v", tt.lifetime, got, tt.want)
+		}
+	}
+}
+
+func TestTokenError(t *testing.T) {
+	sa := &ServiceAccount{ClientID: "svc", ClientSecret: "secret"}
+	tests := []struct {
+		name       string
+		opts       []TokenManagerOption
+		status     int
+		body       string
+		login      bool   // Wrapping ErrLoginRequired
+		want       string // In the message
+		wantNoHint bool
+	}{
+		{"expired offline token", nil, http.StatusBadRequest, `{"error":"invalid_grant","error_description":"Offline session not active"}`,
+			true, "offline token is expired or revoked (Offline session not active); re-run 'agcm auth login'", false},
+		{"misconfigured client ID", []TokenManagerOption{WithClientID("typo")}, http.StatusUnauthorized, `{"error":"unauthorized_client"}`,
+			false, `SSO rejected client ID "typo"`, true},
+		{"rejected service account", []TokenManagerOption{WithServiceAccount(sa), WithLoginCommand("agcm auth login --service-account")},
+			http.StatusUnauthorized, `{"error":"invalid_client"}`, true, "service account credentials were rejected; re-run 'agcm auth login --service-account'", false},
+		{"service account from the environment", []TokenManagerOption{WithServiceAccount(sa), WithLoginCommand("")},
+			http.StatusUnauthorized, `{"error":"invalid_client"}`, true, "service account credentials were rejected", true},
+		{"server error", nil, http.StatusInternalServerError, `oops`, false, "status 500: oops", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := NewTokenManager("offline", tt.opts...)
+			err := tm.tokenError(tt.status, []byte(tt.body))
+			if errors.Is(err, ErrLoginRequired) != tt.login {
+				t.Errorf("errors.Is(%v, ErrLoginRequired) = %v, want %v", err, !tt.login, tt.login)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q does not contain %q", err, tt.want)
+			}
+			if tt.wantNoHint && strings.Contains(err.Error(), "re-run") {
+				t.Errorf("error %q suggests logging in again", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Environment variables that supply service account credentials, e.g. in CI
+const (
+	EnvClientID        = "AGCM_CLIENT_ID"
+	EnvClientSecret    = "AGCM_CLIENT_SECRET"
+	EnvCredentialsFile = "AGCM_CREDENTIALS_FILE"
+)
+
+// ServiceAccount holds OAuth client credentials for a Red Hat service
+// account, used instead of a personal offline token
+type ServiceAccount struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+// validate checks that both halves of the credentials are present
+func (sa *ServiceAccount) validate() error {
+	if sa.ClientID == "" || sa.ClientSecret == "" {
+		return fmt.Errorf("service account credentials need both a client ID and a client secret")
+	}
+	return nil
+}
+
+// WithServiceAccount authenticates with the client-credentials grant
+// instead of exchanging an offline token
+func WithServiceAccount(sa *ServiceAccount) TokenManagerOption {
+	return func(tm *TokenManager) {
+		tm.clientID = sa.ClientID
+		tm.clientSecret = sa.ClientSecret
+	}
+}
+
+// ServiceAccountFromEnv reads credentials from AGCM_CLIENT_ID and
+// AGCM_CLIENT_SECRET, or from the file named by AGCM_CREDENTIALS_FILE.
+// It returns nil if none of them are set.
+func ServiceAccountFromEnv() (*ServiceAccount, error) {
+	id, secret := os.Getenv(EnvClientID), os.Getenv(EnvClientSecret)
+	if id != "" || secret != "" {
+		sa := &ServiceAccount{ClientID: id, ClientSecret: secret}
+		if err := sa.validate(); err != nil {
+			return nil, fmt.Errorf("%s and %s must both be set", EnvClientID, EnvClientSecret)
+		}
+		return sa, nil
+	}
+	if path := os.Getenv(EnvCredentialsFile); path != "" {
+		return LoadServiceAccountFile(path)
+	}
+	return nil, nil
+}
+
+// LoadServiceAccountFile reads credentials from a JSON file with
+// client_id and client_secret fields
+func LoadServiceAccountFile(path string) (*ServiceAccount, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+	return parseServiceAccount(data)
+}
+
+// parseServiceAccount decodes credentials stored as JSON
+func parseServiceAccount(data []byte) (*ServiceAccount, error) {
+	var sa ServiceAccount
+	if err := json.Unmarshal(data, &sa); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials: %w", err)
+	}
+	sa.ClientID = strings.TrimSpace(sa.ClientID)
+	sa.ClientSecret = strings.TrimSpace(sa.ClientSecret)
+	if err := sa.validate(); err != nil {
+		return nil, err
+	}
+	return &sa, nil
+}
+
+// ValidateServiceAccount checks that service account credentials are
+// accepted by SSO
+func ValidateServiceAccount(ctx context.Context, sa *ServiceAccount, opts ...TokenManagerOption) error {
+	if err := sa.validate(); err != nil {
+		return err
+	}
+	tm := NewTokenManager("", append(opts, WithServiceAccount(sa))...)
+	_, err := tm.GetAccessToken(ctx)
+	return err
+}
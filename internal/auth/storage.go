@@ -4,6 +4,8 @@ package auth
 
 import (
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,22 +15,76 @@ import (
 )
 
 const (
-	tokenFileName  = "token"
-	dirPerms       = 0700
-	filePerms      = 0600
-	keyringService = "agcm"
-	keyringUser    = "offline-token"
+	tokenFileName          = "token"
+	serviceAccountFileName = "service-account"
+	encryptedSuffix        = ".enc"
+	dirPerms               = 0700
+	filePerms              = 0600
+	keyringService         = "agcm"
+	keyringUser            = "offline-token"
+	serviceAccountUser     = "service-account"
 )
 
+// DefaultProfile is the profile whose token uses the original keyring entry
+// and token file, so existing logins keep working
+const DefaultProfile = "default"
+
+// Backend identifies where credentials are stored
+type Backend int
+
+const (
+	BackendNone      Backend = iota
+	BackendKeyring           // System keyring
+	BackendEncrypted         // Passphrase-encrypted file in the config directory
+	BackendPlaintext         // Base64 file written by older versions
+)
+
+func (b Backend) String() string {
+	switch b {
+	case BackendKeyring:
+		return "keyring"
+	case BackendEncrypted:
+		return "encrypted file"
+	case BackendPlaintext:
+		return "plaintext file"
+	}
+	return "none"
+}
+
+// PassphraseFunc asks for the passphrase of the encrypted store. confirm
+// is set when a new file is about to be created.
+type PassphraseFunc func(confirm bool) (string, error)
+
 // Storage handles credential persistence
 type Storage struct {
 	configDir      string
+	profile        string
 	keyringEnabled bool
+	passphraseFn   PassphraseFunc
+	passphrase     string // Cached once entered
 }
 
-// NewStorage creates a new Storage instance
-func NewStorage(configDir string) *Storage {
-	s := &Storage{configDir: configDir}
+// StorageOption configures a Storage
+type StorageOption func(*Storage)
+
+// WithPassphrase sets how the encrypted store asks for its passphrase when
+// AGCM_PASSPHRASE is not set
+func WithPassphrase(fn PassphraseFunc) StorageOption {
+	return func(s *Storage) {
+		s.passphraseFn = fn
+	}
+}
+
+// NewStorage creates a new Storage instance for a profile's credentials.
+// An empty profile is the default one.
+func NewStorage(configDir, profile string, opts ...StorageOption) *Storage {
+	if profile == DefaultProfile {
+		profile = ""
+	}
+	s := &Storage{configDir: configDir, profile: profile}
+	for _, opt := range opts {
+		opt(s)
+	}
 	// Test if keyring is available
 	s.keyringEnabled = s.testKeyring()
 	return s
@@ -61,6 +117,40 @@ func DefaultConfigDir() (string, error) {
 	return filepath.Join(home, ".config", "agcm"), nil
 }
 
+// credential names a secret kept in the keyring, or in a file when the
+// keyring is unavailable
+type credential struct {
+	user string // Keyring account
+	file string // Fallback file in the config directory
+}
+
+var (
+	offlineToken   = credential{user: keyringUser, file: tokenFileName}
+	serviceAccount = credential{user: serviceAccountUser, file: serviceAccountFileName}
+)
+
+// keyringUser returns the keyring account holding this profile's credential
+func (s *Storage) keyringUser(c credential) string {
+	if s.profile == "" {
+		return c.user
+	}
+	return c.user + "@" + s.profile
+}
+
+// filePath returns the plaintext file of this profile's credential
+func (s *Storage) filePath(c credential) string {
+	if s.profile == "" {
+		return filepath.Join(s.configDir, c.file)
+	}
+	return filepath.Join(s.configDir, c.file+"-"+s.profile)
+}
+
+// encryptedPath returns the encrypted fallback file of this profile's
+// credential
+func (s *Storage) encryptedPath(c credential) string {
+	return s.filePath(c) + encryptedSuffix
+}
+
 // EnsureDir creates the config directory if it doesn't exist
 func (s *Storage) EnsureDir() error {
 	return os.MkdirAll(s.configDir, dirPerms)
@@ -68,61 +158,185 @@ func (s *Storage) EnsureDir() error {
 
 // SaveToken stores the offline token
 func (s *Storage) SaveToken(token string) error {
+	return s.save(offlineToken, token)
+}
+
+// LoadToken retrieves the stored offline token
+func (s *Storage) LoadToken() (string, error) {
+	return s.load(offlineToken)
+}
+
+// DeleteToken removes the stored token
+func (s *Storage) DeleteToken() error {
+	if err := s.delete(offlineToken); err != nil {
+		return fmt.Errorf("failed to delete token")
+	}
+	return nil
+}
+
+// HasToken checks if a token is stored
+func (s *Storage) HasToken() bool {
+	return s.has(offlineToken)
+}
+
+// SaveServiceAccount stores service account credentials
+func (s *Storage) SaveServiceAccount(sa *ServiceAccount) error {
+	data, err := json.Marshal(sa)
+	if err != nil {
+		return fmt.Errorf("failed to encode credentials: %w", err)
+	}
+	return s.save(serviceAccount, string(data))
+}
+
+// LoadServiceAccount retrieves stored service account credentials, or nil
+// if there are none
+func (s *Storage) LoadServiceAccount() (*ServiceAccount, error) {
+	data, err := s.load(serviceAccount)
+	if err != nil || data == "" {
+		return nil, err
+	}
+	return parseServiceAccount([]byte(data))
+}
+
+// DeleteServiceAccount removes stored service account credentials
+func (s *Storage) DeleteServiceAccount() error {
+	if err := s.delete(serviceAccount); err != nil {
+		return fmt.Errorf("failed to delete service account credentials")
+	}
+	return nil
+}
+
+// HasServiceAccount checks if service account credentials are stored
+func (s *Storage) HasServiceAccount() bool {
+	return s.has(serviceAccount)
+}
+
+// HasCredentials checks if an offline token or service account is stored
+func (s *Storage) HasCredentials() bool {
+	return s.HasToken() || s.HasServiceAccount()
+}
+
+// Backend reports where the credentials in use are stored, checking the
+// service account first as it takes precedence
+func (s *Storage) Backend() Backend {
+	if b := s.backend(serviceAccount); b != BackendNone {
+		return b
+	}
+	return s.backend(offlineToken)
+}
+
+// MigratePlaintext moves credentials from plaintext files written by older
+// versions into the keyring, or the encrypted store when there is no
+// keyring. It returns how many credentials were moved.
+func (s *Storage) MigratePlaintext() (int, error) {
+	moved := 0
+	for _, c := range []credential{offlineToken, serviceAccount} {
+		if _, err := os.Stat(s.filePath(c)); err != nil {
+			continue
+		}
+		value, err := s.loadFile(c)
+		if err != nil {
+			return moved, err
+		}
+		if err := s.save(c, value); err != nil {
+			return moved, err
+		}
+		moved++
+	}
+	return moved, nil
+}
+
+func (s *Storage) save(c credential, value string) error {
 	if s.keyringEnabled {
-		err := keyring.Set(keyringService, keyringUser, token)
+		err := keyring.Set(keyringService, s.keyringUser(c), value)
 		if err == nil {
-			// Remove any old file-based token
-			_ = s.deleteFileToken()
+			// Remove any old file-based copies
+			_ = s.deleteEncryptedFile(c)
+			_ = s.deleteFile(c)
 			return nil
 		}
 		// Fall through to file storage if keyring fails
 	}
 
-	return s.saveFileToken(token)
+	if err := s.saveEncryptedFile(c, value); err != nil {
+		return err
+	}
+	return s.deleteFile(c)
 }
 
-// LoadToken retrieves the stored offline token
-func (s *Storage) LoadToken() (string, error) {
+func (s *Storage) load(c credential) (string, error) {
 	if s.keyringEnabled {
-		token, err := keyring.Get(keyringService, keyringUser)
-		if err == nil && token != "" {
-			return token, nil
+		value, err := keyring.Get(keyringService, s.keyringUser(c))
+		if err == nil && value != "" {
+			return value, nil
 		}
 		// Fall through to file storage if keyring fails or is empty
 	}
 
-	return s.loadFileToken()
+	if _, err := os.Stat(s.encryptedPath(c)); err == nil {
+		return s.loadEncryptedFile(c)
+	}
+	return s.loadFile(c)
 }
 
-// DeleteToken removes the stored token
-func (s *Storage) DeleteToken() error {
+func (s *Storage) delete(c credential) error {
 	var keyringErr, fileErr error
 
 	if s.keyringEnabled {
-		keyringErr = keyring.Delete(keyringService, keyringUser)
+		keyringErr = keyring.Delete(keyringService, s.keyringUser(c))
 	}
 
-	fileErr = s.deleteFileToken()
+	fileErr = errors.Join(s.deleteEncryptedFile(c), s.deleteFile(c))
 
-	// Return error only if both fail and at least one had a token
+	// Return error only if both fail and at least one had a value
 	if keyringErr != nil && fileErr != nil {
-		return fmt.Errorf("failed to delete token")
+		return fileErr
 	}
 	return nil
 }
 
-// HasToken checks if a token is stored
-func (s *Storage) HasToken() bool {
+func (s *Storage) has(c credential) bool {
+	return s.backend(c) != BackendNone
+}
+
+func (s *Storage) backend(c credential) Backend {
 	if s.keyringEnabled {
-		token, err := keyring.Get(keyringService, keyringUser)
-		if err == nil && token != "" {
-			return true
+		value, err := keyring.Get(keyringService, s.keyringUser(c))
+		if err == nil && value != "" {
+			return BackendKeyring
 		}
 	}
+	if _, err := os.Stat(s.encryptedPath(c)); err == nil {
+		return BackendEncrypted
+	}
+	if _, err := os.Stat(s.filePath(c)); err == nil {
+		return BackendPlaintext
+	}
+	return BackendNone
+}
 
-	path := filepath.Join(s.configDir, tokenFileName)
-	_, err := os.Stat(path)
-	return err == nil
+// unlock returns the passphrase of the encrypted store, from
+// AGCM_PASSPHRASE or by asking
+func (s *Storage) unlock(confirm bool) (string, error) {
+	if s.passphrase != "" {
+		return s.passphrase, nil
+	}
+	if p := os.Getenv(EnvPassphrase); p != "" {
+		s.passphrase = p
+		return p, nil
+	}
+	if s.passphraseFn == nil {
+		return "", fmt.Errorf("the encrypted token store needs a passphrase; set %s", EnvPassphrase)
+	}
+	p, err := s.passphraseFn(confirm)
+	if err != nil {
+		return "", err
+	}
+	if p == "" {
+		return "", fmt.Errorf("passphrase cannot be empty")
+	}
+	s.passphrase = p
+	return p, nil
 }
 
 // ConfigDir returns the configuration directory path
@@ -137,42 +351,77 @@ func (s *Storage) UsingKeyring() bool {
 
 // File-based storage fallback methods
 
-func (s *Storage) saveFileToken(token string) error {
+func (s *Storage) saveEncryptedFile(c credential, value string) error {
 	if err := s.EnsureDir(); err != nil {
 		return err
 	}
 
-	// Basic obfuscation - not secure, just prevents casual viewing
-	encoded := base64.StdEncoding.EncodeToString([]byte(token))
+	path := s.encryptedPath(c)
+	_, statErr := os.Stat(path)
+	passphrase, err := s.unlock(os.IsNotExist(statErr))
+	if err != nil {
+		return err
+	}
+	data, err := encrypt([]byte(value), passphrase)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, filePerms)
+}
 
-	path := filepath.Join(s.configDir, tokenFileName)
-	return os.WriteFile(path, []byte(encoded), filePerms)
+func (s *Storage) loadEncryptedFile(c credential) (string, error) {
+	data, err := os.ReadFile(s.encryptedPath(c))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s file: %w", c.file, err)
+	}
+	passphrase, err := s.unlock(false)
+	if err != nil {
+		return "", err
+	}
+	value, err := decrypt(data, passphrase)
+	if err != nil {
+		if errors.Is(err, ErrWrongPassphrase) {
+			// Ask again next time rather than reusing a bad passphrase
+			s.passphrase = ""
+		}
+		return "", fmt.Errorf("failed to unlock %s: %w", c.file, err)
+	}
+	return string(value), nil
+}
+
+func (s *Storage) deleteEncryptedFile(c credential) error {
+	err := os.Remove(s.encryptedPath(c))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete %s: %w", c.file, err)
+	}
+	return nil
 }
 
-func (s *Storage) loadFileToken() (string, error) {
-	path := filepath.Join(s.configDir, tokenFileName)
+// loadFile reads a base64 plaintext file written by older versions
+func (s *Storage) loadFile(c credential) (string, error) {
+	path := s.filePath(c)
 
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return "", nil
 		}
-		return "", fmt.Errorf("failed to read token file: %w", err)
+		return "", fmt.Errorf("failed to read %s file: %w", c.file, err)
 	}
 
 	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
 	if err != nil {
-		return "", fmt.Errorf("failed to decode token: %w", err)
+		return "", fmt.Errorf("failed to decode %s: %w", c.file, err)
 	}
 
 	return string(decoded), nil
 }
 
-func (s *Storage) deleteFileToken() error {
-	path := filepath.Join(s.configDir, tokenFileName)
+func (s *Storage) deleteFile(c credential) error {
+	path := s.filePath(c)
 	err := os.Remove(path)
 	if err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("failed to delete token: %w", err)
+		return fmt.Errorf("failed to delete %s: %w", c.file, err)
 	}
 	return nil
 }
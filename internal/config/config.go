@@ -6,15 +6,21 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 const (
-	configFileName = "config.yaml"
-	dirPerms       = 0700
-	filePerms      = 0644
+	configFileName  = "config.yaml"
+	profilesDirName = "profiles"
+	dirPerms        = 0700
+	filePerms       = 0644
+
+	// DefaultProfile is the profile backed by the top-level api, defaults
+	// and presets settings
+	DefaultProfile = "default"
 )
 
 // FilterPreset represents a saved filter configuration
@@ -25,20 +31,63 @@ type FilterPreset struct {
 	Severity []string `yaml:"severity,omitempty"`
 	Products []string `yaml:"products,omitempty"`
 	Keyword  string   `yaml:"keyword,omitempty"`
+	Query    string   `yaml:"query,omitempty"`
 }
 
 // Config represents the application configuration
 type Config struct {
 	API      APIConfig              `yaml:"api"`
+	Auth     AuthConfig             `yaml:"auth,omitempty"`
 	UI       UIConfig               `yaml:"ui"`
+	Log      LogConfig              `yaml:"log"`
 	Defaults DefaultsConfig         `yaml:"defaults"`
 	Presets  map[string]*FilterPreset `yaml:"presets,omitempty"`
+
+	Profile  string              `yaml:"profile,omitempty"`  // Active profile, set by 'agcm profile use'
+	Profiles map[string]*Profile `yaml:"profiles,omitempty"` // Named portal identities besides the default
+}
+
+// Profile is a named portal identity with its own token, endpoints,
+// defaults and presets. Empty URLs fall back to the top-level api settings.
+type Profile struct {
+	BaseURL  string                   `yaml:"base_url,omitempty"`
+	HydraURL string                   `yaml:"hydra_url,omitempty"`
+	Auth     AuthConfig               `yaml:"auth,omitempty"`
+	Defaults DefaultsConfig           `yaml:"defaults,omitempty"`
+	Presets  map[string]*FilterPreset `yaml:"presets,omitempty"`
 }
 
 // APIConfig contains API-related settings
 type APIConfig struct {
-	BaseURL string        `yaml:"base_url"`
-	Timeout time.Duration `yaml:"timeout"`
+	BaseURL  string        `yaml:"base_url"`
+	HydraURL string        `yaml:"hydra_url,omitempty"` // Search API; defaults to the portal, or base_url when that is customized
+	Timeout  time.Duration `yaml:"timeout"`
+	Retry    RetryConfig   `yaml:"retry"`
+
+	// Client-wide limits shared by parallel exports and the TUI; 0 disables
+	MaxConcurrent     int     `yaml:"max_concurrent"`
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+}
+
+// AuthConfig points at the SSO used to obtain access tokens; empty values
+// mean Red Hat SSO
+type AuthConfig struct {
+	TokenURL string `yaml:"token_url,omitempty"` // OAuth token endpoint
+	ClientID string `yaml:"client_id,omitempty"` // Client offline tokens are issued to
+}
+
+// RetryConfig controls retries of rate-limited and transiently failing requests
+type RetryConfig struct {
+	MaxRetries int           `yaml:"max_retries"` // 0 disables retries
+	BaseDelay  time.Duration `yaml:"base_delay"`
+	MaxDelay   time.Duration `yaml:"max_delay"` // Also the longest Retry-After honored
+}
+
+// LogConfig controls diagnostic logging; --debug and the --log-* flags override it
+type LogConfig struct {
+	Level  string `yaml:"level,omitempty"`  // debug, info, warn or error; empty disables logging
+	File   string `yaml:"file,omitempty"`   // Defaults to agcm/agcm-debug.log in the user cache dir; "-" for stderr
+	Format string `yaml:"format,omitempty"` // text or json
 }
 
 // DefaultsConfig contains default filter values
@@ -49,8 +98,44 @@ type DefaultsConfig struct {
 
 // UIConfig contains UI-related settings
 type UIConfig struct {
-	Theme    string `yaml:"theme"`
-	PageSize int    `yaml:"page_size"`
+	Theme    string                 `yaml:"theme"` // auto, dark, light, high-contrast or a name in Themes
+	PageSize int                    `yaml:"page_size"`
+	Themes   map[string]ThemeConfig `yaml:"themes,omitempty"`
+	Keys     map[string]KeyList     `yaml:"keys,omitempty"` // Binding name to keys, e.g. down: [j, ctrl+n]
+
+	// Case list columns in order, e.g. [case, owner:20, summary]; a width
+	// fixes the column's size. Empty means the defaults.
+	Columns      []string `yaml:"columns,omitempty"`       // TUI case list
+	TableColumns []string `yaml:"table_columns,omitempty"` // agcm list cases
+}
+
+// KeyList is the keys of a binding. A single key may be written as a
+// string; an empty list or null unbinds it.
+type KeyList []string
+
+// UnmarshalYAML accepts a list of keys or a single key
+func (k *KeyList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		if value.Tag == "!!null" {
+			*k = KeyList{}
+			return nil
+		}
+		*k = KeyList{value.Value}
+		return nil
+	}
+	var keys []string
+	if err := value.Decode(&keys); err != nil {
+		return err
+	}
+	*k = keys
+	return nil
+}
+
+// ThemeConfig is a user-defined palette: a built-in theme with some
+// colors replaced, e.g. colors: {primary: "#005F87", severity1: "201"}
+type ThemeConfig struct {
+	Base   string            `yaml:"base,omitempty"` // Built-in theme to start from (default auto)
+	Colors map[string]string `yaml:"colors,omitempty"`
 }
 
 // DefaultConfig returns the default configuration
@@ -59,9 +144,16 @@ func DefaultConfig() *Config {
 		API: APIConfig{
 			BaseURL: "https://api.access.redhat.com",
 			Timeout: 30 * time.Second,
+			Retry: RetryConfig{
+				MaxRetries: 3,
+				BaseDelay:  500 * time.Millisecond,
+				MaxDelay:   30 * time.Second,
+			},
+			MaxConcurrent:     6,
+			RequestsPerSecond: 10,
 		},
 		UI: UIConfig{
-			Theme:    "dark",
+			Theme:    "auto",
 			PageSize: 25,
 		},
 	}
@@ -71,6 +163,7 @@ func DefaultConfig() *Config {
 type Manager struct {
 	configDir string
 	config    *Config
+	profile   string // Active profile; empty for the default
 }
 
 // NewManager creates a new configuration manager
@@ -143,43 +236,191 @@ func (m *Manager) Set(cfg *Config) {
 	m.config = cfg
 }
 
+// activeProfile returns the active named profile, or nil for the default
+func (m *Manager) activeProfile() *Profile {
+	if m.profile == "" {
+		return nil
+	}
+	return m.config.Profiles[m.profile]
+}
+
+// Profile returns the name of the active profile
+func (m *Manager) Profile() string {
+	if m.profile == "" {
+		return DefaultProfile
+	}
+	return m.profile
+}
+
+// UseProfile makes name the active profile for this session
+func (m *Manager) UseProfile(name string) error {
+	if name == "" || name == DefaultProfile {
+		m.profile = ""
+		return nil
+	}
+	if m.config.Profiles[name] == nil {
+		return fmt.Errorf("unknown profile %q (see 'agcm profile list')", name)
+	}
+	m.profile = name
+	return nil
+}
+
+// AddProfile creates an empty profile if it does not exist yet
+func (m *Manager) AddProfile(name string) {
+	if name == "" || name == DefaultProfile {
+		return
+	}
+	if m.config.Profiles == nil {
+		m.config.Profiles = make(map[string]*Profile)
+	}
+	if m.config.Profiles[name] == nil {
+		m.config.Profiles[name] = &Profile{}
+	}
+}
+
+// ProfileNames returns every profile, the default first
+func (m *Manager) ProfileNames() []string {
+	names := make([]string, 0, len(m.config.Profiles)+1)
+	for name := range m.config.Profiles {
+		if name != DefaultProfile {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return append([]string{DefaultProfile}, names...)
+}
+
+// ProfileDir returns where a profile keeps its local data such as the case
+// store. The default profile uses the config directory itself.
+func ProfileDir(configDir, profile string) string {
+	if profile == "" || profile == DefaultProfile {
+		return configDir
+	}
+	return filepath.Join(configDir, profilesDirName, profile)
+}
+
 // GetBaseURL returns the API base URL
 func (m *Manager) GetBaseURL() string {
+	if p := m.activeProfile(); p != nil && p.BaseURL != "" {
+		return p.BaseURL
+	}
 	return m.config.API.BaseURL
 }
 
+// GetHydraURL returns the Hydra search API base URL, empty for the default.
+// A profile with its own base URL but no Hydra URL follows the base URL.
+func (m *Manager) GetHydraURL() string {
+	if p := m.activeProfile(); p != nil && (p.HydraURL != "" || p.BaseURL != "") {
+		return p.HydraURL
+	}
+	return m.config.API.HydraURL
+}
+
+// GetAuth returns the SSO settings of the active profile, falling back to
+// the top-level ones
+func (m *Manager) GetAuth() AuthConfig {
+	a := m.config.Auth
+	if p := m.activeProfile(); p != nil {
+		if p.Auth.TokenURL != "" {
+			a.TokenURL = p.Auth.TokenURL
+		}
+		if p.Auth.ClientID != "" {
+			a.ClientID = p.Auth.ClientID
+		}
+	}
+	return a
+}
+
+// GetDefaults returns the default filter values of the active profile
+func (m *Manager) GetDefaults() DefaultsConfig {
+	if p := m.activeProfile(); p != nil {
+		return p.Defaults
+	}
+	return m.config.Defaults
+}
+
 // GetTimeout returns the API timeout
 func (m *Manager) GetTimeout() time.Duration {
 	return m.config.API.Timeout
 }
 
+// GetRetry returns the API retry settings
+func (m *Manager) GetRetry() RetryConfig {
+	return m.config.API.Retry
+}
+
+// GetLog returns the logging settings
+func (m *Manager) GetLog() LogConfig {
+	return m.config.Log
+}
+
 // GetTheme returns the UI theme
 func (m *Manager) GetTheme() string {
 	return m.config.UI.Theme
 }
 
+// SetTheme sets the UI theme
+func (m *Manager) SetTheme(name string) {
+	m.config.UI.Theme = name
+}
+
+// GetThemes returns the user-defined themes
+func (m *Manager) GetThemes() map[string]ThemeConfig {
+	return m.config.UI.Themes
+}
+
+// GetKeys returns the key binding overrides
+func (m *Manager) GetKeys() map[string][]string {
+	keys := make(map[string][]string, len(m.config.UI.Keys))
+	for name, list := range m.config.UI.Keys {
+		keys[name] = list
+	}
+	return keys
+}
+
+// GetColumns returns the TUI case list columns
+func (m *Manager) GetColumns() []string {
+	return m.config.UI.Columns
+}
+
+// SetColumns sets the TUI case list columns
+func (m *Manager) SetColumns(columns []string) {
+	m.config.UI.Columns = columns
+}
+
+// GetTableColumns returns the list cases columns
+func (m *Manager) GetTableColumns() []string {
+	return m.config.UI.TableColumns
+}
+
 // GetPageSize returns the UI page size
 func (m *Manager) GetPageSize() int {
 	return m.config.UI.PageSize
 }
 
+// presets returns the preset map of the active profile
+func (m *Manager) presets() *map[string]*FilterPreset {
+	if p := m.activeProfile(); p != nil {
+		return &p.Presets
+	}
+	return &m.config.Presets
+}
+
 // GetPreset returns a filter preset by slot key (1-9, 0)
 func (m *Manager) GetPreset(slot string) *FilterPreset {
-	if m.config.Presets == nil {
-		return nil
-	}
-	return m.config.Presets[slot]
+	return (*m.presets())[slot]
 }
 
 // SetPreset saves a filter preset to a slot
 func (m *Manager) SetPreset(slot string, preset *FilterPreset) {
-	if m.config.Presets == nil {
-		m.config.Presets = make(map[string]*FilterPreset)
+	presets := m.presets()
+	if *presets == nil {
+		*presets = make(map[string]*FilterPreset)
 	}
-	m.config.Presets[slot] = preset
+	(*presets)[slot] = preset
 }
 
 // GetPresets returns all presets
 func (m *Manager) GetPresets() map[string]*FilterPreset {
-	return m.config.Presets
+	return *m.presets()
 }
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+func init() {
+	RegisterFormat("csv", ".csv", "CSV, one row per case (for spreadsheets)", func(opts *Options) (Format, error) {
+		return csvFormat{}, nil
+	})
+}
+
+// csvHeader lists the columns written by the CSV format
+var csvHeader = []string{
+	"case_number", "summary", "status", "severity", "product", "version", "type",
+	"account_number", "account_name", "owner", "contact_name", "contact_email",
+	"created_date", "last_modified_date", "closed_date", "comments", "attachments",
+}
+
+// csvFormat writes one spreadsheet row per case
+type csvFormat struct{}
+
+func (csvFormat) FormatCase(export *CaseExport) ([]byte, error) {
+	return csvFormat{}.FormatCases([]*CaseExport{export})
+}
+
+func (csvFormat) FormatCases(exports []*CaseExport) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(csvHeader); err != nil {
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for _, export := range exports {
+		if err := w.Write(csvRow(export)); err != nil {
+			return nil, fmt.Errorf("failed to write CSV row for case %s: %w", export.Case.CaseNumber, err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func csvRow(export *CaseExport) []string {
+	c := export.Case
+	closed := ""
+	if c.ClosedDate != nil {
+		closed = csvTime(*c.ClosedDate)
+	}
+	return []string{
+		c.CaseNumber, c.Summary, c.Status, c.Severity, c.Product, c.Version, c.Type,
+		c.AccountNumber, c.AccountName, c.Owner, c.ContactName, c.ContactEmail,
+		csvTime(c.CreatedDate), csvTime(c.LastModified), closed,
+		strconv.Itoa(len(export.Comments)), strconv.Itoa(len(export.Attachments)),
+	}
+}
+
+// csvTime formats times as RFC 3339 so spreadsheets can parse them
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
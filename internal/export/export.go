@@ -4,31 +4,49 @@ package export
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/green/agcm/internal/api"
+	"github.com/green/agcm/internal/logging"
 )
 
 // Options configures the export operation
 type Options struct {
 	OutputDir          string
-	OutputFile         string   // For single-file combined export
-	Format             string   // "markdown" or "json"
+	OutputFile         string // For single-file combined export
+	Format             string // Registered format name, see FormatNames
 	IncludeAttachments bool
 	AttachmentsDir     string
-	Combined           bool     // Combine all cases into single file
+	Combined           bool // Combine all cases into single file
 	Concurrency        int
-	TemplatePath       string   // Custom template file
-	CaseNumbers        []string // Specific cases to export
-	Debug              bool     // Enable debug logging
+	TemplatePath       string       // Custom template file
+	CaseNumbers        []string     // Specific cases to export
+	Logger             *slog.Logger // Progress and failure logging; nil discards
+	Version            string       // agcm version recorded in JSON exports
+	Resume             bool         // Skip cases already in the output directory's manifest
+	Incremental        bool         // Skip cases whose last modified date is unchanged since the manifest
 }
 
+// StepUpToDate is the progress step reported for cases a resumed or
+// incremental export skips
+const StepUpToDate = "Up to date"
+
+// manifestCheckpointInterval is how often an in-progress export saves its
+// manifest, so an interrupted run can be resumed
+const manifestCheckpointInterval = 2 * time.Second
+
 // DefaultOptions returns sensible defaults
 func DefaultOptions() *Options {
 	return &Options{
@@ -41,25 +59,20 @@ func DefaultOptions() *Options {
 
 // Progress reports export progress
 type Progress struct {
-	TotalCases      int
-	CompletedCases  int
-	CurrentCase     string
-	CurrentStep     string
-	Error           error
+	TotalCases     int
+	CompletedCases int
+	CurrentCase    string
+	CurrentStep    string
+	Error          error
 }
 
 // Exporter handles bulk case exports
 type Exporter struct {
-	client    *api.Client
-	formatter *Formatter
-	opts      *Options
-}
-
-// debugf prints debug messages if debug mode is enabled
-func (e *Exporter) debugf(format string, args ...interface{}) {
-	if e.opts.Debug {
-		fmt.Fprintf(os.Stderr, "[DEBUG] "+format+"\n", args...)
-	}
+	client *api.Client
+	format Format
+	info   FormatInfo
+	opts   *Options
+	log    *slog.Logger
 }
 
 // NewExporter creates a new exporter
@@ -73,64 +86,67 @@ func NewExporter(client *api.Client, opts *Options) (*Exporter, error) {
 		opts.Concurrency = 1
 	}
 
-	var formatter *Formatter
-	var err error
+	if opts.Format == "" {
+		opts.Format = "markdown"
+	}
+	info, err := LookupFormat(opts.Format)
+	if err != nil {
+		return nil, err
+	}
+	if opts.TemplatePath != "" && info.Name != "markdown" {
+		return nil, fmt.Errorf("templates only apply to the markdown format")
+	}
+	if (opts.Resume || opts.Incremental) && opts.Combined {
+		return nil, fmt.Errorf("resumable and incremental exports write one file per case and cannot be combined")
+	}
 
-	if opts.TemplatePath != "" {
-		tmplData, err := os.ReadFile(opts.TemplatePath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read template: %w", err)
-		}
-		formatter, err = NewFormatterWithTemplate(string(tmplData))
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		formatter, err = NewFormatter()
-		if err != nil {
-			return nil, err
-		}
+	format, err := info.New(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	log := opts.Logger
+	if log == nil {
+		log = logging.Discard()
 	}
 
 	return &Exporter{
-		client:    client,
-		formatter: formatter,
-		opts:      opts,
+		client: client,
+		format: format,
+		info:   info,
+		opts:   opts,
+		log:    log.With("component", "export"),
 	}, nil
 }
 
-// ExportCase exports a single case to markdown
+// ExportCase fetches a single case with its comments and attachments
 func (e *Exporter) ExportCase(ctx context.Context, caseNumber string) (*CaseExport, error) {
-	e.debugf("ExportCase: starting export for case %s", caseNumber)
+	log := e.log.With("case", caseNumber)
+	start := time.Now()
 
 	// Get case details
-	e.debugf("ExportCase: fetching case details for %s", caseNumber)
 	c, err := e.client.GetCase(ctx, caseNumber)
 	if err != nil {
-		e.debugf("ExportCase: failed to get case %s: %v", caseNumber, err)
-		return nil, fmt.Errorf("failed to get case %s: %w", caseNumber, err)
+		log.Warn("failed to get case", "error", err)
+		return nil, api.CaseError(caseNumber, err)
 	}
-	e.debugf("ExportCase: got case %s: %s (status: %s)", caseNumber, c.Summary, c.Status)
 
 	// Get comments
-	e.debugf("ExportCase: fetching comments for case %s", caseNumber)
 	comments, err := e.client.GetCaseComments(ctx, caseNumber)
 	if err != nil {
-		e.debugf("ExportCase: failed to get comments for case %s: %v", caseNumber, err)
+		log.Warn("failed to get comments", "error", err)
 		return nil, fmt.Errorf("failed to get comments for case %s: %w", caseNumber, err)
 	}
-	e.debugf("ExportCase: got %d comments for case %s", len(comments), caseNumber)
 
 	// Get attachments
-	e.debugf("ExportCase: fetching attachments for case %s", caseNumber)
 	attachments, err := e.client.GetCaseAttachments(ctx, caseNumber)
 	if err != nil {
-		e.debugf("ExportCase: failed to get attachments for case %s: %v", caseNumber, err)
+		log.Warn("failed to get attachments", "error", err)
 		return nil, fmt.Errorf("failed to get attachments for case %s: %w", caseNumber, err)
 	}
-	e.debugf("ExportCase: got %d attachments for case %s", len(attachments), caseNumber)
 
-	e.debugf("ExportCase: completed export for case %s", caseNumber)
+	log.Debug("fetched case", "status", c.Status, "comments", len(comments),
+		"attachments", len(attachments), "duration", time.Since(start))
 	return &CaseExport{
 		Case:        c,
 		Comments:    comments,
@@ -146,7 +162,7 @@ func (e *Exporter) ExportCaseToFile(ctx context.Context, caseNumber, outputPath
 		return err
 	}
 
-	md, err := e.formatter.FormatCase(export)
+	data, err := e.formatCase(export)
 	if err != nil {
 		return fmt.Errorf("failed to format case: %w", err)
 	}
@@ -157,7 +173,7 @@ func (e *Exporter) ExportCaseToFile(ctx context.Context, caseNumber, outputPath
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	if err := os.WriteFile(outputPath, []byte(md), 0644); err != nil {
+	if err := os.WriteFile(outputPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
@@ -166,19 +182,32 @@ func (e *Exporter) ExportCaseToFile(ctx context.Context, caseNumber, outputPath
 
 // ExportCases exports multiple cases with progress reporting
 func (e *Exporter) ExportCases(ctx context.Context, caseNumbers []string, progressCh chan<- Progress) (*Manifest, error) {
-	e.debugf("ExportCases: starting export of %d cases to %s", len(caseNumbers), e.opts.OutputDir)
-	e.debugf("ExportCases: options: format=%s combined=%v attachments=%v concurrency=%d",
-		e.opts.Format, e.opts.Combined, e.opts.IncludeAttachments, e.opts.Concurrency)
+	return e.exportCases(ctx, caseNumbers, nil, progressCh)
+}
+
+// exportCases exports caseNumbers. lastModified holds dates already known
+// from a case listing; incremental exports look up any that are missing.
+func (e *Exporter) exportCases(ctx context.Context, caseNumbers []string, lastModified map[string]time.Time, progressCh chan<- Progress) (*Manifest, error) {
+	e.log.Info("starting export", "cases", len(caseNumbers), "dir", e.opts.OutputDir,
+		"format", e.opts.Format, "combined", e.opts.Combined, "attachments", e.opts.IncludeAttachments,
+		"concurrency", e.opts.Concurrency, "resume", e.opts.Resume, "incremental", e.opts.Incremental)
 
 	if err := os.MkdirAll(e.opts.OutputDir, 0755); err != nil {
-		e.debugf("ExportCases: failed to create output directory: %v", err)
 		return nil, fmt.Errorf("failed to create output directory: %w", err)
 	}
-	e.debugf("ExportCases: created output directory %s", e.opts.OutputDir)
 
-	manifest := NewManifest()
+	manifestPath := filepath.Join(e.opts.OutputDir, ManifestFileName)
+	manifest, err := e.loadPreviousManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	previous := make(map[string]ManifestCase, len(manifest.Cases))
+	for _, mc := range manifest.Cases {
+		previous[mc.CaseNumber] = mc
+	}
 	manifest.ExportedAt = time.Now()
-	manifest.TotalCases = len(caseNumbers)
+	manifest.Format = e.info.Name
+	lastSave := time.Now()
 
 	var exports []*CaseExport
 	var exportsMu sync.Mutex
@@ -199,6 +228,27 @@ func (e *Exporter) ExportCases(ctx context.Context, caseNumbers []string, progre
 			sem <- struct{}{}
 			defer func() { <-sem }()
 
+			if prev, ok := previous[cn]; ok {
+				skip, err := e.upToDate(ctx, prev, lastModified[cn])
+				if err != nil {
+					errCh <- err
+					return
+				}
+				if skip {
+					e.log.Debug("case is up to date, skipping", "case", cn)
+					newCompleted := atomic.AddInt64(&completedCount, 1)
+					if progressCh != nil {
+						progressCh <- Progress{
+							TotalCases:     len(caseNumbers),
+							CompletedCases: int(newCompleted),
+							CurrentCase:    cn,
+							CurrentStep:    StepUpToDate,
+						}
+					}
+					return
+				}
+			}
+
 			currentCompleted := atomic.LoadInt64(&completedCount)
 			if progressCh != nil {
 				progressCh <- Progress{
@@ -211,7 +261,7 @@ func (e *Exporter) ExportCases(ctx context.Context, caseNumbers []string, progre
 
 			export, err := e.ExportCase(ctx, cn)
 			if err != nil {
-				errCh <- fmt.Errorf("case %s: %w", cn, err)
+				errCh <- err
 				return
 			}
 
@@ -222,6 +272,7 @@ func (e *Exporter) ExportCases(ctx context.Context, caseNumbers []string, progre
 			// Determine output path and write individual files if not combined
 			var outputPath string
 			var attDir string
+			var contentHash string
 
 			if !e.opts.Combined {
 				// Write individual file
@@ -231,23 +282,25 @@ func (e *Exporter) ExportCases(ctx context.Context, caseNumbers []string, progre
 						errCh <- fmt.Errorf("failed to create case directory: %w", err)
 						return
 					}
-					outputPath = filepath.Join(caseDir, "case.md")
+					outputPath = filepath.Join(caseDir, "case"+e.extension())
 					attDir = filepath.Join(caseDir, e.opts.AttachmentsDir)
 				} else {
-					outputPath = fmt.Sprintf("case-%s.md", cn)
+					outputPath = fmt.Sprintf("case-%s%s", cn, e.extension())
 					attDir = filepath.Join(".", e.opts.AttachmentsDir, cn)
 				}
 
-				md, err := e.formatter.FormatCase(export)
+				data, err := e.formatCase(export)
 				if err != nil {
 					errCh <- fmt.Errorf("failed to format case %s: %w", cn, err)
 					return
 				}
 
-				if err := os.WriteFile(outputPath, []byte(md), 0644); err != nil {
+				if err := os.WriteFile(outputPath, data, 0644); err != nil {
 					errCh <- fmt.Errorf("failed to write case %s: %w", cn, err)
 					return
 				}
+				sum := sha256.Sum256(data)
+				contentHash = "sha256:" + hex.EncodeToString(sum[:])
 			} else {
 				// Combined mode: attachments go to a shared directory per case
 				attDir = filepath.Join(e.opts.OutputDir, e.opts.AttachmentsDir, cn)
@@ -273,7 +326,8 @@ func (e *Exporter) ExportCases(ctx context.Context, caseNumbers []string, progre
 
 					if err := e.downloadAttachment(ctx, cn, att, attDir); err != nil {
 						// Log but don't fail the whole export
-						_, _ = fmt.Fprintf(os.Stderr, "Warning: failed to download attachment %s: %v\n", att.Filename, err)
+						e.log.Warn("failed to download attachment", "case", cn, "uuid", att.UUID,
+							"file", att.Filename, "error", err)
 					}
 				}
 			}
@@ -281,10 +335,25 @@ func (e *Exporter) ExportCases(ctx context.Context, caseNumbers []string, progre
 			// Add to manifest (for both combined and individual modes)
 			if e.opts.Combined {
 				// For combined mode, reference the combined file
-				manifest.AddCase(cn, export.Case.Summary, "all-cases.md", len(export.Attachments))
-			} else {
-				manifest.AddCase(cn, export.Case.Summary, filepath.Base(filepath.Dir(outputPath))+"/case.md", len(export.Attachments))
+				outputPath = e.combinedPath()
+			}
+			exportsMu.Lock()
+			manifest.PutCase(ManifestCase{
+				CaseNumber:            cn,
+				Summary:               export.Case.Summary,
+				File:                  e.manifestFile(outputPath),
+				AttachmentsDownloaded: len(export.Attachments),
+				LastModified:          export.Case.LastModified,
+				ExportedAt:            export.ExportedAt,
+				ContentHash:           contentHash,
+			})
+			if time.Since(lastSave) >= manifestCheckpointInterval {
+				if err := manifest.Save(manifestPath); err != nil {
+					e.log.Warn("failed to checkpoint manifest", "error", err)
+				}
+				lastSave = time.Now()
 			}
+			exportsMu.Unlock()
 
 			newCompleted := atomic.AddInt64(&completedCount, 1)
 			if progressCh != nil {
@@ -308,29 +377,40 @@ func (e *Exporter) ExportCases(ctx context.Context, caseNumbers []string, progre
 	}
 
 	if len(errs) > 0 {
+		// Record what did succeed so the export can be resumed
+		if err := manifest.Save(manifestPath); err != nil {
+			e.log.Warn("failed to write manifest", "error", err)
+		}
 		// Return partial results with error
 		return manifest, fmt.Errorf("export completed with %d errors: %v", len(errs), errs[0])
 	}
 
 	// Write combined file if requested
 	if e.opts.Combined && len(exports) > 0 {
-		md, err := e.formatter.FormatCases(exports)
+		// Keep the requested case order regardless of completion order
+		order := make(map[string]int, len(caseNumbers))
+		for i, cn := range caseNumbers {
+			order[cn] = i
+		}
+		sort.Slice(exports, func(i, j int) bool {
+			return order[exports[i].Case.CaseNumber] < order[exports[j].Case.CaseNumber]
+		})
+		sort.Slice(manifest.Cases, func(i, j int) bool {
+			return order[manifest.Cases[i].CaseNumber] < order[manifest.Cases[j].CaseNumber]
+		})
+
+		outputPath := e.combinedPath()
+		data, err := e.formatCases(exports, outputPath)
 		if err != nil {
 			return manifest, fmt.Errorf("failed to format combined export: %w", err)
 		}
 
-		outputPath := e.opts.OutputFile
-		if outputPath == "" {
-			outputPath = filepath.Join(e.opts.OutputDir, "all-cases.md")
-		}
-
-		if err := os.WriteFile(outputPath, []byte(md), 0644); err != nil {
+		if err := os.WriteFile(outputPath, data, 0644); err != nil {
 			return manifest, fmt.Errorf("failed to write combined export: %w", err)
 		}
 	}
 
 	// Write manifest
-	manifestPath := filepath.Join(e.opts.OutputDir, "export-manifest.json")
 	if err := manifest.Save(manifestPath); err != nil {
 		return manifest, fmt.Errorf("failed to write manifest: %w", err)
 	}
@@ -338,6 +418,101 @@ func (e *Exporter) ExportCases(ctx context.Context, caseNumbers []string, progre
 	return manifest, nil
 }
 
+// loadPreviousManifest returns the manifest an export builds on: the existing
+// one when resuming or exporting incrementally, otherwise a fresh manifest
+func (e *Exporter) loadPreviousManifest(path string) (*Manifest, error) {
+	if !e.opts.Resume && !e.opts.Incremental {
+		return NewManifest(), nil
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		e.log.Debug("no manifest, exporting everything", "path", path)
+		return NewManifest(), nil
+	}
+	manifest, err := LoadManifest(path)
+	if err != nil {
+		return nil, err
+	}
+	e.log.Debug("loaded manifest", "path", path, "cases", len(manifest.Cases))
+	return manifest, nil
+}
+
+// upToDate reports whether a case recorded in the previous manifest can be
+// skipped. Resumed exports skip any case whose file is still present;
+// incremental exports also require its last modified date to be unchanged.
+func (e *Exporter) upToDate(ctx context.Context, prev ManifestCase, lastModified time.Time) (bool, error) {
+	if prev.File == "" || !strings.HasSuffix(prev.File, e.extension()) {
+		return false, nil
+	}
+	path := filepath.FromSlash(prev.File)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(e.opts.OutputDir, path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return false, nil
+	}
+
+	if !e.opts.Incremental {
+		return true, nil
+	}
+	if prev.LastModified.IsZero() {
+		return false, nil
+	}
+	if lastModified.IsZero() {
+		c, err := e.client.GetCase(ctx, prev.CaseNumber)
+		if err != nil {
+			return false, api.CaseError(prev.CaseNumber, err)
+		}
+		lastModified = c.LastModified
+	}
+	return lastModified.Equal(prev.LastModified), nil
+}
+
+// extension returns the file extension for the export format
+func (e *Exporter) extension() string {
+	return e.info.Extension
+}
+
+// combinedPath returns where the combined export is written
+func (e *Exporter) combinedPath() string {
+	if e.opts.OutputFile != "" {
+		return e.opts.OutputFile
+	}
+	return filepath.Join(e.opts.OutputDir, "all-cases"+e.extension())
+}
+
+// manifestFile returns path as recorded in the manifest: relative to the
+// output directory when inside it, otherwise as given
+func (e *Exporter) manifestFile(path string) string {
+	if e.opts.OutputDir == "" {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(e.opts.OutputDir, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		if abs, err := filepath.Abs(path); err == nil {
+			return abs
+		}
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// formatCase renders a single case in the configured format
+func (e *Exporter) formatCase(export *CaseExport) ([]byte, error) {
+	return e.format.FormatCase(export)
+}
+
+// formatCases renders the combined export. Combined JSON is written as an
+// array, or as one document per line when the output ends in .ndjson or .jsonl.
+func (e *Exporter) formatCases(exports []*CaseExport, outputPath string) ([]byte, error) {
+	if e.info.Name == "json" {
+		switch strings.ToLower(filepath.Ext(outputPath)) {
+		case ".ndjson", ".jsonl":
+			return FormatNDJSON(exports, e.opts.Version)
+		}
+	}
+	return e.format.FormatCases(exports)
+}
+
 // downloadAttachment downloads a single attachment
 func (e *Exporter) downloadAttachment(ctx context.Context, caseNumber string, att api.Attachment, destDir string) error {
 	reader, filename, err := e.client.DownloadAttachment(ctx, caseNumber, att.UUID)
@@ -366,45 +541,35 @@ func (e *Exporter) downloadAttachment(ctx context.Context, caseNumber string, at
 
 // ExportWithFilter exports cases matching the given filter
 func (e *Exporter) ExportWithFilter(ctx context.Context, filter *api.CaseFilter, progressCh chan<- Progress) (*Manifest, error) {
-	e.debugf("ExportWithFilter: starting filtered export")
-	e.debugf("ExportWithFilter: filter status=%v severity=%v products=%v accounts=%v group=%q",
-		filter.Status, filter.Severity, filter.Products, filter.Accounts, filter.GroupNumber)
+	e.log.Info("starting filtered export", "status", filter.Status, "severity", filter.Severity,
+		"products", filter.Products, "accounts", filter.Accounts, "group", filter.GroupNumber)
 
 	// Fetch all matching cases
-	var allCases []api.Case
-	filter.Count = 100 // Fetch in batches
 	filter.StartIndex = 0
-
-	for {
-		e.debugf("ExportWithFilter: fetching cases batch at offset %d (limit %d)", filter.StartIndex, filter.Count)
-		result, err := e.client.ListCases(ctx, filter)
-		if err != nil {
-			e.debugf("ExportWithFilter: failed to list cases: %v", err)
-			return nil, fmt.Errorf("failed to list cases: %w", err)
-		}
-		e.debugf("ExportWithFilter: got %d cases in batch (total available: %d)", len(result.Items), result.TotalCount)
-
-		allCases = append(allCases, result.Items...)
-
-		if len(result.Items) < filter.Count || len(allCases) >= result.TotalCount {
-			break
-		}
-		filter.StartIndex += filter.Count
+	filter.Count = api.PageSize
+	var allCases []api.Case
+	it := e.client.IterateCases(ctx, filter)
+	for it.Next() {
+		e.log.Debug("listed cases", "offset", len(allCases), "got", len(it.Page()), "total", it.Total())
+		allCases = append(allCases, it.Page()...)
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list cases: %w", err)
 	}
 
-	e.debugf("ExportWithFilter: found %d total cases to export", len(allCases))
+	e.log.Info("found cases to export", "cases", len(allCases))
 
 	if len(allCases) == 0 {
-		e.debugf("ExportWithFilter: no cases found matching filter")
 		return NewManifest(), nil
 	}
 
 	// Extract case numbers
 	caseNumbers := make([]string, len(allCases))
+	lastModified := make(map[string]time.Time, len(allCases))
 	for i, c := range allCases {
 		caseNumbers[i] = c.CaseNumber
-		e.debugf("ExportWithFilter: case %d: %s - %s", i+1, c.CaseNumber, c.Summary)
+		lastModified[c.CaseNumber] = c.LastModified
 	}
 
-	return e.ExportCases(ctx, caseNumbers, progressCh)
+	return e.exportCases(ctx, caseNumbers, lastModified, progressCh)
 }
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package export
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/green/agcm/internal/api"
+	"github.com/green/agcm/internal/apitest"
+)
+
+func newTestExporter(t *testing.T, opts *Options) (*apitest.Fixtures, *Exporter) {
+	t.Helper()
+	fx := apitest.DefaultFixtures(time.Now())
+	srv := apitest.NewServer(fx)
+	t.Cleanup(srv.Close)
+	e, err := NewExporter(srv.NewClient(api.WithRateLimit(0, 0)), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fx, e
+}
+
+// readManifest loads the manifest from dir and checks it lists want
+func readManifest(t *testing.T, dir string, want []string) *Manifest {
+	t.Helper()
+	m, err := LoadManifest(filepath.Join(dir, ManifestFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.TotalCases != len(want) || len(m.Cases) != len(want) {
+		t.Fatalf("manifest total_cases %d with %d entries, want %d", m.TotalCases, len(m.Cases), len(want))
+	}
+	for _, cn := range want {
+		if m.FindCase(cn) == nil {
+			t.Errorf("manifest is missing case %s", cn)
+		}
+	}
+	return m
+}
+
+func TestExportFormats(t *testing.T) {
+	for _, format := range []string{"markdown", "json"} {
+		t.Run(format, func(t *testing.T) {
+			dir := t.TempDir()
+			fx, e := newTestExporter(t, &Options{OutputDir: dir, Format: format, Concurrency: 2})
+			numbers := []string{fx.Cases[0].CaseNumber, fx.Cases[3].CaseNumber, fx.Cases[5].CaseNumber}
+
+			if _, err := e.ExportCases(context.Background(), numbers, nil); err != nil {
+				t.Fatal(err)
+			}
+
+			m := readManifest(t, dir, numbers)
+			if m.Format != format {
+				t.Errorf("manifest format = %q, want %q", m.Format, format)
+			}
+			for _, mc := range m.Cases {
+				data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(mc.File)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				sum := sha256.Sum256(data)
+				if mc.ContentHash != "sha256:"+hex.EncodeToString(sum[:]) {
+					t.Errorf("case %s content hash %s does not match its file", mc.CaseNumber, mc.ContentHash)
+				}
+				if !strings.Contains(string(data), mc.Summary) {
+					t.Errorf("case %s file does not contain its summary %q", mc.CaseNumber, mc.Summary)
+				}
+				if format == "json" && !json.Valid(data) {
+					t.Errorf("case %s file is not valid JSON", mc.CaseNumber)
+				}
+			}
+		})
+	}
+}
+
+func TestExportWithFilter(t *testing.T) {
+	dir := t.TempDir()
+	fx, e := newTestExporter(t, &Options{OutputDir: dir, Format: "markdown", Concurrency: 4})
+
+	var want []string
+	for _, c := range fx.Cases {
+		if c.AccountNumber == "5550202" && c.Status != "Closed" {
+			want = append(want, c.CaseNumber)
+		}
+	}
+
+	m, err := e.ExportWithFilter(context.Background(), &api.CaseFilter{Accounts: []string{"5550202"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Cases) != len(want) {
+		t.Errorf("exported %d cases, want %d", len(m.Cases), len(want))
+	}
+	readManifest(t, dir, want)
+}
+
+func TestExportResumeKeepsEarlierCases(t *testing.T) {
+	dir := t.TempDir()
+	fx, e := newTestExporter(t, &Options{OutputDir: dir, Format: "markdown", Concurrency: 1, Resume: true})
+	ctx := context.Background()
+	first := []string{fx.Cases[0].CaseNumber, fx.Cases[1].CaseNumber}
+	second := []string{fx.Cases[1].CaseNumber, fx.Cases[2].CaseNumber}
+
+	if _, err := e.ExportCases(ctx, first, nil); err != nil {
+		t.Fatal(err)
+	}
+	progress := make(chan Progress, 10)
+	if _, err := e.ExportCases(ctx, second, progress); err != nil {
+		t.Fatal(err)
+	}
+	close(progress)
+
+	skipped := 0
+	for p := range progress {
+		if p.CurrentStep == StepUpToDate {
+			skipped++
+		}
+	}
+	if skipped != 1 {
+		t.Errorf("resume skipped %d cases, want 1", skipped)
+	}
+	readManifest(t, dir, []string{fx.Cases[0].CaseNumber, fx.Cases[1].CaseNumber, fx.Cases[2].CaseNumber})
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Format renders case exports in one output format
+type Format interface {
+	// FormatCase renders a single case
+	FormatCase(export *CaseExport) ([]byte, error)
+	// FormatCases renders several cases into one combined file
+	FormatCases(exports []*CaseExport) ([]byte, error)
+}
+
+// FormatFactory creates a format configured by the export options
+type FormatFactory func(opts *Options) (Format, error)
+
+// FormatInfo describes a registered format
+type FormatInfo struct {
+	Name        string
+	Description string
+	Extension   string
+	factory     FormatFactory
+}
+
+var (
+	formatsMu sync.RWMutex
+	formats   = make(map[string]*FormatInfo)
+)
+
+// RegisterFormat makes a format available by name. It panics if the name is
+// already registered, so formats register themselves from init functions.
+func RegisterFormat(name, extension, description string, factory FormatFactory) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+
+	if _, dup := formats[name]; dup {
+		panic("export: format registered twice: " + name)
+	}
+	formats[name] = &FormatInfo{
+		Name:        name,
+		Description: description,
+		Extension:   extension,
+		factory:     factory,
+	}
+}
+
+// Formats returns the registered formats sorted by name
+func Formats() []FormatInfo {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+
+	list := make([]FormatInfo, 0, len(formats))
+	for _, f := range formats {
+		list = append(list, *f)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// FormatNames returns the names of the registered formats
+func FormatNames() []string {
+	var names []string
+	for _, f := range Formats() {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+// LookupFormat returns the named format's registration
+func LookupFormat(name string) (FormatInfo, error) {
+	formatsMu.RLock()
+	info, ok := formats[strings.ToLower(name)]
+	formatsMu.RUnlock()
+
+	if !ok {
+		return FormatInfo{}, fmt.Errorf("unsupported export format %q (use %s)", name, strings.Join(FormatNames(), ", "))
+	}
+	return *info, nil
+}
+
+// New creates an instance of the format
+func (f FormatInfo) New(opts *Options) (Format, error) {
+	return f.factory(opts)
+}
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+
+	"github.com/green/agcm/internal/api"
+)
+
+func init() {
+	RegisterFormat("html", ".html", "Self-contained HTML page (print-ready for PDF)", func(opts *Options) (Format, error) {
+		return newHTMLFormat()
+	})
+}
+
+// htmlTemplate renders one or more cases as a standalone page. Styles are
+// inlined so the file can be mailed or printed to PDF without extra assets.
+const htmlTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #1f2328; max-width: 960px; margin: 2em auto; padding: 0 1em; line-height: 1.5; }
+h1 { font-size: 1.5em; border-bottom: 2px solid #cc0000; padding-bottom: .3em; }
+h2 { font-size: 1.2em; margin-top: 1.5em; }
+table.meta { border-collapse: collapse; width: 100%; }
+table.meta th { text-align: left; width: 10em; color: #57606a; font-weight: 600; }
+table.meta th, table.meta td { padding: .25em .5em; border-bottom: 1px solid #d0d7de; vertical-align: top; }
+.text { white-space: pre-wrap; overflow-wrap: anywhere; }
+.comment { border: 1px solid #d0d7de; border-radius: 6px; margin: 1em 0; page-break-inside: avoid; }
+.comment header { background: #f6f8fa; border-bottom: 1px solid #d0d7de; padding: .4em .8em; font-size: .9em; }
+.comment.internal header { background: #fff8c5; }
+.comment .author { font-weight: 600; }
+.comment .badge { float: right; font-size: .8em; color: #57606a; }
+.comment .text { padding: .6em .8em; }
+table.attachments { border-collapse: collapse; width: 100%; font-size: .9em; }
+table.attachments th, table.attachments td { border: 1px solid #d0d7de; padding: .3em .5em; text-align: left; }
+footer { margin-top: 2em; color: #57606a; font-size: .8em; }
+article + article { border-top: 3px double #d0d7de; margin-top: 3em; padding-top: 1em; }
+@media print {
+  body { max-width: none; margin: 0; }
+  article + article { page-break-before: always; border-top: none; }
+  a { color: inherit; }
+}
+</style>
+</head>
+<body>
+{{range .Cases}}<article>
+<h1>Case {{.Case.CaseNumber}}: {{.Case.Summary}}</h1>
+<table class="meta">
+<tr><th>Status</th><td>{{.Case.Status}}</td></tr>
+<tr><th>Severity</th><td>{{.Case.Severity}}</td></tr>
+<tr><th>Product</th><td>{{.Case.Product}} {{.Case.Version}}</td></tr>
+<tr><th>Type</th><td>{{.Case.Type}}</td></tr>
+<tr><th>Created</th><td>{{formatTime .Case.CreatedDate}}</td></tr>
+<tr><th>Last Updated</th><td>{{formatTime .Case.LastModified}}</td></tr>
+{{- if .Case.ClosedDate}}
+<tr><th>Closed</th><td>{{formatTime .Case.ClosedDate}}</td></tr>
+{{- end}}
+<tr><th>Owner</th><td>{{.Case.Owner}}</td></tr>
+<tr><th>Contact</th><td>{{.Case.ContactName}}{{if .Case.ContactEmail}} &lt;{{.Case.ContactEmail}}&gt;{{end}}</td></tr>
+<tr><th>Account</th><td>{{.Case.AccountName}} ({{.Case.AccountNumber}})</td></tr>
+</table>
+<h2>Description</h2>
+<div class="text">{{cleanHTML .Case.Description}}</div>
+<h2>Conversation ({{len .Comments}})</h2>
+{{range .Comments}}<section class="comment{{if not .IsPublicComment}} internal{{end}}">
+<header><span class="author">{{.Author}}</span> &middot; {{formatTime .CreatedDate}}<span class="badge">{{if .IsPublicComment}}Public{{else}}Internal{{end}}</span></header>
+<div class="text">{{cleanHTML .GetText}}</div>
+</section>
+{{else}}<p>No comments.</p>
+{{end}}
+{{- if .Attachments}}
+<h2>Attachments</h2>
+<table class="attachments">
+<tr><th>Filename</th><th>Size</th><th>Uploaded</th><th>By</th></tr>
+{{range .Attachments}}<tr><td>{{.Filename}}</td><td>{{formatSize .GetSize}}</td><td>{{formatTime .CreatedDate}}</td><td>{{.CreatedBy}}</td></tr>
+{{end}}</table>
+{{- end}}
+<footer>Exported by agcm on {{formatTime .ExportedAt}}</footer>
+</article>
+{{end}}</body>
+</html>
+`
+
+// htmlFormat renders cases as standalone HTML pages
+type htmlFormat struct {
+	tmpl *template.Template
+}
+
+type htmlPage struct {
+	Title string
+	Cases []*CaseExport
+}
+
+func newHTMLFormat() (*htmlFormat, error) {
+	funcMap := template.FuncMap{
+		"formatTime": formatTime,
+		"formatSize": api.FormatSize,
+		"cleanHTML":  cleanHTML,
+	}
+	tmpl, err := template.New("html").Funcs(funcMap).Parse(htmlTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML template: %w", err)
+	}
+	return &htmlFormat{tmpl: tmpl}, nil
+}
+
+func (h *htmlFormat) FormatCase(export *CaseExport) ([]byte, error) {
+	return h.render(htmlPage{
+		Title: fmt.Sprintf("Case %s: %s", export.Case.CaseNumber, export.Case.Summary),
+		Cases: []*CaseExport{export},
+	})
+}
+
+func (h *htmlFormat) FormatCases(exports []*CaseExport) ([]byte, error) {
+	return h.render(htmlPage{
+		Title: fmt.Sprintf("%d support cases", len(exports)),
+		Cases: exports,
+	})
+}
+
+func (h *htmlFormat) render(page htmlPage) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := h.tmpl.Execute(&buf, page); err != nil {
+		return nil, fmt.Errorf("failed to execute HTML template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/green/agcm/internal/api"
+)
+
+// JSONSchemaVersion is bumped whenever the JSON document layout changes incompatibly
+const JSONSchemaVersion = 1
+
+// JSONDocument is the versioned JSON export of a single case
+type JSONDocument struct {
+	SchemaVersion int              `json:"schema_version"`
+	Export        JSONExportInfo   `json:"export"`
+	Case          JSONCase         `json:"case"`
+	Comments      []JSONComment    `json:"comments"`
+	Attachments   []JSONAttachment `json:"attachments"`
+}
+
+// JSONExportInfo describes when and how the document was produced
+type JSONExportInfo struct {
+	ExportedAt  time.Time `json:"exported_at"`
+	Tool        string    `json:"tool"`
+	ToolVersion string    `json:"tool_version,omitempty"`
+}
+
+// JSONCase holds the case fields of a JSON export
+type JSONCase struct {
+	CaseNumber    string     `json:"case_number"`
+	Summary       string     `json:"summary"`
+	Description   string     `json:"description"`
+	Status        string     `json:"status"`
+	Severity      string     `json:"severity"`
+	Product       string     `json:"product"`
+	Version       string     `json:"version"`
+	Type          string     `json:"type"`
+	AccountNumber string     `json:"account_number"`
+	AccountName   string     `json:"account_name"`
+	ContactName   string     `json:"contact_name"`
+	ContactEmail  string     `json:"contact_email"`
+	Owner         string     `json:"owner"`
+	CreatedBy     string     `json:"created_by"`
+	CreatedDate   time.Time  `json:"created_date"`
+	LastModified  time.Time  `json:"last_modified_date"`
+	ClosedDate    *time.Time `json:"closed_date"`
+}
+
+// JSONComment holds a comment in a JSON export
+type JSONComment struct {
+	ID          string    `json:"id"`
+	Author      string    `json:"author"`
+	AuthorEmail string    `json:"author_email"`
+	CreatedDate time.Time `json:"created_date"`
+	Public      bool      `json:"public"`
+	Text        string    `json:"text"`
+}
+
+// JSONAttachment holds attachment metadata in a JSON export
+type JSONAttachment struct {
+	UUID        string    `json:"uuid"`
+	Filename    string    `json:"filename"`
+	Description string    `json:"description"`
+	Size        int64     `json:"size"`
+	MimeType    string    `json:"mime_type"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedDate time.Time `json:"created_date"`
+}
+
+func init() {
+	RegisterFormat("json", ".json", "Versioned JSON document per case", func(opts *Options) (Format, error) {
+		return &jsonFormat{version: opts.Version}, nil
+	})
+}
+
+// jsonFormat writes JSON documents; combined exports are a JSON array
+type jsonFormat struct {
+	version string
+}
+
+func (j *jsonFormat) FormatCase(export *CaseExport) ([]byte, error) {
+	return FormatJSON(export, j.version)
+}
+
+func (j *jsonFormat) FormatCases(exports []*CaseExport) ([]byte, error) {
+	return FormatJSONArray(exports, j.version)
+}
+
+// NewJSONDocument converts a case export to its JSON document
+func NewJSONDocument(export *CaseExport, toolVersion string) *JSONDocument {
+	c := export.Case
+	doc := &JSONDocument{
+		SchemaVersion: JSONSchemaVersion,
+		Export: JSONExportInfo{
+			ExportedAt:  export.ExportedAt.UTC(),
+			Tool:        "agcm",
+			ToolVersion: toolVersion,
+		},
+		Case: JSONCase{
+			CaseNumber:    c.CaseNumber,
+			Summary:       c.Summary,
+			Description:   c.Description,
+			Status:        c.Status,
+			Severity:      c.Severity,
+			Product:       c.Product,
+			Version:       c.Version,
+			Type:          c.Type,
+			AccountNumber: c.AccountNumber,
+			AccountName:   c.AccountName,
+			ContactName:   c.ContactName,
+			ContactEmail:  c.ContactEmail,
+			Owner:         c.Owner,
+			CreatedBy:     c.CreatedBy,
+			CreatedDate:   c.CreatedDate,
+			LastModified:  c.LastModified,
+			ClosedDate:    c.ClosedDate,
+		},
+		Comments:    make([]JSONComment, 0, len(export.Comments)),
+		Attachments: make([]JSONAttachment, 0, len(export.Attachments)),
+	}
+
+	for _, cm := range export.Comments {
+		doc.Comments = append(doc.Comments, JSONComment{
+			ID:          cm.ID,
+			Author:      cm.Author,
+			AuthorEmail: cm.AuthorEmail,
+			CreatedDate: cm.CreatedDate,
+			Public:      cm.IsPublicComment(),
+			Text:        cm.GetText(),
+		})
+	}
+	for _, a := range export.Attachments {
+		doc.Attachments = append(doc.Attachments, jsonAttachment(a))
+	}
+	return doc
+}
+
+func jsonAttachment(a api.Attachment) JSONAttachment {
+	return JSONAttachment{
+		UUID:        a.UUID,
+		Filename:    a.Filename,
+		Description: a.Description,
+		Size:        a.GetSize(),
+		MimeType:    a.MimeType,
+		CreatedBy:   a.CreatedBy,
+		CreatedDate: a.CreatedDate,
+	}
+}
+
+// FormatJSON renders a case export as an indented JSON document
+func FormatJSON(export *CaseExport, toolVersion string) ([]byte, error) {
+	data, err := json.MarshalIndent(NewJSONDocument(export, toolVersion), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal case %s: %w", export.Case.CaseNumber, err)
+	}
+	return append(data, '\n'), nil
+}
+
+// FormatJSONArray renders several case exports as a JSON array of documents
+func FormatJSONArray(exports []*CaseExport, toolVersion string) ([]byte, error) {
+	docs := make([]*JSONDocument, len(exports))
+	for i, export := range exports {
+		docs[i] = NewJSONDocument(export, toolVersion)
+	}
+	data, err := json.MarshalIndent(docs, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal cases: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// FormatNDJSON renders several case exports as one JSON document per line
+func FormatNDJSON(exports []*CaseExport, toolVersion string) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, export := range exports {
+		if err := enc.Encode(NewJSONDocument(export, toolVersion)); err != nil {
+			return nil, fmt.Errorf("failed to marshal case %s: %w", export.Case.CaseNumber, err)
+		}
+	}
+	return buf.Bytes(), nil
+}
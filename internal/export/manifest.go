@@ -6,12 +6,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 )
 
+// ManifestFileName is the manifest written to the export directory
+const ManifestFileName = "export-manifest.json"
+
 // Manifest records metadata about an export operation
 type Manifest struct {
 	ExportedAt     time.Time         `json:"exported_at"`
+	Format         string            `json:"format,omitempty"`
 	TotalCases     int               `json:"total_cases"`
 	FiltersApplied *ManifestFilters  `json:"filters_applied,omitempty"`
 	Cases          []ManifestCase    `json:"cases"`
@@ -28,10 +33,13 @@ type ManifestFilters struct {
 
 // ManifestCase records info about a single exported case
 type ManifestCase struct {
-	CaseNumber            string `json:"case_number"`
-	Summary               string `json:"summary"`
-	File                  string `json:"file"`
-	AttachmentsDownloaded int    `json:"attachments_downloaded"`
+	CaseNumber            string    `json:"case_number"`
+	Summary               string    `json:"summary"`
+	File                  string    `json:"file"`
+	AttachmentsDownloaded int       `json:"attachments_downloaded"`
+	LastModified          time.Time `json:"last_modified,omitempty"` // Case last modified date when exported
+	ExportedAt            time.Time `json:"exported_at,omitempty"`
+	ContentHash           string    `json:"content_hash,omitempty"` // sha256 of the written file
 }
 
 // NewManifest creates a new empty manifest
@@ -51,6 +59,26 @@ func (m *Manifest) AddCase(caseNumber, summary, file string, attachments int) {
 	})
 }
 
+// PutCase adds a case to the manifest, replacing any earlier entry for it
+func (m *Manifest) PutCase(mc ManifestCase) {
+	if existing := m.FindCase(mc.CaseNumber); existing != nil {
+		*existing = mc
+		return
+	}
+	m.Cases = append(m.Cases, mc)
+}
+
+// CountExportedSince returns how many cases were exported at or after t
+func (m *Manifest) CountExportedSince(t time.Time) int {
+	n := 0
+	for _, c := range m.Cases {
+		if !c.ExportedAt.Before(t) {
+			n++
+		}
+	}
+	return n
+}
+
 // SetFilters records the filters that were applied
 func (m *Manifest) SetFilters(status, severity, products []string, since, until string) {
 	m.FiltersApplied = &ManifestFilters{
@@ -62,14 +90,22 @@ func (m *Manifest) SetFilters(status, severity, products []string, since, until
 	}
 }
 
-// Save writes the manifest to a JSON file
+// Save writes the manifest to a JSON file. The write is atomic so an
+// interrupted export never leaves a truncated manifest behind.
 func (m *Manifest) Save(path string) error {
+	// Resumed exports keep earlier cases, so count what is listed
+	m.TotalCases = len(m.Cases)
 	data, err := json.MarshalIndent(m, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal manifest: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	tmp := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
 		return fmt.Errorf("failed to write manifest: %w", err)
 	}
 
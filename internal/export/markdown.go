@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"fmt"
 	"html"
+	"os"
 	"regexp"
 	"strings"
 	"text/template"
@@ -91,7 +92,7 @@ func NewFormatter() (*Formatter, error) {
 func NewFormatterWithTemplate(tmplStr string) (*Formatter, error) {
 	funcMap := template.FuncMap{
 		"formatTime": formatTime,
-		"formatSize": formatSize,
+		"formatSize": api.FormatSize,
 		"cleanHTML":  cleanHTML,
 		"truncUUID":  truncUUID,
 		"add":        func(a, b int) int { return a + b },
@@ -127,6 +128,45 @@ func (f *Formatter) FormatCases(exports []*CaseExport) (string, error) {
 	return strings.Join(parts, "\n\n---\n\n"), nil
 }
 
+func init() {
+	RegisterFormat("markdown", ".md", "Markdown document (supports --template)", newMarkdownFormat)
+}
+
+// markdownFormat adapts Formatter to the Format interface
+type markdownFormat struct {
+	f *Formatter
+}
+
+func newMarkdownFormat(opts *Options) (Format, error) {
+	if opts.TemplatePath == "" {
+		f, err := NewFormatter()
+		if err != nil {
+			return nil, err
+		}
+		return &markdownFormat{f: f}, nil
+	}
+
+	tmplData, err := os.ReadFile(opts.TemplatePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	f, err := NewFormatterWithTemplate(string(tmplData))
+	if err != nil {
+		return nil, err
+	}
+	return &markdownFormat{f: f}, nil
+}
+
+func (m *markdownFormat) FormatCase(export *CaseExport) ([]byte, error) {
+	md, err := m.f.FormatCase(export)
+	return []byte(md), err
+}
+
+func (m *markdownFormat) FormatCases(exports []*CaseExport) ([]byte, error) {
+	md, err := m.f.FormatCases(exports)
+	return []byte(md), err
+}
+
 // formatTime formats a time value for display
 func formatTime(t interface{}) string {
 	switch v := t.(type) {
@@ -145,20 +185,6 @@ func formatTime(t interface{}) string {
 	}
 }
 
-// formatSize formats a byte size for display
-func formatSize(bytes int64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
-	}
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
-}
-
 // cleanHTML converts HTML to plain text/markdown
 func cleanHTML(s string) string {
 	// Decode HTML entities
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+func init() {
+	RegisterFormat("ndjson", ".ndjson", "Newline-delimited JSON, one record per comment (for log pipelines)", func(opts *Options) (Format, error) {
+		return ndjsonFormat{}, nil
+	})
+}
+
+// CommentRecord is one line of NDJSON output
+type CommentRecord struct {
+	CaseNumber  string    `json:"case_number"`
+	CaseSummary string    `json:"case_summary"`
+	CaseStatus  string    `json:"case_status"`
+	Severity    string    `json:"severity"`
+	Product     string    `json:"product"`
+	CommentID   string    `json:"comment_id"`
+	Author      string    `json:"author"`
+	CreatedDate time.Time `json:"created_date"`
+	Public      bool      `json:"public"`
+	Text        string    `json:"text"`
+}
+
+// ndjsonFormat writes one JSON record per comment
+type ndjsonFormat struct{}
+
+func (ndjsonFormat) FormatCase(export *CaseExport) ([]byte, error) {
+	return ndjsonFormat{}.FormatCases([]*CaseExport{export})
+}
+
+func (ndjsonFormat) FormatCases(exports []*CaseExport) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	for _, export := range exports {
+		c := export.Case
+		for _, cm := range export.Comments {
+			rec := CommentRecord{
+				CaseNumber:  c.CaseNumber,
+				CaseSummary: c.Summary,
+				CaseStatus:  c.Status,
+				Severity:    c.Severity,
+				Product:     c.Product,
+				CommentID:   cm.ID,
+				Author:      cm.Author,
+				CreatedDate: cm.CreatedDate,
+				Public:      cm.IsPublicComment(),
+				Text:        cm.GetText(),
+			}
+			if err := enc.Encode(rec); err != nil {
+				return nil, fmt.Errorf("failed to marshal comment on case %s: %w", c.CaseNumber, err)
+			}
+		}
+	}
+	return buf.Bytes(), nil
+}
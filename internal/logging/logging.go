@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Stderr is the File value that logs to standard error instead of a file
+const Stderr = "-"
+
+// DefaultFile is where logs go when no file is configured. The TUI owns the
+// terminal, so logging to stderr must be asked for explicitly.
+var DefaultFile = defaultFile()
+
+// defaultFile is agcm-debug.log in the user's cache directory, e.g.
+// ~/.cache/agcm. A shared path such as /tmp could be pre-created or
+// symlinked by another user.
+func defaultFile() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		if dir, err = os.UserConfigDir(); err != nil {
+			dir = os.TempDir()
+		}
+	}
+	return filepath.Join(dir, "agcm", "agcm-debug.log")
+}
+
+// Options configures a logger
+type Options struct {
+	Level  string // debug, info, warn or error
+	File   string // Log file path, Stderr, or empty for DefaultFile
+	Format string // text or json
+}
+
+// New creates a logger that redacts tokens and email addresses from every
+// message and string attribute. The returned close function releases the
+// log file.
+func New(opts Options) (*slog.Logger, func() error, error) {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var w io.Writer = os.Stderr
+	closeFn := func() error { return nil }
+	path := opts.File
+	if path == "" {
+		path = DefaultFile
+	}
+	if path != Stderr {
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return nil, nil, fmt.Errorf("failed to create log directory: %w", err)
+		}
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		w = f
+		closeFn = f.Close
+	}
+
+	hopts := &slog.HandlerOptions{Level: level, ReplaceAttr: redactAttr}
+	var h slog.Handler
+	switch strings.ToLower(opts.Format) {
+	case "", "text":
+		h = slog.NewTextHandler(w, hopts)
+	case "json":
+		h = slog.NewJSONHandler(w, hopts)
+	default:
+		_ = closeFn()
+		return nil, nil, fmt.Errorf("unknown log format %q (valid: text, json)", opts.Format)
+	}
+	return slog.New(h), closeFn, nil
+}
+
+// Discard returns a logger that drops everything
+func Discard() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
+
+// ParseLevel converts a level name to a slog.Level; empty means info
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if s == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("unknown log level %q (valid: debug, info, warn, error)", s)
+	}
+	return level, nil
+}
+
+var (
+	bearerRe = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]+`)
+	jwtRe    = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	secretRe = regexp.MustCompile(`(?i)("?(?:access_token|refresh_token|id_token|client_secret|password)"?\s*[:=]\s*"?)[^"&\s,}]+`)
+	emailRe  = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+)
+
+// Redact masks bearer tokens, JWTs, OAuth secrets and email addresses in s
+func Redact(s string) string {
+	s = bearerRe.ReplaceAllString(s, "${1}[REDACTED]")
+	s = jwtRe.ReplaceAllString(s, "[REDACTED]")
+	s = secretRe.ReplaceAllString(s, "${1}[REDACTED]")
+	return emailRe.ReplaceAllString(s, "[EMAIL]")
+}
+
+// redactAttr is a slog ReplaceAttr hook applying Redact to string values
+func redactAttr(_ []string, a slog.Attr) slog.Attr {
+	if a.Value.Kind() == slog.KindString {
+		a.Value = slog.StringValue(Redact(a.Value.String()))
+	}
+	return a
+}
@@ -0,0 +1,290 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/green/agcm/internal/api"
+)
+
+const (
+	storeDirName  = "store"
+	casesDirName  = "cases"
+	stateFileName = "state.json"
+	dirPerms      = 0700
+	filePerms     = 0600 // Case content is customer data
+)
+
+// ErrNotFound is returned when a case is not in the local store
+var ErrNotFound = errors.New("case not in local store")
+
+// CaseRecord is a case with its comments and attachment metadata
+type CaseRecord struct {
+	Case        api.Case         `json:"case"`
+	Comments    []api.Comment    `json:"comments"`
+	Attachments []api.Attachment `json:"attachments"`
+	SyncedAt    time.Time        `json:"synced_at"`
+}
+
+// State records store-wide sync information
+type State struct {
+	LastSync time.Time `json:"last_sync"` // Local time of the last sync, of any filter
+
+	// Newest case modification time, by the portal's clock, seen by the last
+	// sync of each filter; see SyncOptions.key
+	Synced map[string]time.Time `json:"synced,omitempty"`
+}
+
+// Store is a persistent local copy of support cases
+type Store struct {
+	dir string
+	mu  sync.Mutex // Serializes view state updates
+}
+
+// New creates a store under the given config directory
+func New(configDir string) *Store {
+	return &Store{dir: filepath.Join(configDir, storeDirName)}
+}
+
+// Dir returns the store directory
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+func (s *Store) casePath(caseNumber string) string {
+	return filepath.Join(s.dir, casesDirName, filepath.Base(caseNumber)+".json")
+}
+
+// SaveCase writes a case record to the store
+func (s *Store) SaveCase(rec *CaseRecord) error {
+	if rec.Case.CaseNumber == "" {
+		return fmt.Errorf("case record has no case number")
+	}
+	if rec.SyncedAt.IsZero() {
+		rec.SyncedAt = time.Now()
+	}
+	return s.writeJSON(s.casePath(rec.Case.CaseNumber), rec)
+}
+
+// LoadCase reads a case record from the store
+func (s *Store) LoadCase(caseNumber string) (*CaseRecord, error) {
+	data, err := os.ReadFile(s.casePath(caseNumber))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, caseNumber)
+		}
+		return nil, fmt.Errorf("failed to read case %s: %w", caseNumber, err)
+	}
+
+	var rec CaseRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("failed to parse case %s: %w", caseNumber, err)
+	}
+	return &rec, nil
+}
+
+// ListCases returns all stored cases, most recently modified first
+func (s *Store) ListCases() ([]api.Case, error) {
+	entries, err := os.ReadDir(filepath.Join(s.dir, casesDirName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read store: %w", err)
+	}
+
+	cases := make([]api.Case, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		rec, err := s.LoadCase(strings.TrimSuffix(e.Name(), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, rec.Case)
+	}
+
+	sort.Slice(cases, func(i, j int) bool {
+		return cases[i].LastModified.After(cases[j].LastModified)
+	})
+	return cases, nil
+}
+
+// QueryCases filters and pages stored cases like Client.ListCases
+func (s *Store) QueryCases(filter *api.CaseFilter) (*api.ListResponse[api.Case], error) {
+	all, err := s.ListCases()
+	if err != nil {
+		return nil, err
+	}
+	matched := FilterCases(all, filter)
+
+	start, end := 0, len(matched)
+	if filter != nil {
+		start = min(max(filter.StartIndex, 0), len(matched))
+		if filter.Count > 0 {
+			end = min(start+filter.Count, end)
+		}
+	}
+	return &api.ListResponse[api.Case]{
+		Items:      matched[start:end],
+		TotalCount: len(matched),
+		StartIndex: start,
+		Count:      end - start,
+	}, nil
+}
+
+// Products returns the sorted, distinct products of all stored cases
+func (s *Store) Products() ([]string, error) {
+	cases, err := s.ListCases()
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var products []string
+	for _, c := range cases {
+		if c.Product != "" && !seen[c.Product] {
+			seen[c.Product] = true
+			products = append(products, c.Product)
+		}
+	}
+	sort.Strings(products)
+	return products, nil
+}
+
+// LoadState reads the store state, returning an empty state if none exists
+func (s *Store) LoadState() (*State, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, stateFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &State{}, nil
+		}
+		return nil, fmt.Errorf("failed to read store state: %w", err)
+	}
+
+	var st State
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("failed to parse store state: %w", err)
+	}
+	return &st, nil
+}
+
+// SaveState writes the store state
+func (s *Store) SaveState(st *State) error {
+	return s.writeJSON(filepath.Join(s.dir, stateFileName), st)
+}
+
+// writeJSON atomically writes v as JSON to path
+func (s *Store) writeJSON(path string, v any) error {
+	if err := os.MkdirAll(filepath.Dir(path), dirPerms); err != nil {
+		return fmt.Errorf("failed to create store directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", filepath.Base(path), err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, filePerms); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// FilterCases applies a case filter locally, mirroring the Hydra query built by ListCases.
+// GroupNumber is not applied because cases do not carry their group; sync by group instead.
+// A query that does not parse matches nothing.
+func FilterCases(cases []api.Case, filter *api.CaseFilter) []api.Case {
+	if filter == nil {
+		filter = &api.CaseFilter{}
+	}
+
+	var query *api.Query
+	if filter.Query != "" {
+		var err error
+		if query, err = api.ParseQuery(filter.Query); err != nil {
+			return nil
+		}
+	}
+	now := time.Now()
+
+	keyword := strings.ToLower(strings.TrimSpace(filter.Keyword))
+	if keyword == "*:*" {
+		keyword = ""
+	}
+
+	var result []api.Case
+	for _, c := range cases {
+		if len(filter.Status) > 0 {
+			if !matchAny(filter.Status, c.Status) {
+				continue
+			}
+		} else if !filter.IncludeClosed && (query == nil || !query.Has("status")) && strings.EqualFold(c.Status, "Closed") {
+			continue
+		}
+		if len(filter.Severity) > 0 && !matchSeverity(filter.Severity, c.Severity) {
+			continue
+		}
+		if len(filter.Products) > 0 && !matchAny(filter.Products, c.Product) {
+			continue
+		}
+		if len(filter.Accounts) > 0 && !matchAny(filter.Accounts, c.AccountNumber) {
+			continue
+		}
+		if filter.OwnerSSOName != "" && !strings.EqualFold(filter.OwnerSSOName, c.Owner) {
+			continue
+		}
+		if filter.StartDate != nil && c.CreatedDate.Before(*filter.StartDate) {
+			continue
+		}
+		if filter.EndDate != nil && c.CreatedDate.After(*filter.EndDate) {
+			continue
+		}
+		if keyword != "" &&
+			!strings.Contains(strings.ToLower(c.Summary), keyword) &&
+			!strings.Contains(strings.ToLower(c.Description), keyword) &&
+			!strings.Contains(c.CaseNumber, keyword) {
+			continue
+		}
+		if query != nil && !query.Match(&c, now) {
+			continue
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+func matchAny(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), s) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSeverity accepts full severities ("2 (High)") or just the number ("2")
+func matchSeverity(values []string, severity string) bool {
+	num, _, _ := strings.Cut(severity, " ")
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if strings.EqualFold(v, severity) || v == num {
+			return true
+		}
+	}
+	return false
+}
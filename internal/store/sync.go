@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package store
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/green/agcm/internal/api"
+)
+
+// SyncOptions configures a sync
+type SyncOptions struct {
+	Accounts    []string
+	GroupNumber string
+	Full        bool // Ignore the last sync time and refresh every case
+	Progress    func(caseNumber string, fetched int)
+}
+
+// key identifies the filter a sync covers. Each filter keeps its own sync
+// point, since a sync of one account says nothing about another's cases.
+func (o *SyncOptions) key() string {
+	accounts := slices.Clone(o.Accounts)
+	slices.Sort(accounts)
+	return strings.Join(accounts, ",") + "|" + o.GroupNumber
+}
+
+// SyncResult summarizes a sync
+type SyncResult struct {
+	Checked   int
+	Updated   int
+	Unchanged int
+	Since     time.Time
+}
+
+// Sync pulls cases modified since the last sync of the same filter into the
+// store. Cases are listed newest-modified first, so listing stops at the
+// first case older than the newest one the previous sync saw.
+func (s *Store) Sync(ctx context.Context, client *api.Client, opts SyncOptions) (*SyncResult, error) {
+	state, err := s.LoadState()
+	if err != nil {
+		return nil, err
+	}
+
+	key := opts.key()
+	result := &SyncResult{}
+	if !opts.Full {
+		result.Since = state.Synced[key]
+	}
+	newest := result.Since
+	started := time.Now()
+
+	filter := &api.CaseFilter{
+		Accounts:      opts.Accounts,
+		GroupNumber:   opts.GroupNumber,
+		IncludeClosed: true, // Pick up cases that were closed since the last sync
+		Count:         api.PageSize,
+	}
+
+	it := client.IterateCases(ctx, filter)
+	done := false
+	for !done && it.Next() {
+		for _, c := range it.Page() {
+			// Cases modified in the same second as the sync point are
+			// checked again rather than risk missing one
+			if !result.Since.IsZero() && c.LastModified.Before(result.Since) {
+				done = true
+				break
+			}
+			result.Checked++
+			if c.LastModified.After(newest) {
+				newest = c.LastModified
+			}
+
+			// Skip cases whose stored copy is already current
+			if !opts.Full {
+				if rec, err := s.LoadCase(c.CaseNumber); err == nil && !c.LastModified.After(rec.Case.LastModified) {
+					result.Unchanged++
+					continue
+				}
+			}
+
+			if err := s.fetchCase(ctx, client, c.CaseNumber); err != nil {
+				return result, err
+			}
+			result.Updated++
+			if opts.Progress != nil {
+				opts.Progress(c.CaseNumber, result.Updated)
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return result, fmt.Errorf("failed to list cases: %w", err)
+	}
+
+	state.LastSync = started
+	if state.Synced == nil {
+		state.Synced = make(map[string]time.Time)
+	}
+	state.Synced[key] = newest
+	if err := s.SaveState(state); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// fetchCase downloads a case with its comments and attachment metadata
+func (s *Store) fetchCase(ctx context.Context, client *api.Client, caseNumber string) error {
+	c, err := client.GetCase(ctx, caseNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get case %s: %w", caseNumber, err)
+	}
+	comments, err := client.GetCaseComments(ctx, caseNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get comments for case %s: %w", caseNumber, err)
+	}
+	attachments, err := client.GetCaseAttachments(ctx, caseNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get attachments for case %s: %w", caseNumber, err)
+	}
+
+	return s.SaveCase(&CaseRecord{
+		Case:        *c,
+		Comments:    comments,
+		Attachments: attachments,
+		SyncedAt:    time.Now(),
+	})
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/green/agcm/internal/api"
+	"github.com/green/agcm/internal/apitest"
+)
+
+func TestSyncPerFilter(t *testing.T) {
+	fx := apitest.DefaultFixtures(time.Now())
+	srv := apitest.NewServer(fx)
+	defer srv.Close()
+	client := srv.NewClient(api.WithRateLimit(0, 0))
+	s := New(t.TempDir())
+	ctx := context.Background()
+
+	counts := make(map[string]int)
+	for _, c := range fx.Cases {
+		counts[c.AccountNumber]++
+	}
+
+	// A sync of one account must not stop a later sync of another early
+	for _, account := range []string{"5550202", "5550101"} {
+		result, err := s.Sync(ctx, client, SyncOptions{Accounts: []string{account}})
+		if err != nil {
+			t.Fatalf("Sync(%s): %v", account, err)
+		}
+		if result.Updated != counts[account] {
+			t.Errorf("Sync(%s) updated %d cases, want %d", account, result.Updated, counts[account])
+		}
+	}
+	for _, c := range fx.Cases {
+		if _, err := s.LoadCase(c.CaseNumber); err != nil {
+			t.Errorf("case %s not stored: %v", c.CaseNumber, err)
+		}
+	}
+
+	// Syncing again finds nothing newer than what each filter saw
+	result, err := s.Sync(ctx, client, SyncOptions{Accounts: []string{"5550101"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Updated != 0 {
+		t.Errorf("second Sync updated %d cases, want 0", result.Updated)
+	}
+	state, err := s.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Synced) != 2 {
+		t.Errorf("state has %d sync points, want 2", len(state.Synced))
+	}
+}
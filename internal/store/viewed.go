@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const viewedFileName = "viewed.json"
+
+// ViewState records when each case was last viewed
+type ViewState struct {
+	// Since is when tracking began; cases never viewed count as read up to here
+	Since time.Time            `json:"since"`
+	Cases map[string]time.Time `json:"cases"`
+}
+
+// LastViewed returns when a case was last viewed
+func (v *ViewState) LastViewed(caseNumber string) time.Time {
+	if t, ok := v.Cases[caseNumber]; ok && t.After(v.Since) {
+		return t
+	}
+	return v.Since
+}
+
+// IsUnread reports whether a case was modified after it was last viewed
+func (v *ViewState) IsUnread(caseNumber string, lastModified time.Time) bool {
+	return lastModified.After(v.LastViewed(caseNumber))
+}
+
+// LoadViewState reads the view state, starting tracking now if none exists
+func (s *Store) LoadViewState() (*ViewState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.loadViewState()
+}
+
+// MarkViewed records that a case was viewed at the given time
+func (s *Store) MarkViewed(caseNumber string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, err := s.loadViewState()
+	if err != nil {
+		return err
+	}
+	if at.After(v.Cases[caseNumber]) {
+		v.Cases[caseNumber] = at
+	}
+	return s.writeJSON(filepath.Join(s.dir, viewedFileName), v)
+}
+
+func (s *Store) loadViewState() (*ViewState, error) {
+	path := filepath.Join(s.dir, viewedFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read view state: %w", err)
+		}
+		v := &ViewState{Since: time.Now(), Cases: make(map[string]time.Time)}
+		if err := s.writeJSON(path, v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+
+	var v ViewState
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("failed to parse view state: %w", err)
+	}
+	if v.Cases == nil {
+		v.Cases = make(map[string]time.Time)
+	}
+	return &v, nil
+}
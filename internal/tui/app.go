@@ -20,6 +20,7 @@ import (
 	"github.com/green/agcm/internal/api"
 	"github.com/green/agcm/internal/config"
 	"github.com/green/agcm/internal/export"
+	"github.com/green/agcm/internal/store"
 	"github.com/green/agcm/internal/tui/components"
 	"github.com/green/agcm/internal/tui/styles"
 )
@@ -64,6 +65,27 @@ type Options struct {
 	GroupNumber string
 	MaskMode    bool
 	Version     string
+	Offline     bool           // Read cases from Store instead of the API
+	Store       *store.Store   // Local case store; also written through when online
+	Profile     string         // Active profile, shown in the header unless it is the default
+	Theme       string         // Overrides the configured theme
+	Keys        *styles.KeyMap // Key bindings; nil for the defaults
+
+	// TokenExpiresAt is when the offline token expires; the status bar warns
+	// as it approaches. Zero if the token has no fixed expiry.
+	TokenExpiresAt time.Time
+
+	// SwitchProfile connects to another profile; nil disables the switcher
+	SwitchProfile func(name string) (*Connection, error)
+}
+
+// Connection is what the TUI needs to talk to a profile's portal
+type Connection struct {
+	Client         *api.Client
+	Store          *store.Store
+	Accounts       []string
+	GroupNumber    string
+	TokenExpiresAt time.Time
 }
 
 // CachedCaseDetail holds cached case details
@@ -79,6 +101,7 @@ type Model struct {
 	configMgr *config.Manager
 	opts      Options
 	styles    *styles.Styles
+	theme     string // Name of the current theme
 	keys      *styles.KeyMap
 	width     int
 	height    int
@@ -110,7 +133,21 @@ type Model struct {
 	pendingExport    string // "single" or "bulk"
 	exportCaseNumber string // For single export
 	exportPath       string // File or directory path
+	exportFormat     string // Export format chosen in the file picker
 	exportProgressCh chan export.Progress
+	pendingComment   string // Comment text waiting to be posted
+	commentCase      string // Case the pending comment belongs to
+	pendingUpload    string // File chosen for upload
+	uploadCase       string // Case the pending upload belongs to
+	uploading        bool
+	uploadCancel     context.CancelFunc
+	uploadProgressCh chan uploadProgressMsg
+	pendingUpdate    *api.CaseUpdate          // Case change confirmed in the modal
+	updateCase       string                   // Case the pending change belongs to
+	pollInterval     time.Duration            // Delay before the next background poll
+	viewState        *store.ViewState         // Last-viewed times; nil if unavailable
+	polledComments   map[string][]api.Comment // Comments fetched for changed cases
+	pendingProfile   string                   // Profile chosen in the switcher
 
 	// Layout info for mouse
 	listHeight     int
@@ -137,9 +174,36 @@ type Model struct {
 	// Text search within case
 	textSearch     *components.TextSearch
 	textSearchMode bool
+
+	// New case form
+	newCaseDialog *components.NewCaseDialog
+	caseProducts  []api.Product
+	caseValues    *api.CaseValues
+
+	// Case list column chooser
+	columnChooser *components.ColumnChooser
 }
 
+// Background polling for case changes
+const (
+	backgroundPollInterval    = 2 * time.Minute
+	backgroundPollMaxInterval = 15 * time.Minute
+)
+
 // Messages
+type pollTickMsg struct{}
+
+type pollResultMsg struct {
+	cases []api.Case
+	err   error
+}
+
+type commentsPolledMsg struct {
+	caseNumber string
+	comments   []api.Comment
+	err        error
+}
+
 type casesLoadedMsg struct {
 	cases      []api.Case
 	totalCount int
@@ -191,6 +255,56 @@ type productsLoadedMsg struct {
 	err      error
 }
 
+type newCaseOptionsLoadedMsg struct {
+	products []api.Product
+	values   *api.CaseValues
+	err      error
+}
+
+type productVersionsLoadedMsg struct {
+	product  string
+	versions []string
+	err      error
+}
+
+type uploadProgressMsg struct {
+	sent  int64
+	total int64
+}
+
+type uploadCompleteMsg struct {
+	caseNumber string
+	filename   string
+	err        error
+}
+
+type caseValuesLoadedMsg struct {
+	values *api.CaseValues
+	err    error
+}
+
+type caseUpdatedMsg struct {
+	caseNumber string
+	case_      *api.Case
+	err        error
+}
+
+type commentAddedMsg struct {
+	caseNumber string
+	err        error
+}
+
+type caseCreatedMsg struct {
+	case_ *api.Case
+	err   error
+}
+
+type profileSwitchedMsg struct {
+	name string
+	conn *Connection
+	err  error
+}
+
 // Debounce delay for auto-fetching case details
 const debounceDelay = 500 * time.Millisecond
 const casePageSize = 100
@@ -198,7 +312,10 @@ const casePageSize = 100
 // NewModel creates a new TUI model
 func NewModel(client *api.Client, opts Options, configMgr *config.Manager) *Model {
 	s := styles.DefaultStyles()
-	keys := styles.DefaultKeyMap()
+	keys := opts.Keys
+	if keys == nil {
+		keys = styles.DefaultKeyMap()
+	}
 
 	sp := spinner.New()
 	sp.Spinner = spinner.Dot
@@ -209,38 +326,203 @@ func NewModel(client *api.Client, opts Options, configMgr *config.Manager) *Mode
 	caseDetail := components.NewCaseDetail(s, keys)
 	caseDetail.SetMaskMode(opts.MaskMode)
 
-	return &Model{
-		client:       client,
-		configMgr:    configMgr,
-		opts:         opts,
-		styles:       s,
-		keys:         keys,
-		caseList:     caseList,
-		caseDetail:   caseDetail,
-		statusBar:    components.NewStatusBar(s),
-		spinner:      sp,
-		modal:        components.NewModal(s),
-		filePicker:   components.NewFilePickerDialog(s),
-		quickSearch:  components.NewQuickSearch(s),
-		filterDialog: components.NewFilterDialog(s),
-		filterBar:    components.NewFilterBar(s),
-		textSearch:   components.NewTextSearch(s),
-		currentPane:  PaneList,
-		sortField:    SortByLastModified,
-		sortReverse:  true,
-		detailCache:  make(map[string]*CachedCaseDetail),
+	m := &Model{
+		client:        client,
+		configMgr:     configMgr,
+		opts:          opts,
+		styles:        s,
+		keys:          keys,
+		caseList:      caseList,
+		caseDetail:    caseDetail,
+		statusBar:     components.NewStatusBar(s, keys),
+		spinner:       sp,
+		modal:         components.NewModal(s),
+		filePicker:    components.NewFilePickerDialog(s),
+		quickSearch:   components.NewQuickSearch(s),
+		filterDialog:  components.NewFilterDialog(s),
+		filterBar:     components.NewFilterBar(s, keys),
+		textSearch:    components.NewTextSearch(s),
+		newCaseDialog: components.NewNewCaseDialog(s),
+		columnChooser: components.NewColumnChooser(s),
+		currentPane:   PaneList,
+		sortField:     SortByLastModified,
+		sortReverse:   true,
+		detailCache:   make(map[string]*CachedCaseDetail),
+	}
+	m.statusBar.SetTokenExpiry(opts.TokenExpiresAt)
+
+	theme := opts.Theme
+	if theme == "" && configMgr != nil {
+		theme = configMgr.GetTheme()
+	}
+	if err := m.setTheme(theme); err != nil {
+		m.theme = styles.ThemeAuto
+		m.statusBar.SetMessage(m.styles.Warning.Render(err.Error()+"; using auto"), 10*time.Second)
+	}
+
+	if configMgr != nil && len(configMgr.GetColumns()) > 0 {
+		if columns, err := api.ParseColumns(configMgr.GetColumns()); err != nil {
+			m.statusBar.SetMessage(m.styles.Warning.Render("ui.columns: "+err.Error()+"; using defaults"), 10*time.Second)
+		} else {
+			m.caseList.SetColumns(columns)
+		}
+	}
+
+	if opts.Store != nil {
+		// Unread tracking is best effort; without it nothing is marked unread
+		if vs, err := opts.Store.LoadViewState(); err == nil {
+			m.viewState = vs
+		}
 	}
+	return m
 }
 
 // Init implements tea.Model
 func (m *Model) Init() tea.Cmd {
 	m.loadingCases = true
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		m.loadCasesPage(0, false),
+		m.loadCaseValues(),
 		tea.EnterAltScreen,
 		m.spinner.Tick,
 		m.statusBar.SpinnerTick(),
-	)
+	}
+	if !m.opts.Offline {
+		m.pollInterval = backgroundPollInterval
+		cmds = append(cmds, m.schedulePoll())
+	}
+	return tea.Batch(cmds...)
+}
+
+// schedulePoll waits for the current poll interval before polling again
+func (m *Model) schedulePoll() tea.Cmd {
+	return tea.Tick(m.pollInterval, func(time.Time) tea.Msg {
+		return pollTickMsg{}
+	})
+}
+
+// pollCases fetches the first page of the current view to look for changes
+func (m *Model) pollCases() tea.Cmd {
+	filter := m.withDefaults(m.activeFilter, 0, casePageSize)
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		result, err := m.client.ListCases(ctx, filter)
+		if err != nil {
+			return pollResultMsg{err: err}
+		}
+		return pollResultMsg{cases: result.Items}
+	}
+}
+
+// applyPollResult badges cases modified since they were loaded and
+// refreshes the detail of the highlighted case if it changed
+func (m *Model) applyPollResult(msg pollResultMsg) tea.Cmd {
+	if msg.err != nil {
+		m.pollInterval = min(m.pollInterval*2, backgroundPollMaxInterval)
+		return nil
+	}
+	m.pollInterval = backgroundPollInterval
+
+	index := make(map[string]int, len(m.cases))
+	for i, c := range m.cases {
+		index[c.CaseNumber] = i
+	}
+
+	var cmds []tea.Cmd
+	newCases := 0
+	for _, c := range msg.cases {
+		i, ok := index[c.CaseNumber]
+		if !ok {
+			newCases++
+			continue
+		}
+		if !c.LastModified.After(m.cases[i].LastModified) {
+			continue
+		}
+		m.cases[i] = c
+		delete(m.detailCache, c.CaseNumber)
+		if c.CaseNumber == m.highlightedCase {
+			cmds = append(cmds, m.loadCaseDetail(c.CaseNumber))
+		} else {
+			cmds = append(cmds, m.pollComments(c.CaseNumber))
+		}
+	}
+	m.refreshUnread()
+
+	if newCases > 0 {
+		m.statusBar.SetMessage(fmt.Sprintf("%d more case(s) updated - press %s to refresh", newCases, styles.Label(m.keys.Refresh)), 5*time.Second)
+	}
+	return tea.Batch(cmds...)
+}
+
+// pollComments fetches comments of a changed case to count unread ones
+func (m *Model) pollComments(caseNumber string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		comments, err := m.client.GetCaseComments(ctx, caseNumber)
+		return commentsPolledMsg{caseNumber: caseNumber, comments: comments, err: err}
+	}
+}
+
+// lastViewed returns when a case was last viewed, or zero if not tracked
+func (m *Model) lastViewed(caseNumber string) time.Time {
+	if m.viewState == nil {
+		return time.Time{}
+	}
+	return m.viewState.LastViewed(caseNumber)
+}
+
+// markViewed records that the highlighted case was viewed and persists it
+func (m *Model) markViewed(caseNumber string) tea.Cmd {
+	if m.viewState == nil {
+		return nil
+	}
+	now := time.Now()
+	m.viewState.Cases[caseNumber] = now
+	delete(m.polledComments, caseNumber)
+	m.refreshUnread()
+
+	st := m.opts.Store
+	return func() tea.Msg {
+		if err := st.MarkViewed(caseNumber, now); err != nil {
+			return statusMsg{message: m.styles.Warning.Render("Failed to save read state: " + err.Error())}
+		}
+		return nil
+	}
+}
+
+// refreshUnread recomputes which loaded cases have unread activity
+func (m *Model) refreshUnread() {
+	if m.viewState == nil {
+		m.caseList.SetUnread(nil)
+		return
+	}
+
+	unread := make(map[string]int)
+	for _, c := range m.cases {
+		since := m.viewState.LastViewed(c.CaseNumber)
+		if !c.LastModified.After(since) {
+			continue
+		}
+		comments, ok := m.polledComments[c.CaseNumber]
+		if !ok && m.opts.Offline {
+			if rec, err := m.opts.Store.LoadCase(c.CaseNumber); err == nil {
+				comments = rec.Comments
+			}
+		}
+		count := 0
+		for _, cm := range comments {
+			if cm.CreatedDate.After(since) {
+				count++
+			}
+		}
+		unread[c.CaseNumber] = count
+	}
+	m.caseList.SetUnread(unread)
 }
 
 // loadCasesWithFilter loads cases using a custom filter
@@ -249,7 +531,7 @@ func (m *Model) loadCasesWithFilter(filter *api.CaseFilter) tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 		reqFilter := m.withDefaults(filter, 0, casePageSize)
-		result, err := m.client.ListCases(ctx, reqFilter)
+		result, err := m.listCases(ctx, reqFilter)
 		if err != nil {
 			return casesLoadedMsg{err: err}
 		}
@@ -269,7 +551,7 @@ func (m *Model) loadCasesPage(start int, append bool) tea.Cmd {
 		defer cancel()
 
 		reqFilter := m.withDefaults(m.activeFilter, start, casePageSize)
-		result, err := m.client.ListCases(ctx, reqFilter)
+		result, err := m.listCases(ctx, reqFilter)
 		if err != nil {
 			return casesLoadedMsg{err: err}
 		}
@@ -282,6 +564,65 @@ func (m *Model) loadCasesPage(start int, append bool) tea.Cmd {
 	}
 }
 
+// listCases lists cases from the API, or from the local store in offline mode
+func (m *Model) listCases(ctx context.Context, filter *api.CaseFilter) (*api.ListResponse[api.Case], error) {
+	if m.opts.Offline {
+		return m.opts.Store.QueryCases(filter)
+	}
+	return m.client.ListCases(ctx, filter)
+}
+
+// switchProfile connects to another profile in the background
+func (m *Model) switchProfile(name string) tea.Cmd {
+	switchFn := m.opts.SwitchProfile
+	return func() tea.Msg {
+		conn, err := switchFn(name)
+		return profileSwitchedMsg{name: name, conn: conn, err: err}
+	}
+}
+
+// applyConnection points the TUI at a newly switched profile, dropping
+// everything loaded from the previous one
+func (m *Model) applyConnection(name string, conn *Connection) {
+	m.client = conn.Client
+	m.opts.Profile = name
+	m.opts.Store = conn.Store
+	m.opts.Accounts = conn.Accounts
+	m.opts.GroupNumber = conn.GroupNumber
+	m.opts.TokenExpiresAt = conn.TokenExpiresAt
+	m.statusBar.SetTokenExpiry(conn.TokenExpiresAt)
+
+	m.activeFilter = nil
+	m.activePreset = ""
+	m.filterBar.Clear()
+	m.filterBar.ClearPreset()
+	m.updateLayout()
+	m.products = nil
+	m.caseProducts = nil
+	m.caseValues = nil
+	m.detailCache = make(map[string]*CachedCaseDetail)
+	m.polledComments = nil
+	m.viewState = nil
+	if conn.Store != nil {
+		if vs, err := conn.Store.LoadViewState(); err == nil {
+			m.viewState = vs
+		}
+	}
+
+	m.loadingCases = true
+	m.totalCases = 0
+	m.caseList.SetTotalCount(0)
+}
+
+// requireOnline reports whether the action can run, warning in offline mode
+func (m *Model) requireOnline() bool {
+	if m.opts.Offline {
+		m.statusBar.SetMessage(m.styles.Warning.Render("Not available in offline mode"), 2*time.Second)
+		return false
+	}
+	return true
+}
+
 func (m *Model) withDefaults(filter *api.CaseFilter, start, count int) *api.CaseFilter {
 	req := &api.CaseFilter{
 		Count:       count,
@@ -310,10 +651,24 @@ func (m *Model) loadCaseDetail(caseNumber string) tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
+		// Offline: serve everything from the local store
+		if m.opts.Offline {
+			rec, err := m.opts.Store.LoadCase(caseNumber)
+			if err != nil {
+				return caseDetailLoadedMsg{caseNumber: caseNumber, err: err}
+			}
+			return caseDetailLoadedMsg{
+				caseNumber:  caseNumber,
+				case_:       &rec.Case,
+				comments:    rec.Comments,
+				attachments: rec.Attachments,
+			}
+		}
+
 		// Load case details
 		c, err := m.client.GetCase(ctx, caseNumber)
 		if err != nil {
-			return caseDetailLoadedMsg{caseNumber: caseNumber, err: err}
+			return caseDetailLoadedMsg{caseNumber: caseNumber, err: api.CaseError(caseNumber, err)}
 		}
 
 		// Load comments
@@ -328,6 +683,15 @@ func (m *Model) loadCaseDetail(caseNumber string) tea.Cmd {
 			attachments = nil
 		}
 
+		// Keep the local store current with complete details
+		if m.opts.Store != nil && commentsErr == nil && attachErr == nil {
+			_ = m.opts.Store.SaveCase(&store.CaseRecord{
+				Case:        *c,
+				Comments:    comments,
+				Attachments: attachments,
+			})
+		}
+
 		return caseDetailLoadedMsg{
 			caseNumber:  caseNumber,
 			case_:       c,
@@ -340,28 +704,16 @@ func (m *Model) loadCaseDetail(caseNumber string) tea.Cmd {
 }
 
 func (m *Model) fetchAllCaseNumbers(ctx context.Context) ([]string, error) {
-	start := 0
-	total := -1
 	var caseNumbers []string
-
-	for {
-		reqFilter := m.withDefaults(m.activeFilter, start, casePageSize)
-		result, err := m.client.ListCases(ctx, reqFilter)
-		if err != nil {
-			return nil, err
-		}
-		if total < 0 {
-			total = result.TotalCount
-		}
-		for _, c := range result.Items {
+	it := api.IterateCasesWith(ctx, m.listCases, m.withDefaults(m.activeFilter, 0, casePageSize))
+	for it.Next() {
+		for _, c := range it.Page() {
 			caseNumbers = append(caseNumbers, c.CaseNumber)
 		}
-		if len(result.Items) == 0 || len(caseNumbers) >= total {
-			break
-		}
-		start += len(result.Items)
 	}
-
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
 	return caseNumbers, nil
 }
 
@@ -370,7 +722,13 @@ func (m *Model) loadProducts() tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		names, err := m.client.ListCaseProducts(ctx)
+		var names []string
+		var err error
+		if m.opts.Offline {
+			names, err = m.opts.Store.Products()
+		} else {
+			names, err = m.client.ListCaseProducts(ctx)
+		}
 		if err != nil {
 			return productsLoadedMsg{err: err}
 		}
@@ -378,6 +736,143 @@ func (m *Model) loadProducts() tea.Cmd {
 	}
 }
 
+// loadNewCaseOptions loads the products and case values for the new case form
+func (m *Model) loadNewCaseOptions() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		products, err := m.client.ListProducts(ctx)
+		if err != nil {
+			return newCaseOptionsLoadedMsg{err: err}
+		}
+		values, err := m.client.GetCaseValues(ctx)
+		if err != nil {
+			return newCaseOptionsLoadedMsg{err: err}
+		}
+		return newCaseOptionsLoadedMsg{products: products, values: values}
+	}
+}
+
+// loadProductVersions loads the versions of a product for the new case form
+func (m *Model) loadProductVersions(product string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		versions, err := m.client.GetProductVersions(ctx, product)
+		return productVersionsLoadedMsg{product: product, versions: versions, err: err}
+	}
+}
+
+// createCase submits a new case to the portal
+func (m *Model) createCase(req *api.CreateCaseRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		c, err := m.client.CreateCase(ctx, req)
+		return caseCreatedMsg{case_: c, err: err}
+	}
+}
+
+// addComment posts a comment and reports back so the case can be reloaded
+func (m *Model) addComment(caseNumber, text string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		_, err := m.client.AddComment(ctx, caseNumber, text)
+		return commentAddedMsg{caseNumber: caseNumber, err: err}
+	}
+}
+
+// startUpload streams a file to a case, reporting progress in the modal
+func (m *Model) startUpload(caseNumber, filePath string) tea.Cmd {
+	m.uploading = true
+	ctx, cancel := context.WithCancel(context.Background())
+	m.uploadCancel = cancel
+	filename := filepath.Base(filePath)
+	m.modal.ShowProgress("Uploading Attachment", "Uploading "+filename+"...")
+	progressCh := make(chan uploadProgressMsg, 1)
+	m.uploadProgressCh = progressCh
+
+	uploadCmd := func() tea.Msg {
+		_, err := m.client.UploadAttachment(ctx, caseNumber, filePath, func(sent, total int64) {
+			// Drop updates while the UI is still drawing the previous one
+			select {
+			case progressCh <- uploadProgressMsg{sent: sent, total: total}:
+			default:
+			}
+		})
+		close(progressCh)
+		return uploadCompleteMsg{caseNumber: caseNumber, filename: filename, err: err}
+	}
+	return tea.Batch(uploadCmd, m.waitUploadProgress())
+}
+
+// waitUploadProgress waits for the next upload progress update
+func (m *Model) waitUploadProgress() tea.Cmd {
+	ch := m.uploadProgressCh
+	if ch == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		p, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return p
+	}
+}
+
+// loadCaseValues loads the allowed severities, statuses, and types
+func (m *Model) loadCaseValues() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		values, err := m.client.GetCaseValues(ctx)
+		return caseValuesLoadedMsg{values: values, err: err}
+	}
+}
+
+// updateCaseFields sends a case update to the portal
+func (m *Model) updateCaseFields(caseNumber string, update *api.CaseUpdate) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		c, err := m.client.UpdateCase(ctx, caseNumber, update)
+		return caseUpdatedMsg{caseNumber: caseNumber, case_: c, err: err}
+	}
+}
+
+// confirmCaseUpdate asks for confirmation before changing a case
+func (m *Model) confirmCaseUpdate(title, message, caseNumber string, update *api.CaseUpdate) {
+	m.modal.ShowConfirm(title, message,
+		func() {
+			m.pendingUpdate = update
+			m.updateCase = caseNumber
+		},
+		nil,
+	)
+}
+
+// severityIndex finds a case severity in the allowed list by its leading number
+func severityIndex(severities []string, severity string) int {
+	num, _, _ := strings.Cut(strings.TrimSpace(severity), " ")
+	for i, s := range severities {
+		if s == severity {
+			return i
+		}
+		if n, _, _ := strings.Cut(s, " "); n == num {
+			return i
+		}
+	}
+	return -1
+}
+
 // debounceCmd returns a command that fires after the debounce delay
 func debounceCmd(caseNumber string) tea.Cmd {
 	return tea.Tick(debounceDelay, func(t time.Time) tea.Msg {
@@ -407,7 +902,18 @@ func (m *Model) searchCaseByNumber(caseNumber string) tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
 
+		if m.opts.Offline {
+			rec, err := m.opts.Store.LoadCase(caseNumber)
+			if err != nil {
+				return quickSearchResultMsg{caseNumber: caseNumber, err: err}
+			}
+			return quickSearchResultMsg{caseNumber: caseNumber, case_: &rec.Case}
+		}
+
 		c, err := m.client.GetCase(ctx, caseNumber)
+		if err != nil {
+			err = api.CaseError(caseNumber, err)
+		}
 		return quickSearchResultMsg{
 			caseNumber: caseNumber,
 			case_:      c,
@@ -462,14 +968,17 @@ func (m *Model) addOrSelectCase(c *api.Case) {
 		return
 	}
 
+	// Show all cases if the unread filter hides this one
+	if m.caseList.UnreadOnly() && m.caseList.IndexOf(c.CaseNumber) < 0 {
+		m.caseList.SetUnreadOnly(false)
+	}
+
 	// Check if case already in list
-	for i, existing := range m.cases {
-		if existing.CaseNumber == c.CaseNumber {
-			m.caseList.SetCursor(i)
-			m.highlightedCase = c.CaseNumber
-			m.loadingDetail = true
-			return
-		}
+	if i := m.caseList.IndexOf(c.CaseNumber); i >= 0 {
+		m.caseList.SetCursor(i)
+		m.highlightedCase = c.CaseNumber
+		m.loadingDetail = true
+		return
 	}
 
 	// Add to beginning of list
@@ -477,11 +986,8 @@ func (m *Model) addOrSelectCase(c *api.Case) {
 	m.sortCases()
 
 	// Find the new position and select it
-	for i, existing := range m.cases {
-		if existing.CaseNumber == c.CaseNumber {
-			m.caseList.SetCursor(i)
-			break
-		}
+	if i := m.caseList.IndexOf(c.CaseNumber); i >= 0 {
+		m.caseList.SetCursor(i)
 	}
 	m.highlightedCase = c.CaseNumber
 }
@@ -509,6 +1015,7 @@ func (m *Model) sortCases() {
 	})
 	m.caseList.SetCases(m.cases)
 	m.caseList.SetSort(components.SortField(m.sortField), m.sortReverse)
+	m.refreshUnread()
 }
 
 // cycleSortField cycles through sort fields
@@ -548,6 +1055,7 @@ func (m *Model) checkHighlightChange() tea.Cmd {
 		m.caseDetail.SetCase(cached.Case)
 		m.caseDetail.SetComments(cached.Comments)
 		m.caseDetail.SetAttachments(cached.Attachments)
+		m.caseDetail.SetUnreadSince(m.lastViewed(newCase))
 		return nil
 	}
 
@@ -560,6 +1068,23 @@ func (m *Model) checkHighlightChange() tea.Cmd {
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	// Background polling continues while dialogs are open
+	switch pm := msg.(type) {
+	case pollTickMsg:
+		return m, m.pollCases()
+	case pollResultMsg:
+		return m, tea.Batch(m.applyPollResult(pm), m.schedulePoll())
+	case commentsPolledMsg:
+		if pm.err == nil {
+			if m.polledComments == nil {
+				m.polledComments = make(map[string][]api.Comment)
+			}
+			m.polledComments[pm.caseNumber] = pm.comments
+			m.refreshUnread()
+		}
+		return m, nil
+	}
+
 	// Handle product list loading even when dialogs are visible.
 	if pl, ok := msg.(productsLoadedMsg); ok {
 		if pl.err != nil {
@@ -573,6 +1098,34 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Handle new case form data even while the form is visible.
+	switch nc := msg.(type) {
+	case newCaseOptionsLoadedMsg:
+		if nc.err != nil {
+			errText := nc.err.Error()
+			m.statusBar.SetMessage(m.styles.Warning.Render("Failed to load products: "+errText), 5*time.Second)
+			m.newCaseDialog.SetProductsError(errText)
+		} else {
+			m.caseProducts = nc.products
+			if nc.values != nil {
+				m.caseValues = nc.values
+			}
+			m.newCaseDialog.SetProducts(m.caseProducts)
+			m.newCaseDialog.SetCaseValues(m.caseValues)
+		}
+		return m, nil
+
+	case productVersionsLoadedMsg:
+		if nc.err != nil {
+			m.statusBar.SetMessage(m.styles.Warning.Render("Failed to load versions: "+nc.err.Error()), 5*time.Second)
+		}
+		m.newCaseDialog.SetVersions(nc.product, nc.versions)
+		return m, nil
+
+	case components.NewCaseProductSelectedMsg:
+		return m, m.loadProductVersions(nc.Product)
+	}
+
 	// Handle file picker input first
 	if m.filePicker.IsVisible() {
 		filePicker, cmd := m.filePicker.Update(msg)
@@ -598,6 +1151,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					cmds = append(cmds, exportCmd)
 				}
 			}
+			if m.pendingUpload != "" {
+				cmds = append(cmds, m.startUpload(m.uploadCase, m.pendingUpload))
+				m.pendingUpload = ""
+				m.uploadCase = ""
+			}
 		}
 		return m, tea.Batch(cmds...)
 	}
@@ -607,8 +1165,49 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
 			modal, cmd := m.modal.Update(keyMsg)
 			m.modal = modal
+			// Post a comment confirmed in the compose modal
+			if !m.modal.IsVisible() && m.pendingComment != "" {
+				text, caseNumber := m.pendingComment, m.commentCase
+				m.pendingComment = ""
+				m.commentCase = ""
+				m.statusBar.SetMessage(m.styles.Muted.Render("Posting comment to case "+caseNumber+"..."), 0)
+				return m, tea.Batch(cmd, m.addComment(caseNumber, text))
+			}
+			// Apply a case change confirmed in the modal
+			if !m.modal.IsVisible() && m.pendingUpdate != nil {
+				update, caseNumber := m.pendingUpdate, m.updateCase
+				m.pendingUpdate = nil
+				m.updateCase = ""
+				m.statusBar.SetMessage(m.styles.Muted.Render("Updating case "+caseNumber+"..."), 0)
+				return m, tea.Batch(cmd, m.updateCaseFields(caseNumber, update))
+			}
+			// Switch to a profile chosen in the switcher
+			if !m.modal.IsVisible() && m.pendingProfile != "" {
+				name := m.pendingProfile
+				m.pendingProfile = ""
+				if name == m.opts.Profile {
+					return m, cmd
+				}
+				m.statusBar.SetMessage(m.styles.Muted.Render("Switching to profile "+name+"..."), 0)
+				return m, tea.Batch(cmd, m.switchProfile(name))
+			}
+			// Esc on the upload progress modal cancels the transfer
+			if !m.modal.IsVisible() && m.uploading && m.uploadCancel != nil {
+				m.uploadCancel()
+			}
+			return m, cmd
+		}
+	}
+
+	// Handle filter query input; other messages still flow
+	if m.filterBar.IsEditing() {
+		filterBar, cmd := m.filterBar.Update(msg)
+		m.filterBar = filterBar
+		if _, ok := msg.(tea.KeyMsg); ok {
+			m.updateLayout()
 			return m, cmd
 		}
+		cmds = append(cmds, cmd)
 	}
 
 	// Handle quick search input
@@ -628,6 +1227,20 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	// Handle column chooser input
+	if m.columnChooser.IsVisible() {
+		columnChooser, cmd := m.columnChooser.Update(msg)
+		m.columnChooser = columnChooser
+		return m, cmd
+	}
+
+	// Handle new case form input
+	if m.newCaseDialog.IsVisible() {
+		newCaseDialog, cmd := m.newCaseDialog.Update(msg)
+		m.newCaseDialog = newCaseDialog
+		return m, cmd
+	}
+
 	// Handle text search input
 	if m.textSearchMode && m.textSearch.IsVisible() {
 		// Handle search query messages here (they come back from textSearch.Update)
@@ -684,6 +1297,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.updateLayout()
 		m.modal.SetSize(msg.Width, msg.Height)
 		m.filePicker.SetSize(msg.Width, msg.Height)
+		m.newCaseDialog.SetSize(msg.Width, msg.Height)
 
 	case tea.MouseMsg:
 		if !m.modal.IsVisible() && !m.filePicker.IsVisible() {
@@ -723,7 +1337,12 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case quickSearchResultMsg:
 		if msg.err != nil {
-			m.statusBar.SetMessage(m.styles.Error.Render("Case not found: "+msg.caseNumber), 3*time.Second)
+			text := "Case not found: " + msg.caseNumber
+			if api.StatusCode(msg.err) != 0 && !api.IsNotFound(msg.err) {
+				// Say why, e.g. the case exists but belongs to another account
+				text = "Error: " + msg.err.Error()
+			}
+			m.statusBar.SetMessage(m.styles.Error.Render(text), 5*time.Second)
 		} else {
 			m.addOrSelectCase(msg.case_)
 			m.statusBar.SetMessage(m.styles.Success.Render("Found case: "+msg.caseNumber), 2*time.Second)
@@ -738,6 +1357,37 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusBar.SetMessage(m.styles.Muted.Render("Applying filter..."), 0)
 		return m, tea.Batch(m.loadCasesWithFilter(msg.Filter), m.spinner.Tick)
 
+	case components.FilterQueryMsg:
+		filter := &api.CaseFilter{Count: 100}
+		if m.activeFilter != nil {
+			current := *m.activeFilter
+			filter = &current
+		}
+		filter.Query = msg.Query
+		m.activeFilter = filter
+		m.activePreset = ""
+		m.filterBar.ClearPreset()
+		m.filterBar.SetFilter(filter, 0, 0)
+		m.updateLayout()
+		m.loadingCases = true
+		m.detailCache = make(map[string]*CachedCaseDetail)
+		m.totalCases = 0
+		m.caseList.SetTotalCount(0)
+		m.statusBar.SetMessage(m.styles.Muted.Render("Applying query..."), 0)
+		return m, tea.Batch(m.loadCasesWithFilter(filter), m.spinner.Tick)
+
+	case components.ColumnsChangedMsg:
+		m.caseList.SetColumns(msg.Columns)
+		if m.configMgr != nil {
+			m.configMgr.SetColumns(api.ColumnSpecs(msg.Columns))
+			if err := m.configMgr.Save(); err != nil {
+				m.statusBar.SetMessage(m.styles.Warning.Render(fmt.Sprintf("Columns not saved: %v", err)), 3*time.Second)
+				return m, nil
+			}
+		}
+		m.statusBar.SetMessage(m.styles.Success.Render("Columns saved"), 2*time.Second)
+		return m, nil
+
 	case components.FilterClearMsg:
 		m.activeFilter = nil
 		m.loadingCases = true
@@ -750,6 +1400,95 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case components.FilterCancelMsg:
 		// Dialog closed without changes
 
+	case components.NewCaseSubmitMsg:
+		m.statusBar.SetMessage(m.styles.Muted.Render("Creating case..."), 0)
+		return m, m.createCase(msg.Request)
+
+	case components.NewCaseCancelMsg:
+		// Form closed without submitting
+
+	case uploadProgressMsg:
+		progress := 0.0
+		if msg.total > 0 {
+			progress = float64(msg.sent) / float64(msg.total)
+		}
+		m.modal.UpdateProgress(progress, fmt.Sprintf("Uploaded %s of %s", api.FormatSize(msg.sent), api.FormatSize(msg.total)))
+		cmds = append(cmds, m.waitUploadProgress())
+
+	case uploadCompleteMsg:
+		m.uploading = false
+		m.uploadCancel = nil
+		m.uploadProgressCh = nil
+		m.modal.Hide()
+		if msg.err != nil {
+			m.statusBar.SetMessage(m.styles.Error.Render("Upload failed: "+msg.err.Error()), 5*time.Second)
+		} else {
+			// Drop the cached detail so the new attachment shows up
+			delete(m.detailCache, msg.caseNumber)
+			m.statusBar.SetMessage(m.styles.Success.Render("Uploaded "+msg.filename+" to case "+msg.caseNumber), 3*time.Second)
+			if msg.caseNumber == m.highlightedCase {
+				m.loadingDetail = true
+				cmds = append(cmds, m.loadCaseDetail(msg.caseNumber), m.spinner.Tick)
+			}
+		}
+
+	case caseValuesLoadedMsg:
+		if msg.err == nil {
+			m.caseValues = msg.values
+		}
+
+	case caseUpdatedMsg:
+		if msg.err != nil {
+			m.statusBar.SetMessage(m.styles.Error.Render("Failed to update case: "+msg.err.Error()), 5*time.Second)
+		} else {
+			for i := range m.cases {
+				if m.cases[i].CaseNumber == msg.caseNumber {
+					m.cases[i] = *msg.case_
+					break
+				}
+			}
+			m.sortCases()
+			delete(m.detailCache, msg.caseNumber)
+			m.statusBar.SetMessage(m.styles.Success.Render(fmt.Sprintf("Case %s: severity %s, status %s",
+				msg.caseNumber, msg.case_.Severity, msg.case_.Status)), 3*time.Second)
+			if msg.caseNumber == m.highlightedCase {
+				m.loadingDetail = true
+				cmds = append(cmds, m.loadCaseDetail(msg.caseNumber), m.spinner.Tick)
+			}
+		}
+
+	case commentAddedMsg:
+		if msg.err != nil {
+			m.statusBar.SetMessage(m.styles.Error.Render("Failed to add comment: "+msg.err.Error()), 5*time.Second)
+		} else {
+			// Drop the cached detail so the new comment shows up
+			delete(m.detailCache, msg.caseNumber)
+			m.statusBar.SetMessage(m.styles.Success.Render("Comment added to case "+msg.caseNumber), 3*time.Second)
+			if msg.caseNumber == m.highlightedCase {
+				m.loadingDetail = true
+				cmds = append(cmds, m.loadCaseDetail(msg.caseNumber), m.spinner.Tick)
+			}
+		}
+
+	case caseCreatedMsg:
+		if msg.err != nil {
+			m.statusBar.SetMessage(m.styles.Error.Render("Failed to create case: "+msg.err.Error()), 5*time.Second)
+		} else {
+			m.addOrSelectCase(msg.case_)
+			m.loadingDetail = true
+			m.statusBar.SetMessage(m.styles.Success.Render("Created case: "+msg.case_.CaseNumber), 3*time.Second)
+			cmds = append(cmds, m.loadCaseDetail(msg.case_.CaseNumber), m.spinner.Tick)
+		}
+
+	case profileSwitchedMsg:
+		if msg.err != nil {
+			m.statusBar.SetMessage(m.styles.Error.Render("Failed to switch profile: "+msg.err.Error()), 5*time.Second)
+		} else {
+			m.applyConnection(msg.name, msg.conn)
+			m.statusBar.SetMessage(m.styles.Success.Render("Switched to profile "+msg.name), 3*time.Second)
+			cmds = append(cmds, m.loadCasesPage(0, false), m.loadCaseValues(), m.spinner.Tick)
+		}
+
 	case productsLoadedMsg:
 		if msg.err != nil {
 			m.statusBar.SetMessage(m.styles.Warning.Render("Failed to load products"), 3*time.Second)
@@ -836,8 +1575,48 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 		}
 
+		// Cycle themes (T)
+		if key.Matches(msg, m.keys.Theme) {
+			return m, m.cycleTheme()
+		}
+
+		// Column chooser (C)
+		if key.Matches(msg, m.keys.Columns) {
+			m.columnChooser.Show(m.caseList.Columns(), m.caseList.ColumnWidths())
+			return m, nil
+		}
+
+		// Filter query in the filter bar (:)
+		if key.Matches(msg, m.keys.Query) {
+			cmd := m.filterBar.EditQuery()
+			m.updateLayout()
+			return m, cmd
+		}
+
+		// New case form (N)
+		if key.Matches(msg, m.keys.NewCase) {
+			if !m.requireOnline() {
+				return m, nil
+			}
+			account := ""
+			if len(m.opts.Accounts) == 1 {
+				account = m.opts.Accounts[0]
+			} else if m.configMgr != nil {
+				account = m.configMgr.GetDefaults().AccountNumber
+			}
+			cmds = append(cmds, m.newCaseDialog.Show(account, m.opts.GroupNumber))
+			if len(m.caseProducts) == 0 {
+				m.newCaseDialog.SetProductsLoading()
+				cmds = append(cmds, m.loadNewCaseOptions())
+			} else {
+				m.newCaseDialog.SetProducts(m.caseProducts)
+				m.newCaseDialog.SetCaseValues(m.caseValues)
+			}
+			return m, tea.Batch(cmds...)
+		}
+
 		// Clear filter (F)
-		if msg.String() == "F" && (m.activeFilter != nil || m.activePreset != "") {
+		if key.Matches(msg, m.keys.ClearFilter) && (m.activeFilter != nil || m.activePreset != "") {
 			m.activeFilter = nil
 			m.activePreset = ""
 			m.filterBar.Clear()
@@ -852,7 +1631,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Preset save mode (Ctrl+s)
-		if msg.String() == "ctrl+s" {
+		if key.Matches(msg, m.keys.SavePreset) {
 			m.presetSaveMode = true
 			m.statusBar.SetMessage(m.styles.Label.Render("Press 1-9 or 0 to save current filter to preset slot..."), 5*time.Second)
 			return m, nil
@@ -907,7 +1686,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.statusBar.SetMessage(m.styles.Success.Render(fmt.Sprintf("Loaded preset %s: %s", slot, name)), 2*time.Second)
 					return m, tea.Batch(m.loadCasesWithFilter(m.activeFilter), m.spinner.Tick)
 				} else {
-					m.statusBar.SetMessage(m.styles.Muted.Render(fmt.Sprintf("No preset in slot %s (Ctrl+s to save)", slot)), 2*time.Second)
+					m.statusBar.SetMessage(m.styles.Muted.Render(fmt.Sprintf("No preset in slot %s (%s to save)", slot, styles.Label(m.keys.SavePreset))), 2*time.Second)
 				}
 				return m, nil
 			}
@@ -918,29 +1697,67 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.cycleSortField()
 			return m, nil
 		}
-		if msg.String() == "S" {
+		if key.Matches(msg, m.keys.SortOrder) {
 			m.toggleSortOrder()
 			return m, nil
 		}
 
+		// Show only cases with unread activity (U)
+		if key.Matches(msg, m.keys.UnreadOnly) {
+			if m.viewState == nil {
+				m.statusBar.SetMessage(m.styles.Warning.Render("Read tracking is unavailable"), 2*time.Second)
+				return m, nil
+			}
+			m.caseList.SetUnreadOnly(!m.caseList.UnreadOnly())
+			if m.caseList.UnreadOnly() {
+				m.statusBar.SetMessage("Showing unread cases only", 2*time.Second)
+			} else {
+				m.statusBar.SetMessage("Showing all cases", 2*time.Second)
+			}
+			return m, m.checkHighlightChange()
+		}
+
+		// Switch profile (P)
+		if key.Matches(msg, m.keys.Profile) {
+			if m.opts.SwitchProfile == nil {
+				m.statusBar.SetMessage(m.styles.Warning.Render("Profile switching is unavailable"), 2*time.Second)
+				return m, nil
+			}
+			names := m.configMgr.ProfileNames()
+			current := 0
+			for i, name := range names {
+				if name == m.opts.Profile {
+					current = i
+				}
+			}
+			m.modal.ShowSelect("Switch Profile", "", names, current,
+				func(name string) { m.pendingProfile = name }, nil)
+			return m, nil
+		}
+
 		// Export current case (e)
 		if key.Matches(msg, m.keys.Export) {
+			if !m.requireOnline() {
+				return m, nil
+			}
 			if c := m.caseDetail.GetCase(); c != nil {
 				m.pendingExport = "single"
 				m.exportCaseNumber = c.CaseNumber
-				defaultName := fmt.Sprintf("case-%s.md", c.CaseNumber)
+				defaultName := fmt.Sprintf("case-%s%s", c.CaseNumber, m.exportExtension())
 				cmd := m.filePicker.Show(
 					"Export Case",
-					fmt.Sprintf("Export case %s to markdown file", c.CaseNumber),
+					fmt.Sprintf("Export case %s to a file", c.CaseNumber),
 					components.FilePickerModeFile,
 					defaultName,
 					func(filename string) {
 						m.exportPath = filename
+						m.exportFormat = m.filePicker.SelectedFormat()
 					},
 					func() {
 						m.pendingExport = ""
 					},
 				)
+				m.filePicker.SetFormats(exportFormatOptions(), m.exportFormat)
 				return m, cmd
 			} else {
 				m.statusBar.SetMessage(m.styles.Warning.Render("No case selected"), 2*time.Second)
@@ -949,7 +1766,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Export all cases (E)
-		if msg.String() == "E" {
+		if key.Matches(msg, m.keys.BulkExport) {
+			if !m.requireOnline() {
+				return m, nil
+			}
 			if len(m.cases) > 0 {
 				m.pendingExport = "bulk"
 				cmd := m.filePicker.Show(
@@ -959,11 +1779,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					"./exports",
 					func(dir string) {
 						m.exportPath = dir
+						m.exportFormat = m.filePicker.SelectedFormat()
 					},
 					func() {
 						m.pendingExport = ""
 					},
 				)
+				m.filePicker.SetFormats(exportFormatOptions(), m.exportFormat)
 				return m, cmd
 			} else {
 				m.statusBar.SetMessage(m.styles.Warning.Render("No cases loaded"), 2*time.Second)
@@ -972,7 +1794,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Bundle export (B) - export to bundled markdown files
-		if msg.String() == "B" {
+		if key.Matches(msg, m.keys.Bundle) {
+			if !m.requireOnline() {
+				return m, nil
+			}
 			if len(m.cases) > 0 {
 				m.pendingExport = "bundle"
 				cmd := m.filePicker.Show(
@@ -1004,8 +1829,112 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 			return m, tea.Batch(cmds...)
 		}
+		// Close or reopen the current case (X)
+		if key.Matches(msg, m.keys.CloseCase) {
+			if !m.requireOnline() {
+				return m, nil
+			}
+			c := m.caseDetail.GetCase()
+			if c == nil {
+				m.statusBar.SetMessage(m.styles.Warning.Render("No case selected"), 2*time.Second)
+				return m, nil
+			}
+			if strings.EqualFold(c.Status, "Closed") {
+				m.confirmCaseUpdate("Reopen Case",
+					fmt.Sprintf("Reopen case %s?\n%s", c.CaseNumber, c.Summary),
+					c.CaseNumber, &api.CaseUpdate{Status: "Waiting on Red Hat"})
+			} else {
+				m.confirmCaseUpdate("Close Case",
+					fmt.Sprintf("Close case %s?\n%s", c.CaseNumber, c.Summary),
+					c.CaseNumber, &api.CaseUpdate{Status: "Closed"})
+			}
+			return m, nil
+		}
+
+		// Raise the severity of the current case by one level (+)
+		if key.Matches(msg, m.keys.BumpSev) {
+			if !m.requireOnline() {
+				return m, nil
+			}
+			c := m.caseDetail.GetCase()
+			if c == nil {
+				m.statusBar.SetMessage(m.styles.Warning.Render("No case selected"), 2*time.Second)
+				return m, nil
+			}
+			if m.caseValues == nil {
+				m.statusBar.SetMessage(m.styles.Warning.Render("Case values not loaded yet"), 2*time.Second)
+				return m, nil
+			}
+			idx := severityIndex(m.caseValues.Severities, c.Severity)
+			if idx < 0 {
+				m.statusBar.SetMessage(m.styles.Warning.Render(fmt.Sprintf("Unknown severity %q", c.Severity)), 2*time.Second)
+				return m, nil
+			}
+			if idx == 0 {
+				m.statusBar.SetMessage(m.styles.Warning.Render("Severity cannot be raised further"), 2*time.Second)
+				return m, nil
+			}
+			next := m.caseValues.Severities[idx-1]
+			m.confirmCaseUpdate("Raise Severity",
+				fmt.Sprintf("Change case %s severity\nfrom %s to %s?", c.CaseNumber, c.Severity, next),
+				c.CaseNumber, &api.CaseUpdate{Severity: next})
+			return m, nil
+		}
+
+		// Upload an attachment (only in Attachments tab)
+		if key.Matches(msg, m.keys.Upload) && m.caseDetail.ActiveTab() == 2 {
+			if !m.requireOnline() {
+				return m, nil
+			}
+			if m.uploading {
+				m.statusBar.SetMessage(m.styles.Warning.Render("An upload is already in progress"), 2*time.Second)
+				return m, nil
+			}
+			if c := m.caseDetail.GetCase(); c != nil {
+				caseNumber := c.CaseNumber
+				cmd := m.filePicker.Show(
+					"Upload Attachment",
+					fmt.Sprintf("Select a file to attach to case %s", caseNumber),
+					components.FilePickerModeOpen,
+					"",
+					func(path string) {
+						m.pendingUpload = path
+						m.uploadCase = caseNumber
+					},
+					nil,
+				)
+				return m, cmd
+			}
+			m.statusBar.SetMessage(m.styles.Warning.Render("No case selected"), 2*time.Second)
+			return m, nil
+		}
+
+		// Compose a comment (only in Comments tab)
+		if key.Matches(msg, m.keys.Comment) && m.caseDetail.ActiveTab() == 1 {
+			if !m.requireOnline() {
+				return m, nil
+			}
+			if c := m.caseDetail.GetCase(); c != nil {
+				caseNumber := c.CaseNumber
+				cmd := m.modal.ShowTextArea(
+					"Add Comment",
+					fmt.Sprintf("Public comment on case %s", caseNumber),
+					func(text string) {
+						if strings.TrimSpace(text) != "" {
+							m.pendingComment = text
+							m.commentCase = caseNumber
+						}
+					},
+					nil,
+				)
+				return m, cmd
+			}
+			m.statusBar.SetMessage(m.styles.Warning.Render("No case selected"), 2*time.Second)
+			return m, nil
+		}
+
 		// Global next/previous comment shortcuts (only in Comments tab)
-		if (msg.String() == "n" || msg.String() == "p") && m.caseDetail.ActiveTab() == 1 {
+		if (key.Matches(msg, m.keys.NextComment) || key.Matches(msg, m.keys.PrevComment)) && m.caseDetail.ActiveTab() == 1 {
 			caseDetail, cmd := m.caseDetail.Update(msg)
 			m.caseDetail = caseDetail
 			cmds = append(cmds, cmd)
@@ -1069,13 +1998,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Stop any active scrollbar drag - case count changed so drag math is invalid
 				m.listScrollDrag = false
 				// Restore cursor position first (this calls ensureVisible which may change offset)
-				if selectedCase != "" {
-					for i, c := range m.cases {
-						if c.CaseNumber == selectedCase {
-							m.caseList.SetCursor(i)
-							break
-						}
-					}
+				if i := m.caseList.IndexOf(selectedCase); i >= 0 {
+					m.caseList.SetCursor(i)
 				}
 				// Then restore scroll offset (overrides ensureVisible's changes)
 				m.caseList.SetOffset(savedOffset)
@@ -1117,6 +2041,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.caseDetail.SetCase(msg.case_)
 				m.caseDetail.SetComments(msg.comments)
 				m.caseDetail.SetAttachments(msg.attachments)
+				m.caseDetail.SetUnreadSince(m.lastViewed(msg.caseNumber))
+				cmds = append(cmds, m.markViewed(msg.caseNumber))
 			}
 			// Show errors for comments/attachments if any
 			if msg.commentsErr != nil {
@@ -1153,12 +2079,16 @@ func (m *Model) togglePane() {
 func (m *Model) startSingleExport(caseNumber, filename string) tea.Cmd {
 	m.exporting = true
 	m.modal.ShowProgress("Exporting Case", "Preparing export...")
+	format := m.exportFormat
 
 	return func() tea.Msg {
 		// Debug: log export attempt
 
 		opts := export.DefaultOptions()
 		opts.OutputFile = filename
+		if format != "" {
+			opts.Format = format
+		}
 
 		exporter, err := export.NewExporter(m.client, opts)
 		if err != nil {
@@ -1176,6 +2106,25 @@ func (m *Model) startSingleExport(caseNumber, filename string) tea.Cmd {
 	}
 }
 
+// exportFormatOptions lists the registered export formats for the file picker
+func exportFormatOptions() []components.FormatOption {
+	var opts []components.FormatOption
+	for _, f := range export.Formats() {
+		opts = append(opts, components.FormatOption{Name: f.Name, Extension: f.Extension})
+	}
+	return opts
+}
+
+// exportExtension returns the file extension of the chosen export format
+func (m *Model) exportExtension() string {
+	if m.exportFormat != "" {
+		if info, err := export.LookupFormat(m.exportFormat); err == nil {
+			return info.Extension
+		}
+	}
+	return ".md"
+}
+
 func (m *Model) startBulkExport(outputDir string) tea.Cmd {
 	m.exporting = true
 	ctx, cancel := context.WithCancel(context.Background())
@@ -1183,9 +2132,13 @@ func (m *Model) startBulkExport(outputDir string) tea.Cmd {
 	m.modal.ShowProgress("Exporting Cases", "Starting export...")
 	progressCh := make(chan export.Progress, 10)
 	m.exportProgressCh = progressCh
+	format := m.exportFormat
 	exportCmd := func() tea.Msg {
 		opts := export.DefaultOptions()
 		opts.OutputDir = outputDir
+		if format != "" {
+			opts.Format = format
+		}
 
 		exporter, err := export.NewExporter(m.client, opts)
 		if err != nil {
@@ -1335,10 +2288,7 @@ func (m *Model) maybeLoadMoreCases() tea.Cmd {
 func (m *Model) updateLayout() {
 	headerHeight := 1
 	footerHeight := 1
-	filterBarHeight := 0
-	if m.filterBar.HasActiveFilter() {
-		filterBarHeight = 2
-	}
+	filterBarHeight := m.filterBar.Height()
 	// Subtract 1 to avoid an extra padding line at the bottom
 	contentHeight := m.height - headerHeight - footerHeight - filterBarHeight - 1
 	if contentHeight < 2 {
@@ -1390,10 +2340,7 @@ func (m *Model) updateLayout() {
 
 func (m *Model) handleMouse(msg tea.MouseMsg) tea.Cmd {
 	headerHeight := 1
-	filterBarHeight := 0
-	if m.filterBar.HasActiveFilter() {
-		filterBarHeight = 2
-	}
+	filterBarHeight := m.filterBar.Height()
 	listTop := headerHeight + filterBarHeight
 	listHeaderY := listTop + 1 // Column headers row (after border)
 	detailTop := m.detailY
@@ -1437,9 +2384,9 @@ func (m *Model) handleMouse(msg tea.MouseMsg) tea.Cmd {
 
 		// Click on column headers in case list
 		if msg.Y == listHeaderY {
-			// Column positions: CASE(0-10), MODIFIED(11-23), SEV(24-28), STATUS(29-49), SUMMARY(50+)
 			x := msg.X - 1 // Account for border
-			if x >= 0 && x < 10 {
+			switch m.caseList.ColumnAt(x) {
+			case "case":
 				// CASE column - sort by case number
 				if m.sortField == SortByCaseNumber {
 					m.toggleSortOrder()
@@ -1447,7 +2394,7 @@ func (m *Model) handleMouse(msg tea.MouseMsg) tea.Cmd {
 					m.sortField = SortByCaseNumber
 					m.sortCases()
 				}
-			} else if x >= 11 && x < 24 {
+			case "modified":
 				// MODIFIED column - toggle between LastModified and Created
 				switch m.sortField {
 				case SortByLastModified:
@@ -1459,7 +2406,15 @@ func (m *Model) handleMouse(msg tea.MouseMsg) tea.Cmd {
 					m.sortField = SortByLastModified
 					m.sortCases()
 				}
-			} else if x >= 24 && x < 29 {
+			case "created", "age":
+				// CREATED and AGE columns - sort by creation date
+				if m.sortField == SortByCreated {
+					m.toggleSortOrder()
+				} else {
+					m.sortField = SortByCreated
+					m.sortCases()
+				}
+			case "severity":
 				// SEV column - sort by severity
 				if m.sortField == SortBySeverity {
 					m.toggleSortOrder()
@@ -1492,7 +2447,7 @@ func (m *Model) handleMouse(msg tea.MouseMsg) tea.Cmd {
 			rowOffset := msg.Y - listTop - 3
 			if rowOffset >= 0 {
 				clickedIdx := m.caseList.GetOffset() + rowOffset
-				if clickedIdx >= 0 && clickedIdx < len(m.cases) {
+				if clickedIdx >= 0 && clickedIdx < m.caseList.Len() {
 					m.caseList.SetCursor(clickedIdx)
 					return m.checkHighlightChange()
 				}
@@ -1561,8 +2516,8 @@ func (m *Model) handleMouse(msg tea.MouseMsg) tea.Cmd {
 			rowOffset := msg.Y - listTop - 3
 			if rowOffset >= 0 {
 				clickedIdx := m.caseList.GetOffset() + rowOffset
-				if clickedIdx >= 0 && clickedIdx < len(m.cases) {
-					caseNumber := m.cases[clickedIdx].CaseNumber
+				if cs := m.caseList.CaseAt(clickedIdx); cs != nil {
+					caseNumber := cs.CaseNumber
 					url := fmt.Sprintf("https://access.redhat.com/support/cases/#/case/%s", caseNumber)
 					return openURL(url)
 				}
@@ -1619,6 +2574,12 @@ func (m *Model) View() string {
 		versionText = " " + m.opts.Version
 	}
 	sortInfo := fmt.Sprintf(" [Sort: %s]", m.sortField.String())
+	if m.opts.Offline {
+		sortInfo += " [Offline]"
+	}
+	if m.opts.Profile != "" && m.opts.Profile != config.DefaultProfile {
+		sortInfo += " [Profile: " + m.opts.Profile + "]"
+	}
 	headerText := "agcm" + versionText + m.styles.Muted.Render(sortInfo)
 	if m.layoutDebug != "" {
 		headerText += m.styles.Muted.Render(m.layoutDebug)
@@ -1683,6 +2644,16 @@ func (m *Model) View() string {
 		}
 	}
 
+	// Column chooser overlay
+	if m.columnChooser.IsVisible() {
+		view = overlayCenter(view, m.columnChooser.View(), m.width, m.height)
+	}
+
+	// New case form overlay
+	if m.newCaseDialog.IsVisible() {
+		view = overlayCenter(view, m.newCaseDialog.View(), m.width, m.height)
+	}
+
 	// File picker overlay
 	if m.filePicker.IsVisible() {
 		view = overlayCenter(view, m.filePicker.View(), m.width, m.height)
@@ -1904,6 +2875,7 @@ func (m *Model) buildPresetFromCurrent() *config.FilterPreset {
 		preset.Severity = m.activeFilter.Severity
 		preset.Products = m.activeFilter.Products
 		preset.Keyword = m.activeFilter.Keyword
+		preset.Query = m.activeFilter.Query
 	}
 
 	// Generate a name based on content
@@ -1932,6 +2904,13 @@ func (m *Model) buildPresetFromCurrent() *config.FilterPreset {
 			parts = append(parts, fmt.Sprintf("%d Products", len(preset.Products)))
 		}
 	}
+	if preset.Query != "" {
+		q := preset.Query
+		if len(q) > 20 {
+			q = q[:20] + "..."
+		}
+		parts = append(parts, q)
+	}
 	if len(parts) > 0 {
 		preset.Name = strings.Join(parts, ", ")
 	}
@@ -1947,6 +2926,7 @@ func (m *Model) presetToFilter(preset *config.FilterPreset) *api.CaseFilter {
 		Severity: preset.Severity,
 		Products: preset.Products,
 		Keyword:  preset.Keyword,
+		Query:    preset.Query,
 		Count:    100,
 	}
 }
@@ -2012,33 +2992,45 @@ func (m *Model) renderLoadingBox() string {
 func (m *Model) renderHelp() string {
 	var sb string
 
+	k := m.keys
+	label := styles.Label
 	help := []struct {
 		key  string
 		desc string
 	}{
-		{"↑/k, ↓/j", "Navigate up/down"},
-		{"←/→", "Switch detail tabs"},
-		{"gg, G", "Go to top/bottom"},
-		{"PgUp/PgDn", "Page up/down"},
-		{"Tab", "Switch between list/detail"},
-		{"Esc", "Back to list"},
-		{"/", "Quick search by case number"},
-		{"f", "Filter dialog"},
-		{"F", "Clear filter"},
+		{label(k.Up) + ", " + label(k.Down), "Navigate up/down"},
+		{label(k.Left) + ", " + label(k.Right), "Switch detail tabs"},
+		{label(k.Top) + ", " + label(k.Bottom), "Go to top/bottom"},
+		{label(k.PageUp) + ", " + label(k.PageDown), "Page up/down"},
+		{label(k.Tab), "Switch between list/detail"},
+		{label(k.Back), "Back to list"},
+		{label(k.Search), "Quick search by case number"},
+		{label(k.Filter), "Filter dialog"},
+		{label(k.Query), "Edit filter query"},
+		{label(k.NewCase), "Open a new case"},
+		{label(k.ClearFilter), "Clear filter"},
 		{"1-9, 0", "Load filter preset"},
-		{"ctrl+s + #", "Save filter to preset slot"},
-		{"ctrl+f", "Search within case"},
-		{"n, p", "Next/prev comment (Comments tab)"},
-		{"s", "Cycle sort field"},
-		{"S", "Toggle sort order"},
-		{"r", "Refresh"},
-		{"e", "Export current case"},
-		{"E", "Export all cases"},
-		{"B", "Bundle export (4MB files)"},
+		{label(k.SavePreset) + " + #", "Save filter to preset slot"},
+		{label(k.TextSearch), "Search within case"},
+		{label(k.NextComment) + ", " + label(k.PrevComment), "Next/prev comment (Comments tab)"},
+		{label(k.Comment), "Add comment (Comments tab)"},
+		{label(k.Upload), "Upload attachment (Attachments tab)"},
+		{label(k.CloseCase), "Close/reopen case"},
+		{label(k.BumpSev), "Raise case severity"},
+		{label(k.Sort), "Cycle sort field"},
+		{label(k.SortOrder), "Toggle sort order"},
+		{label(k.UnreadOnly), "Show only unread cases"},
+		{label(k.Refresh), "Refresh"},
+		{label(k.Export), "Export current case"},
+		{label(k.BulkExport), "Export all cases"},
+		{label(k.Bundle), "Bundle export (4MB files)"},
+		{label(k.Profile), "Switch profile"},
+		{label(k.Theme), "Cycle color theme"},
+		{label(k.Columns), "Choose case list columns"},
 		{"Right-click", "Open case in browser"},
 		{"Click link", "Open URL"},
-		{"?", "Toggle help"},
-		{"q", "Quit"},
+		{label(k.Help), "Toggle help"},
+		{label(k.Quit), "Quit"},
 	}
 
 	sb = m.styles.Title.Render("Keyboard Shortcuts")
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/green/agcm/internal/api"
+	"github.com/green/agcm/internal/apitest"
+	"github.com/green/agcm/internal/config"
+)
+
+// newTestModel returns a sized model connected to a fake portal
+func newTestModel(t *testing.T) (*apitest.Fixtures, *Model) {
+	t.Helper()
+	fx := apitest.DefaultFixtures(time.Now())
+	srv := apitest.NewServer(fx)
+	t.Cleanup(srv.Close)
+
+	m := NewModel(srv.NewClient(api.WithRateLimit(0, 0)), Options{}, config.NewManager(t.TempDir()))
+	m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	return fx, m
+}
+
+// press sends a key press to the model
+func press(m *Model, s string) {
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	switch s {
+	case "down":
+		msg = tea.KeyMsg{Type: tea.KeyDown}
+	case "up":
+		msg = tea.KeyMsg{Type: tea.KeyUp}
+	}
+	m.Update(msg)
+}
+
+func TestModelLoadsCases(t *testing.T) {
+	fx, m := newTestModel(t)
+
+	// Run the initial load as the Bubble Tea runtime would
+	msg := m.loadCasesPage(0, false)()
+	loaded, ok := msg.(casesLoadedMsg)
+	if !ok {
+		t.Fatalf("loadCasesPage returned %T", msg)
+	}
+	if loaded.err != nil {
+		t.Fatal(loaded.err)
+	}
+	m.Update(loaded)
+
+	open := 0
+	var newest apitest.Case
+	for _, c := range fx.Cases {
+		if c.Status == "Closed" {
+			continue
+		}
+		open++
+		if c.LastModified.After(newest.LastModified) {
+			newest = c
+		}
+	}
+	if len(m.cases) != open || m.totalCases != open {
+		t.Fatalf("model has %d of %d cases, want %d open cases", len(m.cases), m.totalCases, open)
+	}
+
+	// Newest modified first
+	sel := m.caseList.SelectedCase()
+	if sel == nil || sel.CaseNumber != newest.CaseNumber {
+		t.Fatalf("selected case = %v, want %s", sel, newest.CaseNumber)
+	}
+	if view := m.View(); !strings.Contains(view, newest.CaseNumber) {
+		t.Errorf("view does not show case %s", newest.CaseNumber)
+	}
+
+	press(m, "j")
+	second := m.caseList.SelectedCase()
+	if second == nil || second.CaseNumber == newest.CaseNumber {
+		t.Fatalf("j did not move the cursor: selected %v", second)
+	}
+	press(m, "down")
+	press(m, "up")
+	press(m, "k")
+	if sel := m.caseList.SelectedCase(); sel == nil || sel.CaseNumber != newest.CaseNumber {
+		t.Errorf("cursor did not return to the first case: selected %v", sel)
+	}
+}
+
+func TestModelShowsLoadError(t *testing.T) {
+	_, m := newTestModel(t)
+	m.Update(casesLoadedMsg{err: errors.New("portal unavailable")})
+
+	if m.err == nil {
+		t.Fatal("load error was not recorded")
+	}
+	if view := m.View(); !strings.Contains(view, "portal unavailable") {
+		t.Error("view does not show the load error")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -37,6 +38,7 @@ type CaseDetail struct {
 	currentMatchTab  int    // Tab index of current match (0=details, 1=comments)
 	currentMatchLine int    // Line number of current match
 	maskMode         bool   // Mask sensitive text for screenshots
+	unreadSince      time.Time // Comments created after this are new
 	// matchLineOffsets maps synthetic line numbers to actual viewport lines
 	// Key format: tabIndex*1000000 + syntheticLineNumber
 	matchLineOffsets map[int]int
@@ -74,6 +76,37 @@ func (c *CaseDetail) SetComments(comments []api.Comment) {
 	c.updateContent()
 }
 
+// SetUnreadSince marks comments created after t as new and, on the
+// Comments tab, scrolls to the oldest of them
+func (c *CaseDetail) SetUnreadSince(t time.Time) {
+	c.unreadSince = t
+	c.updateContent()
+	if c.activeTab == 1 {
+		c.scrollToFirstUnread()
+	}
+}
+
+// firstUnread returns the index of the oldest new comment, or -1
+func (c *CaseDetail) firstUnread() int {
+	if c.unreadSince.IsZero() {
+		return -1
+	}
+	// Comments are newest first
+	for i := len(c.comments) - 1; i >= 0; i-- {
+		if c.comments[i].CreatedDate.After(c.unreadSince) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *CaseDetail) scrollToFirstUnread() {
+	if i := c.firstUnread(); i >= 0 && i < len(c.commentOffsets) {
+		c.currentComment = i
+		c.viewport.SetYOffset(c.commentOffsets[i])
+	}
+}
+
 // SetAttachments updates the attachments
 func (c *CaseDetail) SetAttachments(attachments []api.Attachment) {
 	c.attachments = attachments
@@ -110,6 +143,9 @@ func (c *CaseDetail) SetActiveTab(tab int) {
 		c.activeTab = tab
 		c.updateContent()
 		c.viewport.GotoTop()
+		if tab == 1 {
+			c.scrollToFirstUnread()
+		}
 	}
 }
 
@@ -382,7 +418,12 @@ func (c *CaseDetail) renderComments() string {
 			visStyle = c.styles.Muted
 		}
 
-		sb.WriteString(fmt.Sprintf("%s %s • %s • %s\n", numStr, author, date, visStyle.Render(visibility)))
+		newMark := ""
+		if !c.unreadSince.IsZero() && comment.CreatedDate.After(c.unreadSince) {
+			newMark = " • " + c.styles.Warning.Render("New")
+		}
+
+		sb.WriteString(fmt.Sprintf("%s %s • %s • %s%s\n", numStr, author, date, visStyle.Render(visibility), newMark))
 		lineCount++
 
 		// Comment text (with highlighting) - synthetic line number = i*100 + j in Comments tab
@@ -460,7 +501,7 @@ func (c *CaseDetail) renderAttachments() string {
 
 		size := "n/a"
 		if attSize, ok := attachmentSize(att); ok {
-			size = formatSize(attSize)
+			size = api.FormatSize(attSize)
 		}
 		date := att.CreatedDate.Format("2006-01-02")
 
@@ -586,21 +627,17 @@ func (c *CaseDetail) Update(msg tea.Msg) (*CaseDetail, tea.Cmd) {
 		switch {
 		case key.Matches(msg, c.keys.Left):
 			if c.activeTab > 0 {
-				c.activeTab--
-				c.updateContent()
-				c.viewport.GotoTop()
+				c.SetActiveTab(c.activeTab - 1)
 			}
 		case key.Matches(msg, c.keys.Right):
 			if c.activeTab < 2 {
-				c.activeTab++
-				c.updateContent()
-				c.viewport.GotoTop()
+				c.SetActiveTab(c.activeTab + 1)
 			}
 		case key.Matches(msg, c.keys.Top):
 			c.viewport.GotoTop()
 		case key.Matches(msg, c.keys.Bottom):
 			c.viewport.GotoBottom()
-		case msg.String() == "n":
+		case key.Matches(msg, c.keys.NextComment):
 			// Next comment (relative to current scroll position)
 			if c.activeTab == 1 && len(c.commentOffsets) > 0 {
 				y := c.viewport.YOffset
@@ -619,7 +656,7 @@ func (c *CaseDetail) Update(msg tea.Msg) (*CaseDetail, tea.Cmd) {
 				c.currentComment = nextIdx
 				c.viewport.SetYOffset(c.commentOffsets[c.currentComment])
 			}
-		case msg.String() == "p":
+		case key.Matches(msg, c.keys.PrevComment):
 			// Previous comment (relative to current scroll position)
 			if c.activeTab == 1 && len(c.commentOffsets) > 0 {
 				y := c.viewport.YOffset
@@ -839,19 +876,6 @@ func ansiCut(s string, width int) string {
 	return result.String()
 }
 
-func formatSize(bytes int64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
-	}
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
-}
-
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -5,6 +5,8 @@ package components
 import (
 	"fmt"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -25,7 +27,8 @@ const (
 
 // CaseList is a component for displaying a list of cases
 type CaseList struct {
-	cases       []api.Case
+	all         []api.Case // Every loaded case
+	cases       []api.Case // Cases shown (all, or unread only)
 	cursor      int
 	offset      int
 	styles      *styles.Styles
@@ -38,6 +41,9 @@ type CaseList struct {
 	maskMode    bool
 	debugInfo   string
 	totalCount  int
+	unread      map[string]int // Unread cases and their new comment counts
+	unreadOnly  bool
+	columns     []api.Column
 }
 
 // SetMaskMode enables/disables text masking for privacy
@@ -80,19 +86,90 @@ func stripNonPrintable(s string) string {
 
 // NewCaseList creates a new case list component
 func NewCaseList(s *styles.Styles, keys *styles.KeyMap) *CaseList {
+	columns, _ := api.ParseColumns(api.DefaultListColumns)
 	return &CaseList{
-		styles: s,
-		keys:   keys,
+		styles:  s,
+		keys:    keys,
+		columns: columns,
 	}
 }
 
+// SetColumns sets the columns shown, in order
+func (c *CaseList) SetColumns(columns []api.Column) {
+	c.columns = columns
+}
+
+// Columns returns the columns shown
+func (c *CaseList) Columns() []api.Column {
+	return c.columns
+}
+
+// ColumnWidths returns the width each column is drawn at
+func (c *CaseList) ColumnWidths() []int {
+	return api.LayoutColumns(c.columns, c.contentWidth(), columnGap)
+}
+
 // SetCases updates the list with new cases
 func (c *CaseList) SetCases(cases []api.Case) {
-	c.cases = cases
+	c.all = cases
+	c.applyView()
 	c.cursor = 0
 	c.offset = 0
 }
 
+// applyView selects which loaded cases are shown
+func (c *CaseList) applyView() {
+	if !c.unreadOnly {
+		c.cases = c.all
+		return
+	}
+	c.cases = nil
+	for _, cs := range c.all {
+		if _, ok := c.unread[cs.CaseNumber]; ok {
+			c.cases = append(c.cases, cs)
+		}
+	}
+}
+
+// refreshView reapplies the view, keeping the selected case if still shown
+func (c *CaseList) refreshView() {
+	selected := ""
+	if sel := c.SelectedCase(); sel != nil {
+		selected = sel.CaseNumber
+	}
+	c.applyView()
+	if i := c.IndexOf(selected); i >= 0 {
+		c.SetCursor(i)
+	} else {
+		c.cursor = max(min(c.cursor, len(c.cases)-1), 0)
+		c.ensureVisible()
+	}
+	c.SetOffset(c.offset)
+}
+
+// Len returns the number of cases shown
+func (c *CaseList) Len() int {
+	return len(c.cases)
+}
+
+// CaseAt returns the shown case at index i
+func (c *CaseList) CaseAt(i int) *api.Case {
+	if i >= 0 && i < len(c.cases) {
+		return &c.cases[i]
+	}
+	return nil
+}
+
+// IndexOf returns the index of a shown case, or -1
+func (c *CaseList) IndexOf(caseNumber string) int {
+	for i := range c.cases {
+		if c.cases[i].CaseNumber == caseNumber {
+			return i
+		}
+	}
+	return -1
+}
+
 // SelectedCase returns the currently selected case
 func (c *CaseList) SelectedCase() *api.Case {
 	if c.cursor >= 0 && c.cursor < len(c.cases) {
@@ -128,6 +205,25 @@ func (c *CaseList) SetTotalCount(total int) {
 	c.totalCount = total
 }
 
+// SetUnread sets the cases with unread activity and their new comment counts
+func (c *CaseList) SetUnread(unread map[string]int) {
+	c.unread = unread
+	if c.unreadOnly {
+		c.refreshView()
+	}
+}
+
+// SetUnreadOnly shows only cases with unread activity
+func (c *CaseList) SetUnreadOnly(on bool) {
+	c.unreadOnly = on
+	c.refreshView()
+}
+
+// UnreadOnly reports whether only unread cases are shown
+func (c *CaseList) UnreadOnly() bool {
+	return c.unreadOnly
+}
+
 // SetDebugInfo sets a debug string to show in the separator line when enabled.
 func (c *CaseList) SetDebugInfo(info string) {
 	c.debugInfo = info
@@ -259,6 +355,11 @@ func (c *CaseList) VisibleRows() int {
 	return c.visibleRows()
 }
 
+// contentWidth is the width available to rows, less border and scrollbar
+func (c *CaseList) contentWidth() int {
+	return max(c.width-5, 20)
+}
+
 // ensureVisible adjusts offset to keep cursor visible
 func (c *CaseList) ensureVisible() {
 	visible := c.visibleRows()
@@ -294,13 +395,13 @@ func (c *CaseList) Update(msg tea.Msg) (*CaseList, tea.Cmd) {
 		case key.Matches(msg, c.keys.Bottom):
 			c.cursor = len(c.cases) - 1
 			c.ensureVisible()
-		case msg.String() == "pgup":
+		case key.Matches(msg, c.keys.PageUp):
 			c.cursor -= c.visibleRows()
 			if c.cursor < 0 {
 				c.cursor = 0
 			}
 			c.ensureVisible()
-		case msg.String() == "pgdown":
+		case key.Matches(msg, c.keys.PageDown):
 			c.cursor += c.visibleRows()
 			if c.cursor >= len(c.cases) {
 				c.cursor = len(c.cases) - 1
@@ -319,11 +420,7 @@ func (c *CaseList) View() string {
 		style = c.styles.Focused
 	}
 
-	// Calculate available width for content (minus border + scrollbar)
-	contentWidth := c.width - 5
-	if contentWidth < 20 {
-		contentWidth = 20
-	}
+	contentWidth := c.contentWidth()
 
 	// Build header
 	header := c.renderHeader(contentWidth)
@@ -335,7 +432,11 @@ func (c *CaseList) View() string {
 
 	visible := c.visibleRows()
 	if len(c.cases) == 0 {
-		rows = append(rows, c.styles.Muted.Render("  No cases loaded"))
+		if c.unreadOnly {
+			rows = append(rows, c.styles.Muted.Render("  No unread cases (U to show all)"))
+		} else {
+			rows = append(rows, c.styles.Muted.Render("  No cases loaded"))
+		}
 	} else {
 		for i := c.offset; i < len(c.cases) && i < c.offset+visible; i++ {
 			selected := i == c.cursor
@@ -381,13 +482,36 @@ func (c *CaseList) View() string {
 		Render(content)
 }
 
-// Column widths (fixed)
-const (
-	colCase   = 10
-	colDate   = 12
-	colStatus = 20
-	colSev    = 4
-)
+// columnGap is the space between case list columns
+const columnGap = 1
+
+// sortColumn names the column the list is sorted by
+func (c *CaseList) sortColumn() string {
+	switch c.sortField {
+	case SortByCaseNumber:
+		return "case"
+	case SortByCreated:
+		for _, name := range []string{"created", "age"} {
+			if c.hasColumn(name) {
+				return name
+			}
+		}
+		return "modified"
+	case SortBySeverity:
+		return "severity"
+	}
+	return "modified"
+}
+
+// hasColumn reports whether a column is shown
+func (c *CaseList) hasColumn(name string) bool {
+	for _, col := range c.columns {
+		if col.Name == name {
+			return true
+		}
+	}
+	return false
+}
 
 func (c *CaseList) renderHeader(width int) string {
 	// Sort arrow
@@ -396,36 +520,23 @@ func (c *CaseList) renderHeader(width int) string {
 		arrow = "▼"
 	}
 
-	// Build each column with proper padding (pad first, then style)
-	var caseHdr, modHdr, sevHdr, statusHdr string
-
-	switch c.sortField {
-	case SortByCaseNumber:
-		caseHdr = c.styles.HelpKey.Render(padRight("CASE"+arrow, colCase))
-		modHdr = c.styles.Label.Render(padRight("MODIFIED", colDate))
-		sevHdr = c.styles.Label.Render(padRight("SEV", colSev))
-	case SortByLastModified:
-		caseHdr = c.styles.Label.Render(padRight("CASE", colCase))
-		modHdr = c.styles.HelpKey.Render(padRight("MODIFIED"+arrow, colDate))
-		sevHdr = c.styles.Label.Render(padRight("SEV", colSev))
-	case SortByCreated:
-		caseHdr = c.styles.Label.Render(padRight("CASE", colCase))
-		modHdr = c.styles.HelpKey.Render(padRight("CREATED"+arrow, colDate))
-		sevHdr = c.styles.Label.Render(padRight("SEV", colSev))
-	case SortBySeverity:
-		caseHdr = c.styles.Label.Render(padRight("CASE", colCase))
-		modHdr = c.styles.Label.Render(padRight("MODIFIED", colDate))
-		sevHdr = c.styles.HelpKey.Render(padRight("SEV"+arrow, colSev))
-	default:
-		caseHdr = c.styles.Label.Render(padRight("CASE", colCase))
-		modHdr = c.styles.Label.Render(padRight("MODIFIED", colDate))
-		sevHdr = c.styles.Label.Render(padRight("SEV", colSev))
+	sorted := c.sortColumn()
+	widths := api.LayoutColumns(c.columns, width, columnGap)
+	cells := make([]string, len(c.columns))
+	for i, col := range c.columns {
+		title := col.Title
+		// Without a CREATED column, MODIFIED stands in for it
+		if col.Name == "modified" && c.sortField == SortByCreated && sorted == "modified" {
+			title = "CREATED"
+		}
+		// Pad first, then style
+		if col.Name == sorted {
+			cells[i] = c.styles.HelpKey.Render(padRight(api.TruncateColumn(title+arrow, widths[i]), widths[i]))
+		} else {
+			cells[i] = c.styles.Label.Render(padRight(api.TruncateColumn(title, widths[i]), widths[i]))
+		}
 	}
-
-	statusHdr = c.styles.Label.Render(padRight("STATUS", colStatus))
-	summaryHdr := c.styles.Label.Render("SUMMARY")
-
-	return caseHdr + " " + modHdr + " " + sevHdr + " " + statusHdr + " " + summaryHdr
+	return strings.Join(cells, strings.Repeat(" ", columnGap))
 }
 
 func padRight(s string, width int) string {
@@ -547,55 +658,76 @@ func (c *CaseList) renderScrollbar(height int) string {
 }
 
 func (c *CaseList) renderRow(cs *api.Case, width int, selected bool) string {
-	// Format date
-	date := cs.LastModified.Format("Jan 02 2006")
-
-	// Extract severity number
-	sev := cs.Severity
-	if len(sev) > 0 {
-		sev = string(sev[0])
-	}
-
-	// Truncate status if needed
-	status := cs.Status
-	if len(status) > colStatus {
-		status = status[:colStatus-1] + "…"
-	}
-
-	// Calculate remaining width for summary
-	descWidth := width - colCase - colDate - colStatus - colSev - 4
-	if descWidth < 10 {
-		descWidth = 10
-	}
-
-	summary := cs.Summary
-	// Strip control characters and non-printable chars to prevent rendering issues
-	summary = stripNonPrintable(summary)
-	if c.maskMode {
-		summary = maskText(summary)
-	}
-	if len(summary) > descWidth {
-		summary = summary[:descWidth-1] + "…"
+	now := time.Now()
+	widths := api.LayoutColumns(c.columns, width, columnGap)
+
+	// Mark cases with activity since they were last viewed
+	newComments, unread := c.unread[cs.CaseNumber]
+	newTag := ""
+	if newComments > 0 {
+		newTag = fmt.Sprintf("[%d new] ", newComments)
+	}
+
+	plain := make([]string, len(c.columns))
+	styled := make([]string, len(c.columns))
+	for i, col := range c.columns {
+		w := widths[i]
+		value := col.Value(cs, now)
+		switch col.Name {
+		case "case":
+			if unread {
+				plain[i] = padRight(api.TruncateColumn(value+" ●", w), w)
+				styled[i] = c.styles.CaseNumber.Render(api.TruncateColumn(value, w)) +
+					c.styles.Warning.Render(padRight(api.TruncateColumn(" ●", w-len(value)), w-len(value)))
+			} else {
+				plain[i] = padRight(api.TruncateColumn(value, w), w)
+				styled[i] = c.styles.CaseNumber.Render(plain[i])
+			}
+		case "severity":
+			plain[i] = padRight(api.TruncateColumn(value, w), w)
+			styled[i] = c.styles.SeverityStyle(cs.Severity).Render(plain[i])
+		case "status":
+			plain[i] = padRight(api.TruncateColumn(value, w), w)
+			styled[i] = c.styles.StatusStyle(cs.Status).Render(plain[i])
+		case "summary":
+			// Strip control characters and non-printable chars to prevent rendering issues
+			value = stripNonPrintable(value)
+			if c.maskMode {
+				value = maskText(value)
+			}
+			tag := api.TruncateColumn(newTag, w)
+			text := padRight(api.TruncateColumn(value, w-utf8.RuneCountInString(tag)), w-utf8.RuneCountInString(tag))
+			plain[i] = tag + text
+			styled[i] = text
+			if tag != "" {
+				styled[i] = c.styles.Warning.Render(tag) + text
+			}
+		default:
+			value = stripNonPrintable(value)
+			if c.maskMode && (col.Name == "owner" || col.Name == "contact" || col.Name == "account") {
+				value = maskText(value)
+			}
+			plain[i] = padRight(api.TruncateColumn(value, w), w)
+			styled[i] = plain[i]
+		}
 	}
 
-	// Build the row
-	row := fmt.Sprintf("%-*s %-*s %-*s %-*s %s",
-		colCase, cs.CaseNumber,
-		colDate, date,
-		colSev, sev,
-		colStatus, status,
-		summary,
-	)
-
+	gap := strings.Repeat(" ", columnGap)
 	if selected {
-		return c.styles.ListItemSelected.Width(width).Render(row)
+		return c.styles.ListItemSelected.Width(width).Render(strings.Join(plain, gap))
 	}
+	return strings.Join(styled, gap)
+}
 
-	// Apply severity color to the severity column only
-	caseNum := c.styles.CaseNumber.Render(fmt.Sprintf("%-*s", colCase, cs.CaseNumber))
-	dateStr := fmt.Sprintf("%-*s", colDate, date)
-	sevStr := c.styles.SeverityStyle(cs.Severity).Render(fmt.Sprintf("%-*s", colSev, sev))
-	statusStr := c.styles.StatusStyle(cs.Status).Render(fmt.Sprintf("%-*s", colStatus, status))
-
-	return fmt.Sprintf("%s %s %s %s %s", caseNum, dateStr, sevStr, statusStr, summary)
+// ColumnAt returns the name of the column at x cells into the list, or ""
+func (c *CaseList) ColumnAt(x int) string {
+	widths := api.LayoutColumns(c.columns, c.contentWidth(), columnGap)
+	left := 0
+	for i, col := range c.columns {
+		if x >= left && x < left+widths[i] {
+			return col.Name
+		}
+		left += widths[i] + columnGap
+	}
+	return ""
 }
@@ -0,0 +1,206 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/green/agcm/internal/api"
+	"github.com/green/agcm/internal/tui/styles"
+)
+
+// ColumnsChangedMsg is sent when the user applies a new column layout
+type ColumnsChangedMsg struct {
+	Columns []api.Column
+}
+
+// chooserColumn is a column in the chooser and whether it is shown
+type chooserColumn struct {
+	col   api.Column
+	shown bool
+	width int // Width drawn at, for resizing shared-width columns
+}
+
+// ColumnChooser is a modal for picking, ordering and resizing the case list
+// columns
+type ColumnChooser struct {
+	styles  *styles.Styles
+	items   []chooserColumn
+	cursor  int
+	err     string
+	visible bool
+}
+
+// NewColumnChooser creates a new column chooser
+func NewColumnChooser(s *styles.Styles) *ColumnChooser {
+	return &ColumnChooser{styles: s}
+}
+
+// Show opens the chooser on the current columns and the widths they are
+// drawn at; the hidden columns follow
+func (cc *ColumnChooser) Show(columns []api.Column, widths []int) {
+	cc.items = nil
+	for i, col := range columns {
+		cc.items = append(cc.items, chooserColumn{col: col, shown: true, width: widths[i]})
+	}
+	for _, col := range api.CaseColumns() {
+		if !cc.has(col.Name) {
+			cc.items = append(cc.items, chooserColumn{col: col, width: max(col.Width, col.Min)})
+		}
+	}
+	cc.cursor = 0
+	cc.err = ""
+	cc.visible = true
+}
+
+// has reports whether a column is in the chooser
+func (cc *ColumnChooser) has(name string) bool {
+	for _, item := range cc.items {
+		if item.col.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Hide closes the chooser
+func (cc *ColumnChooser) Hide() {
+	cc.visible = false
+}
+
+// IsVisible returns whether the chooser is shown
+func (cc *ColumnChooser) IsVisible() bool {
+	return cc.visible
+}
+
+// columns returns the shown columns in order
+func (cc *ColumnChooser) columns() []api.Column {
+	var cols []api.Column
+	for _, item := range cc.items {
+		if item.shown {
+			cols = append(cols, item.col)
+		}
+	}
+	return cols
+}
+
+// resize changes the width of the column under the cursor, fixing a
+// shared-width column at its current size first
+func (cc *ColumnChooser) resize(delta int) {
+	item := &cc.items[cc.cursor]
+	if item.col.Width == 0 {
+		item.col.Width = item.width
+	}
+	item.col.Width = max(item.col.Width+delta, 1)
+	item.width = item.col.Width
+}
+
+// move swaps the column under the cursor with its neighbour
+func (cc *ColumnChooser) move(delta int) {
+	to := cc.cursor + delta
+	if to < 0 || to >= len(cc.items) {
+		return
+	}
+	cc.items[cc.cursor], cc.items[to] = cc.items[to], cc.items[cc.cursor]
+	cc.cursor = to
+}
+
+// Update handles input
+func (cc *ColumnChooser) Update(msg tea.Msg) (*ColumnChooser, tea.Cmd) {
+	if !cc.visible {
+		return cc, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return cc, nil
+	}
+	cc.err = ""
+	switch keyMsg.String() {
+	case "up", "k":
+		if cc.cursor > 0 {
+			cc.cursor--
+		}
+	case "down", "j":
+		if cc.cursor < len(cc.items)-1 {
+			cc.cursor++
+		}
+	case "K", "shift+up":
+		cc.move(-1)
+	case "J", "shift+down":
+		cc.move(1)
+	case " ", "x":
+		cc.items[cc.cursor].shown = !cc.items[cc.cursor].shown
+	case "<", "-", "left", "h":
+		cc.resize(-1)
+	case ">", "+", "right", "l":
+		cc.resize(1)
+	case "=":
+		def, _ := api.LookupColumn(cc.items[cc.cursor].col.Name)
+		cc.items[cc.cursor].col = def
+		cc.items[cc.cursor].width = max(def.Width, def.Min)
+	case "enter":
+		cols := cc.columns()
+		if len(cols) == 0 {
+			cc.err = "Show at least one column"
+			return cc, nil
+		}
+		cc.Hide()
+		return cc, func() tea.Msg {
+			return ColumnsChangedMsg{Columns: cols}
+		}
+	case "esc":
+		cc.Hide()
+	}
+	return cc, nil
+}
+
+// View renders the chooser
+func (cc *ColumnChooser) View() string {
+	if !cc.visible {
+		return ""
+	}
+
+	var content strings.Builder
+	content.WriteString(cc.styles.Title.Render("Case List Columns"))
+	content.WriteString("\n\n")
+
+	for i, item := range cc.items {
+		box := "[ ]"
+		if item.shown {
+			box = "[x]"
+		}
+		width := "auto"
+		if item.col.Width > 0 {
+			width = fmt.Sprintf("%d", item.col.Width)
+		}
+		line := fmt.Sprintf("%s %-10s %5s", box, item.col.Name, width)
+		if i == cc.cursor {
+			content.WriteString(cc.styles.HelpKey.Render("> " + line))
+		} else if item.shown {
+			content.WriteString("  " + line)
+		} else {
+			content.WriteString(cc.styles.Muted.Render("  " + line))
+		}
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	if cc.err != "" {
+		content.WriteString(cc.styles.Error.Render(cc.err))
+		content.WriteString("\n")
+	}
+	content.WriteString(cc.styles.Muted.Render("Space: show/hide • J/K: move • </>: width\n=: reset width • Enter: apply • Esc: cancel"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(cc.styles.Header.GetBackground()).
+		Background(cc.styles.Colors.Surface).
+		Padding(1, 3).
+		Width(50)
+
+	return boxStyle.Render(content.String())
+}
@@ -15,13 +15,13 @@ import (
 
 
 // formatFilePickerOutput post-processes filepicker output to add full-width backgrounds
-func formatFilePickerOutput(output string, width int) string {
+func formatFilePickerOutput(output string, width int, cursorColor lipgloss.Color) string {
 	lines := strings.Split(output, "\n")
 	if len(lines) == 0 {
 		return output
 	}
 
-	cursorBg := lipgloss.NewStyle().Background(lipgloss.Color("238"))
+	cursorBg := lipgloss.NewStyle().Background(cursorColor)
 
 	var result []string
 	for _, line := range lines {
@@ -58,8 +58,15 @@ type FilePickerMode int
 const (
 	FilePickerModeFile FilePickerMode = iota // Select/create a file
 	FilePickerModeDir                        // Select a directory
+	FilePickerModeOpen                       // Select an existing file of any type
 )
 
+// FormatOption is an output format offered by the file picker
+type FormatOption struct {
+	Name      string
+	Extension string
+}
+
 // FilePickerDialog is a modal file picker dialog
 type FilePickerDialog struct {
 	styles       *styles.Styles
@@ -75,6 +82,8 @@ type FilePickerDialog struct {
 	selectedPath string
 	onConfirm    func(string)
 	onCancel     func()
+	formats      []FormatOption // Optional format choices, cycled with ctrl+t
+	formatIdx    int
 }
 
 // NewFilePickerDialog creates a new file picker dialog
@@ -86,24 +95,10 @@ func NewFilePickerDialog(s *styles.Styles) *FilePickerDialog {
 	fp.ShowSize = true
 	fp.SetHeight(15)
 
-	// Style the filepicker for dark background
-	fp.Styles.Cursor = lipgloss.NewStyle().Foreground(lipgloss.Color("212")) // Pink cursor
-	fp.Styles.Symlink = lipgloss.NewStyle().Foreground(lipgloss.Color("36")) // Cyan
-	fp.Styles.Directory = lipgloss.NewStyle().Foreground(lipgloss.Color("33")).Bold(true) // Blue, bold
-	fp.Styles.File = lipgloss.NewStyle().Foreground(lipgloss.Color("252")) // Light gray
-	fp.Styles.Permission = lipgloss.NewStyle().Foreground(lipgloss.Color("244")) // Gray
-	fp.Styles.Selected = lipgloss.NewStyle().Foreground(lipgloss.Color("212")).Bold(true) // Pink, bold
-	fp.Styles.FileSize = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Width(7).Align(lipgloss.Right) // Dim gray, right-aligned
-	fp.Styles.EmptyDirectory = lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Italic(true)
-
 	ti := textinput.New()
 	ti.Placeholder = "Enter path..."
 	ti.CharLimit = 256
 	ti.Width = 50
-	ti.PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("33"))       // Blue prompt
-	ti.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("255"))        // White text
-	ti.PlaceholderStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240")) // Dim placeholder
-	ti.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("212"))     // Pink cursor
 
 	return &FilePickerDialog{
 		styles:     s,
@@ -120,13 +115,22 @@ func (f *FilePickerDialog) Show(title, message string, mode FilePickerMode, defa
 	f.visible = true
 	f.onConfirm = onConfirm
 	f.onCancel = onCancel
+	f.formats = nil
+	f.applyTheme()
 
 	// Configure filepicker based on mode
-	if mode == FilePickerModeDir {
+	switch mode {
+	case FilePickerModeDir:
 		f.filepicker.DirAllowed = true
 		f.filepicker.FileAllowed = false
 		f.showInput = false // Start in directory browser mode
-	} else {
+	case FilePickerModeOpen:
+		f.filepicker.DirAllowed = false // Directories are entered, never selected
+		f.filepicker.FileAllowed = true
+		f.filepicker.AllowedTypes = nil
+		f.showInput = false // Start in browser mode to pick an existing file
+		f.textInput.Blur()
+	default:
 		f.filepicker.DirAllowed = true // Allow entering directories to navigate
 		f.filepicker.FileAllowed = true
 		f.filepicker.AllowedTypes = []string{".md"}
@@ -155,6 +159,58 @@ func (f *FilePickerDialog) Show(title, message string, mode FilePickerMode, defa
 	return f.filepicker.Init()
 }
 
+// applyTheme colors the file list and path input from the current theme
+func (f *FilePickerDialog) applyTheme() {
+	c := f.styles.Colors
+	f.filepicker.Styles.Cursor = lipgloss.NewStyle().Foreground(c.Accent)
+	f.filepicker.Styles.Symlink = lipgloss.NewStyle().Foreground(c.Secondary)
+	f.filepicker.Styles.Directory = lipgloss.NewStyle().Foreground(c.Highlight).Bold(true)
+	f.filepicker.Styles.File = lipgloss.NewStyle().Foreground(c.Foreground)
+	f.filepicker.Styles.Permission = lipgloss.NewStyle().Foreground(c.Muted)
+	f.filepicker.Styles.Selected = lipgloss.NewStyle().Foreground(c.Accent).Bold(true)
+	f.filepicker.Styles.FileSize = lipgloss.NewStyle().Foreground(c.Muted).Width(7).Align(lipgloss.Right)
+	f.filepicker.Styles.EmptyDirectory = lipgloss.NewStyle().Foreground(c.Muted).Italic(true)
+
+	f.textInput.PromptStyle = lipgloss.NewStyle().Foreground(c.Highlight)
+	f.textInput.TextStyle = lipgloss.NewStyle().Foreground(c.Foreground)
+	f.textInput.PlaceholderStyle = lipgloss.NewStyle().Foreground(c.Muted)
+	f.textInput.Cursor.Style = lipgloss.NewStyle().Foreground(c.Accent)
+}
+
+// SetFormats offers a choice of output formats, starting with selected.
+// In file mode the path's extension follows the chosen format.
+func (f *FilePickerDialog) SetFormats(formats []FormatOption, selected string) {
+	f.formats = formats
+	f.formatIdx = 0
+	for i, opt := range formats {
+		if opt.Name == selected {
+			f.formatIdx = i
+		}
+	}
+	f.applyFormat("")
+}
+
+// SelectedFormat returns the chosen format name, or "" if none were offered
+func (f *FilePickerDialog) SelectedFormat() string {
+	if len(f.formats) == 0 {
+		return ""
+	}
+	return f.formats[f.formatIdx].Name
+}
+
+// applyFormat updates the file filter and path extension for the current format
+func (f *FilePickerDialog) applyFormat(oldExt string) {
+	if len(f.formats) == 0 || f.mode != FilePickerModeFile {
+		return
+	}
+	ext := f.formats[f.formatIdx].Extension
+	f.filepicker.AllowedTypes = []string{ext}
+	if value := f.textInput.Value(); oldExt != "" && strings.HasSuffix(value, oldExt) {
+		f.textInput.SetValue(strings.TrimSuffix(value, oldExt) + ext)
+		f.textInput.CursorEnd()
+	}
+}
+
 // Hide hides the dialog
 func (f *FilePickerDialog) Hide() {
 	f.visible = false
@@ -200,6 +256,14 @@ func (f *FilePickerDialog) Update(msg tea.Msg) (*FilePickerDialog, tea.Cmd) {
 			}
 			return f, nil
 
+		case "ctrl+t":
+			if len(f.formats) > 0 {
+				oldExt := f.formats[f.formatIdx].Extension
+				f.formatIdx = (f.formatIdx + 1) % len(f.formats)
+				f.applyFormat(oldExt)
+			}
+			return f, nil
+
 		case "esc":
 			if f.onCancel != nil {
 				f.onCancel()
@@ -258,6 +322,14 @@ func (f *FilePickerDialog) Update(msg tea.Msg) (*FilePickerDialog, tea.Cmd) {
 	return f, tea.Batch(cmds...)
 }
 
+// formatHelp returns the help suffix for the format selector
+func (f *FilePickerDialog) formatHelp() string {
+	if len(f.formats) == 0 {
+		return ""
+	}
+	return " • Ctrl+T format"
+}
+
 // View renders the dialog
 func (f *FilePickerDialog) View() string {
 	if !f.visible {
@@ -265,30 +337,46 @@ func (f *FilePickerDialog) View() string {
 	}
 
 	var content strings.Builder
+	c := f.styles.Colors
 
-	// Title - bright white for visibility
-	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255")).Bold(true)
+	// Title
+	titleStyle := lipgloss.NewStyle().Foreground(c.Foreground).Bold(true)
 	content.WriteString(titleStyle.Render(f.title))
 	content.WriteString("\n")
 
-	// Message - light gray
+	// Message
 	if f.message != "" {
-		msgStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("250"))
+		msgStyle := lipgloss.NewStyle().Foreground(c.Muted)
 		content.WriteString(msgStyle.Render(f.message))
 		content.WriteString("\n")
 	}
 
-	// Current directory - use explicit light styling for dark background
-	dirStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("33")).Bold(true) // Blue
+	// Format choices
+	if len(f.formats) > 0 {
+		content.WriteString("\n")
+		content.WriteString(lipgloss.NewStyle().Foreground(c.Foreground).Bold(true).Render("Format: "))
+		var names []string
+		for i, opt := range f.formats {
+			if i == f.formatIdx {
+				names = append(names, lipgloss.NewStyle().Foreground(c.Accent).Bold(true).Render("["+opt.Name+"]"))
+			} else {
+				names = append(names, lipgloss.NewStyle().Foreground(c.Muted).Render(opt.Name))
+			}
+		}
+		content.WriteString(strings.Join(names, " "))
+		content.WriteString("\n\n")
+	}
+
+	// Current directory
+	dirStyle := lipgloss.NewStyle().Foreground(c.Highlight).Bold(true)
 	content.WriteString(dirStyle.Render("📁 " + f.filepicker.CurrentDirectory))
 	content.WriteString("\n")
-	separatorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")) // Dim gray
+	separatorStyle := lipgloss.NewStyle().Foreground(c.Border)
 	content.WriteString(separatorStyle.Render(strings.Repeat("─", 50)))
 	content.WriteString("\n")
 
-	// Style definitions for dark background
-	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252")).Bold(true)
-	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Italic(true)
+	labelStyle := lipgloss.NewStyle().Foreground(c.Foreground).Bold(true)
+	helpStyle := lipgloss.NewStyle().Foreground(c.Muted).Italic(true)
 
 	if f.showInput {
 		// Text input mode
@@ -296,10 +384,10 @@ func (f *FilePickerDialog) View() string {
 		content.WriteString(labelStyle.Render("Path: "))
 		content.WriteString(f.textInput.View())
 		content.WriteString("\n\n")
-		content.WriteString(helpStyle.Render("Enter to confirm • Tab to browse • Esc to cancel"))
+		content.WriteString(helpStyle.Render("Enter to confirm • Tab to browse • Esc to cancel" + f.formatHelp()))
 	} else {
 		// File picker mode - format output for alignment and full-width backgrounds
-		fpOutput := formatFilePickerOutput(f.filepicker.View(), min(56, f.width-8))
+		fpOutput := formatFilePickerOutput(f.filepicker.View(), min(56, f.width-8), c.Border)
 		content.WriteString(fpOutput)
 		content.WriteString("\n")
 
@@ -310,15 +398,15 @@ func (f *FilePickerDialog) View() string {
 		} else {
 			helpText = "Enter to select file • Tab to type path • Esc to cancel"
 		}
-		content.WriteString(helpStyle.Render(helpText))
+		content.WriteString(helpStyle.Render(helpText + f.formatHelp()))
 	}
 
-	// Modal box style with dark background for better contrast
+	// Modal box style on the theme's dialog background
 	boxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("33")). // Blue border
-		Background(lipgloss.Color("235")).      // Dark gray background
-		Foreground(lipgloss.Color("252")).      // Light gray text
+		BorderForeground(c.Highlight).
+		Background(c.Surface).
+		Foreground(c.Foreground).
 		Padding(1, 2).
 		Width(min(60, f.width-4))
 
@@ -3,31 +3,77 @@
 package components
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/green/agcm/internal/api"
 	"github.com/green/agcm/internal/tui/styles"
 )
 
-// FilterBar shows active filters as pills
+// FilterQueryMsg is sent when a query typed in the filter bar parses
+type FilterQueryMsg struct {
+	Query string
+}
+
+// FilterBar shows active filters as pills, and edits the filter query
 type FilterBar struct {
-	styles      *styles.Styles
-	width       int
-	filter      *api.CaseFilter
-	caseCount   int
-	totalCount  int
-	presetSlot  string
-	presetName  string
+	styles     *styles.Styles
+	width      int
+	filter     *api.CaseFilter
+	caseCount  int
+	totalCount int
+	presetSlot string
+	presetName string
+	keys       *styles.KeyMap
+
+	queryInput textinput.Model
+	editing    bool
+	queryErr   error
 }
 
 // NewFilterBar creates a new filter bar
-func NewFilterBar(s *styles.Styles) *FilterBar {
+func NewFilterBar(s *styles.Styles, keys *styles.KeyMap) *FilterBar {
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.Placeholder = `e.g. severity:1,2 modified:>7d -status:closed "kernel panic"`
+	ti.CharLimit = 500
+
 	return &FilterBar{
-		styles: s,
+		styles:     s,
+		keys:       keys,
+		queryInput: ti,
+	}
+}
+
+// EditQuery starts editing the filter query
+func (f *FilterBar) EditQuery() tea.Cmd {
+	f.editing = true
+	f.queryErr = nil
+	query := ""
+	if f.filter != nil {
+		query = f.filter.Query
+	}
+	f.queryInput.SetValue(query)
+	f.queryInput.CursorEnd()
+	f.queryInput.Focus()
+	return textinput.Blink
+}
+
+// IsEditing returns whether the query is being edited
+func (f *FilterBar) IsEditing() bool {
+	return f.editing
+}
+
+// Height returns the number of lines the bar takes, 0 when hidden
+func (f *FilterBar) Height() int {
+	if !f.HasActiveFilter() {
+		return 0
 	}
+	return lipgloss.Height(strings.TrimRight(f.View(), "\n"))
 }
 
 // SetFilter updates the active filter
@@ -65,7 +111,7 @@ func (f *FilterBar) SetWidth(width int) {
 
 // HasActiveFilter returns true if there's an active filter or preset
 func (f *FilterBar) HasActiveFilter() bool {
-	if f.presetSlot != "" {
+	if f.presetSlot != "" || f.editing {
 		return true
 	}
 	if f.filter == nil {
@@ -77,18 +123,79 @@ func (f *FilterBar) HasActiveFilter() bool {
 		len(f.filter.Products) > 0 ||
 		f.filter.Keyword != "" ||
 		len(f.filter.Status) > 0 ||
-		len(f.filter.Severity) > 0
+		len(f.filter.Severity) > 0 ||
+		f.filter.Query != ""
 }
 
-// Update handles input (not much to do for display-only component)
+// Update handles query input while editing
 func (f *FilterBar) Update(msg tea.Msg) (*FilterBar, tea.Cmd) {
-	return f, nil
+	if !f.editing {
+		return f, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "enter":
+			query := strings.TrimSpace(f.queryInput.Value())
+			if _, err := api.ParseQuery(query); err != nil {
+				f.queryErr = err
+				return f, nil
+			}
+			f.stopEditing()
+			return f, func() tea.Msg {
+				return FilterQueryMsg{Query: query}
+			}
+		case "esc":
+			f.stopEditing()
+			return f, nil
+		}
+	}
+
+	before := f.queryInput.Value()
+	var cmd tea.Cmd
+	f.queryInput, cmd = f.queryInput.Update(msg)
+	if f.queryInput.Value() != before {
+		f.queryErr = nil
+	}
+	return f, cmd
+}
+
+func (f *FilterBar) stopEditing() {
+	f.editing = false
+	f.queryErr = nil
+	f.queryInput.Blur()
+}
+
+// viewQueryError shows the query with the malformed part highlighted
+func (f *FilterBar) viewQueryError() string {
+	msg := f.queryErr.Error()
+	var qerr *api.QueryError
+	if !errors.As(f.queryErr, &qerr) {
+		return f.styles.Error.Render(msg)
+	}
+
+	query := f.queryInput.Value()
+	start, end := min(qerr.Pos, len(query)), min(qerr.End, len(query))
+	bad := query[start:end]
+	if bad == "" {
+		bad = " "
+	}
+	highlight := lipgloss.NewStyle().
+		Background(f.styles.Colors.Error).
+		Foreground(f.styles.Colors.Contrast)
+
+	msg = qerr.Msg
+	if limit := f.width - 4; limit > 3 && len(msg) > limit {
+		msg = msg[:limit-3] + "..."
+	}
+	return query[:start] + highlight.Render(bad) + query[end:] + "\n" +
+		f.styles.Error.Render(msg)
 }
 
 func (f *FilterBar) renderPill(label, value string) string {
 	pillStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color("240")).
-		Foreground(lipgloss.Color("255")).
+		Background(f.styles.Colors.Border).
+		Foreground(f.styles.Colors.Foreground).
 		Padding(0, 1)
 
 	return pillStyle.Render(label + ": " + value)
@@ -100,13 +207,28 @@ func (f *FilterBar) View() string {
 		return ""
 	}
 
+	barStyle := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder(), false, false, true, false).
+		BorderForeground(f.styles.Colors.Border).
+		Width(f.width).
+		Padding(0, 1)
+
+	if f.editing {
+		f.queryInput.Width = max(f.width-10, 10)
+		content := f.styles.Label.Render("Query: ") + f.queryInput.View()
+		if f.queryErr != nil {
+			content += "\n" + f.viewQueryError()
+		}
+		return barStyle.Render(content)
+	}
+
 	var pills []string
 
 	// Preset indicator - when preset is active, only show preset (not individual filters)
 	if f.presetSlot != "" {
 		presetStyle := lipgloss.NewStyle().
-			Background(lipgloss.Color("33")).
-			Foreground(lipgloss.Color("255")).
+			Background(f.styles.Colors.Highlight).
+			Foreground(f.styles.Colors.Contrast).
 			Bold(true).
 			Padding(0, 1)
 		presetLabel := fmt.Sprintf("[%s]", f.presetSlot)
@@ -180,6 +302,15 @@ func (f *FilterBar) View() string {
 			}
 			pills = append(pills, f.renderPill("Keyword", kw))
 		}
+
+		// Query
+		if f.filter != nil && f.filter.Query != "" {
+			q := f.filter.Query
+			if len(q) > 40 {
+				q = q[:40] + "..."
+			}
+			pills = append(pills, f.renderPill("Query", q))
+		}
 	}
 
 	// Join pills
@@ -194,17 +325,11 @@ func (f *FilterBar) View() string {
 	}
 
 	// Clear hint
-	clearHint := f.styles.Muted.Render("  [f to edit, F to clear]")
+	clearHint := f.styles.Muted.Render(fmt.Sprintf("  [%s to edit, %s for query, %s to clear]",
+		styles.Label(f.keys.Filter), styles.Label(f.keys.Query), styles.Label(f.keys.ClearFilter)))
 
 	// Build bar
 	content := f.styles.Label.Render("Filters: ") + pillsStr + countStr + clearHint
 
-	// Style the bar
-	barStyle := lipgloss.NewStyle().
-		Border(lipgloss.NormalBorder(), false, false, true, false).
-		BorderForeground(lipgloss.Color("240")).
-		Width(f.width).
-		Padding(0, 1)
-
 	return barStyle.Render(content)
 }
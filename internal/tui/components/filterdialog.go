@@ -59,6 +59,7 @@ type FilterDialog struct {
 	productsLoading  bool
 	productsError    string
 	selectedProducts []string // Tag-based multi-product selection
+	query            string   // Filter bar query, kept across edits
 
 	// Checkbox states
 	statusOpen        bool
@@ -129,7 +130,10 @@ func (f *FilterDialog) ShowWithFilter(filter *api.CaseFilter) tea.Cmd {
 	f.focusedField = fieldAccounts
 	f.accountsInput.Focus()
 
+	f.query = ""
 	if filter != nil {
+		f.query = filter.Query
+
 		// Accounts
 		if len(filter.Accounts) > 0 {
 			f.accountsInput.SetValue(strings.Join(filter.Accounts, ", "))
@@ -241,6 +245,7 @@ func (f *FilterDialog) SetSize(width, height int) {
 func (f *FilterDialog) buildFilter() *api.CaseFilter {
 	filter := &api.CaseFilter{
 		Count: 100,
+		Query: f.query,
 	}
 
 	// Accounts
@@ -674,11 +679,11 @@ func (f *FilterDialog) renderButton(label string, focused bool) string {
 	if focused {
 		return lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("33")).
+			Foreground(f.styles.Colors.Highlight).
 			Render("[ " + label + " ]")
 	}
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color("245")).
+		Foreground(f.styles.Colors.Muted).
 		Render("  " + label + "  ")
 }
 
@@ -688,8 +693,8 @@ func (f *FilterDialog) renderProductTags() string {
 	}
 
 	tagStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color("62")).
-		Foreground(lipgloss.Color("255")).
+		Background(f.styles.Colors.Primary).
+		Foreground(f.styles.Colors.Contrast).
 		Padding(0, 1)
 
 	var tags []string
@@ -708,8 +713,8 @@ func (f *FilterDialog) renderProductTags() string {
 // renderProductDropdownBox renders the product dropdown as a separate bordered box
 func (f *FilterDialog) renderProductDropdownBox() string {
 	var content strings.Builder
-	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
-	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("33"))
+	helpStyle := lipgloss.NewStyle().Foreground(f.styles.Colors.Muted)
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(f.styles.Colors.Highlight)
 
 	content.WriteString(titleStyle.Render("Select Product"))
 	content.WriteString("\n\n")
@@ -753,7 +758,7 @@ func (f *FilterDialog) renderProductDropdownBox() string {
 	// Box style for dropdown
 	boxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("62")).
+		BorderForeground(f.styles.Colors.Primary).
 		Padding(1, 2).
 		Width(58)
 
@@ -782,7 +787,7 @@ func (f *FilterDialog) View() string {
 	var content strings.Builder
 
 	// Title
-	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("33"))
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(f.styles.Colors.Highlight)
 	content.WriteString(titleStyle.Render("Filter Cases"))
 	content.WriteString("\n\n")
 
@@ -856,13 +861,13 @@ func (f *FilterDialog) View() string {
 	content.WriteString("\n\n")
 
 	// Help text
-	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	helpStyle := lipgloss.NewStyle().Foreground(f.styles.Colors.Muted)
 	content.WriteString(helpStyle.Render("Tab/↑↓: Navigate  Space: Toggle  Enter: Select  Esc: Cancel"))
 
 	// Modal box style - no background, just border
 	boxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("33")).
+		BorderForeground(f.styles.Colors.Highlight).
 		Padding(1, 2).
 		Width(60)
 
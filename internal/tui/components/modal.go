@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -19,6 +20,9 @@ const (
 	ModalNone ModalType = iota
 	ModalTextInput
 	ModalProgress
+	ModalTextArea
+	ModalConfirm
+	ModalSelect
 )
 
 // Modal is a dialog component
@@ -28,8 +32,11 @@ type Modal struct {
 	title       string
 	message     string
 	textInput   textinput.Model
+	textArea    textarea.Model
 	progress    float64
 	progressMsg string
+	options     []string
+	selected    int
 	width       int
 	height      int
 	visible     bool
@@ -44,9 +51,16 @@ func NewModal(s *styles.Styles) *Modal {
 	ti.CharLimit = 256
 	ti.Width = 40
 
+	ta := textarea.New()
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 32000
+	ta.SetWidth(60)
+	ta.SetHeight(10)
+
 	return &Modal{
 		styles:    s,
 		textInput: ti,
+		textArea:  ta,
 	}
 }
 
@@ -62,12 +76,51 @@ func (m *Modal) ShowTextInput(title, message, defaultValue string, onConfirm fun
 	m.visible = true
 }
 
+// ShowTextArea shows a multi-line text input modal
+func (m *Modal) ShowTextArea(title, message string, onConfirm func(string), onCancel func()) tea.Cmd {
+	m.modalType = ModalTextArea
+	m.title = title
+	m.message = message
+	m.textArea.Reset()
+	m.onConfirm = onConfirm
+	m.onCancel = onCancel
+	m.visible = true
+	return m.textArea.Focus()
+}
+
+// ShowConfirm shows a yes/no confirmation modal
+func (m *Modal) ShowConfirm(title, message string, onConfirm func(), onCancel func()) {
+	m.modalType = ModalConfirm
+	m.title = title
+	m.message = message
+	m.onConfirm = func(string) {
+		if onConfirm != nil {
+			onConfirm()
+		}
+	}
+	m.onCancel = onCancel
+	m.visible = true
+}
+
+// ShowSelect shows a list to pick one option from
+func (m *Modal) ShowSelect(title, message string, options []string, selected int, onConfirm func(string), onCancel func()) {
+	m.modalType = ModalSelect
+	m.title = title
+	m.message = message
+	m.options = options
+	m.selected = max(0, min(selected, len(options)-1))
+	m.onConfirm = onConfirm
+	m.onCancel = onCancel
+	m.visible = true
+}
+
 // ShowProgress shows a progress modal
 func (m *Modal) ShowProgress(title, message string) {
 	m.modalType = ModalProgress
 	m.title = title
 	m.progressMsg = message
 	m.progress = 0
+	m.onCancel = nil
 	m.visible = true
 }
 
@@ -82,6 +135,7 @@ func (m *Modal) Hide() {
 	m.visible = false
 	m.modalType = ModalNone
 	m.textInput.Blur()
+	m.textArea.Blur()
 }
 
 // IsVisible returns whether modal is visible
@@ -99,6 +153,8 @@ func (m *Modal) SetSize(width, height int) {
 	m.width = width
 	m.height = height
 	m.textInput.Width = min(40, width-20)
+	m.textArea.SetWidth(max(20, min(70, width-20)))
+	m.textArea.SetHeight(max(3, min(12, height-16)))
 }
 
 // Update handles input
@@ -129,6 +185,65 @@ func (m *Modal) Update(msg tea.Msg) (*Modal, tea.Cmd) {
 			m.textInput, cmd = m.textInput.Update(msg)
 			return m, cmd
 
+		case ModalTextArea:
+			switch msg.String() {
+			case "ctrl+s":
+				if m.onConfirm != nil {
+					m.onConfirm(m.textArea.Value())
+				}
+				m.Hide()
+				return m, nil
+			case "esc":
+				if m.onCancel != nil {
+					m.onCancel()
+				}
+				m.Hide()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.textArea, cmd = m.textArea.Update(msg)
+			return m, cmd
+
+		case ModalConfirm:
+			switch msg.String() {
+			case "y", "Y", "enter":
+				if m.onConfirm != nil {
+					m.onConfirm("")
+				}
+				m.Hide()
+				return m, nil
+			case "n", "N", "esc":
+				if m.onCancel != nil {
+					m.onCancel()
+				}
+				m.Hide()
+				return m, nil
+			}
+
+		case ModalSelect:
+			switch msg.String() {
+			case "up", "k":
+				if m.selected > 0 {
+					m.selected--
+				}
+			case "down", "j":
+				if m.selected < len(m.options)-1 {
+					m.selected++
+				}
+			case "enter":
+				if m.onConfirm != nil && len(m.options) > 0 {
+					m.onConfirm(m.options[m.selected])
+				}
+				m.Hide()
+				return m, nil
+			case "esc":
+				if m.onCancel != nil {
+					m.onCancel()
+				}
+				m.Hide()
+				return m, nil
+			}
+
 		case ModalProgress:
 			if msg.String() == "esc" {
 				if m.onCancel != nil {
@@ -165,6 +280,36 @@ func (m *Modal) View() string {
 		content.WriteString("\n\n")
 		content.WriteString(m.styles.Muted.Render("Enter to confirm • Esc to cancel"))
 
+	case ModalTextArea:
+		if m.message != "" {
+			content.WriteString(m.message)
+			content.WriteString("\n\n")
+		}
+		content.WriteString(m.textArea.View())
+		content.WriteString("\n\n")
+		content.WriteString(m.styles.Muted.Render("Ctrl+S to send • Esc to cancel"))
+
+	case ModalConfirm:
+		content.WriteString(m.message)
+		content.WriteString("\n\n")
+		content.WriteString(m.styles.Muted.Render("y/Enter to confirm • n/Esc to cancel"))
+
+	case ModalSelect:
+		if m.message != "" {
+			content.WriteString(m.message)
+			content.WriteString("\n\n")
+		}
+		for i, opt := range m.options {
+			if i == m.selected {
+				content.WriteString(m.styles.HelpKey.Render("> " + opt))
+			} else {
+				content.WriteString("  " + opt)
+			}
+			content.WriteString("\n")
+		}
+		content.WriteString("\n")
+		content.WriteString(m.styles.Muted.Render("↑/↓ to choose • Enter to select • Esc to cancel"))
+
 	case ModalProgress:
 		content.WriteString(m.progressMsg)
 		content.WriteString("\n\n")
@@ -177,9 +322,12 @@ func (m *Modal) View() string {
 	boxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(m.styles.Header.GetBackground()).
-		Background(lipgloss.Color("248")).
+		Background(m.styles.Colors.Surface).
 		Padding(1, 3).
 		Width(50)
+	if m.modalType == ModalTextArea {
+		boxStyle = boxStyle.Width(m.textArea.Width() + 8)
+	}
 
 	return boxStyle.Render(content.String())
 }
@@ -0,0 +1,619 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/green/agcm/internal/api"
+	"github.com/green/agcm/internal/tui/styles"
+)
+
+// NewCaseSubmitMsg is sent when the new case form is submitted
+type NewCaseSubmitMsg struct {
+	Request *api.CreateCaseRequest
+}
+
+// NewCaseCancelMsg is sent when the new case form is cancelled
+type NewCaseCancelMsg struct{}
+
+// NewCaseProductSelectedMsg is sent when a product without known versions is chosen
+type NewCaseProductSelectedMsg struct {
+	Product string
+}
+
+// Field indices for new case form navigation
+const (
+	ncFieldSummary = iota
+	ncFieldProduct
+	ncFieldVersion
+	ncFieldSeverity
+	ncFieldType
+	ncFieldAccount
+	ncFieldGroup
+	ncFieldDescription
+	ncFieldSubmit
+	ncFieldCancel
+	ncFieldCount
+)
+
+// maxChoiceRows is how many product/version matches are shown below a field
+const maxChoiceRows = 5
+
+// NewCaseDialog is a multi-field form for opening a new support case
+type NewCaseDialog struct {
+	styles *styles.Styles
+	width  int
+	height int
+
+	// Form fields
+	summaryInput textinput.Model
+	productInput textinput.Model
+	versionInput textinput.Model
+	accountInput textinput.Model
+	groupInput   textinput.Model
+	description  textarea.Model
+
+	// Product choices (from ListProducts)
+	products        []api.Product
+	productMatches  []string
+	productCursor   int
+	productsLoading bool
+	productsError   string
+	selectedProduct string
+
+	// Version choices (from the product or GetProductVersions)
+	versions        []string
+	versionMatches  []string
+	versionCursor   int
+	versionsLoading bool
+
+	// Severity and type selectors (from GetCaseValues)
+	severities []string
+	severity   int
+	types      []string
+	caseType   int
+
+	errMsg       string
+	focusedField int
+	visible      bool
+}
+
+// NewNewCaseDialog creates a new case form
+func NewNewCaseDialog(s *styles.Styles) *NewCaseDialog {
+	newInput := func(placeholder string, limit int) textinput.Model {
+		ti := textinput.New()
+		ti.Placeholder = placeholder
+		ti.CharLimit = limit
+		ti.Width = 40
+		ti.Prompt = ""
+		return ti
+	}
+
+	ta := textarea.New()
+	ta.Placeholder = "Describe the problem, when it started, and what you have tried"
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 32000
+	ta.SetWidth(54)
+	ta.SetHeight(6)
+
+	return &NewCaseDialog{
+		styles:       s,
+		summaryInput: newInput("one-line problem statement", 255),
+		productInput: newInput("type to search products", 100),
+		versionInput: newInput("type to search versions", 50),
+		accountInput: newInput("account number", 20),
+		groupInput:   newInput("case group number (optional)", 20),
+		description:  ta,
+		severities:   []string{"1 (Urgent)", "2 (High)", "3 (Normal)", "4 (Low)"},
+		severity:     2,
+	}
+}
+
+// Show displays the form, prefilled with the given account and group
+func (n *NewCaseDialog) Show(account, group string) tea.Cmd {
+	n.visible = true
+	n.errMsg = ""
+	n.summaryInput.SetValue("")
+	n.productInput.SetValue("")
+	n.versionInput.SetValue("")
+	n.accountInput.SetValue(account)
+	n.groupInput.SetValue(group)
+	n.description.Reset()
+	n.selectedProduct = ""
+	n.versions = nil
+	n.versionsLoading = false
+	n.caseType = 0
+	if len(n.severities) > 2 {
+		n.severity = 2 // Normal
+	}
+	n.focusedField = ncFieldSummary
+	n.focusField(n.focusedField)
+	n.updateProductMatches()
+	n.updateVersionMatches()
+	return textinput.Blink
+}
+
+// Hide hides the form
+func (n *NewCaseDialog) Hide() {
+	n.visible = false
+	n.blurAll()
+}
+
+// IsVisible returns whether the form is visible
+func (n *NewCaseDialog) IsVisible() bool {
+	return n.visible
+}
+
+// SetSize sets the screen size
+func (n *NewCaseDialog) SetSize(width, height int) {
+	n.width = width
+	n.height = height
+	descHeight := 6
+	if height < 40 {
+		descHeight = max(3, height-34)
+	}
+	n.description.SetHeight(descHeight)
+}
+
+// SetProducts sets the available products
+func (n *NewCaseDialog) SetProducts(products []api.Product) {
+	n.products = products
+	n.productsLoading = false
+	n.productsError = ""
+	n.updateProductMatches()
+}
+
+// SetProductsLoading flags the product list as loading
+func (n *NewCaseDialog) SetProductsLoading() {
+	n.productsLoading = true
+	n.productsError = ""
+}
+
+// SetProductsError records a product load error to show in the form
+func (n *NewCaseDialog) SetProductsError(msg string) {
+	n.productsLoading = false
+	n.productsError = msg
+}
+
+// SetVersions sets the versions for a product, ignoring stale results
+func (n *NewCaseDialog) SetVersions(product string, versions []string) {
+	if product != n.selectedProduct {
+		return
+	}
+	n.versions = versions
+	n.versionsLoading = false
+	n.updateVersionMatches()
+}
+
+// SetCaseValues sets the allowed severities and case types
+func (n *NewCaseDialog) SetCaseValues(values *api.CaseValues) {
+	if values == nil {
+		return
+	}
+	if len(values.Severities) > 0 {
+		n.severities = values.Severities
+		if n.severity >= len(n.severities) {
+			n.severity = len(n.severities) - 1
+		}
+	}
+	n.types = values.Types
+	if n.caseType >= len(n.types) {
+		n.caseType = 0
+	}
+}
+
+func (n *NewCaseDialog) blurAll() {
+	n.summaryInput.Blur()
+	n.productInput.Blur()
+	n.versionInput.Blur()
+	n.accountInput.Blur()
+	n.groupInput.Blur()
+	n.description.Blur()
+}
+
+func (n *NewCaseDialog) focusField(field int) {
+	n.blurAll()
+
+	switch field {
+	case ncFieldSummary:
+		n.summaryInput.Focus()
+	case ncFieldProduct:
+		n.productInput.Focus()
+		n.updateProductMatches()
+	case ncFieldVersion:
+		n.versionInput.Focus()
+		n.updateVersionMatches()
+	case ncFieldAccount:
+		n.accountInput.Focus()
+	case ncFieldGroup:
+		n.groupInput.Focus()
+	case ncFieldDescription:
+		n.description.Focus()
+	}
+}
+
+func (n *NewCaseDialog) moveFocus(delta int) {
+	n.focusedField = (n.focusedField + delta + ncFieldCount) % ncFieldCount
+	n.focusField(n.focusedField)
+}
+
+func (n *NewCaseDialog) updateProductMatches() {
+	query := strings.ToLower(strings.TrimSpace(n.productInput.Value()))
+	n.productMatches = n.productMatches[:0]
+	for _, p := range n.products {
+		if query == "" || strings.Contains(strings.ToLower(p.Name), query) {
+			n.productMatches = append(n.productMatches, p.Name)
+		}
+	}
+	if n.productCursor >= len(n.productMatches) || n.productCursor < 0 {
+		n.productCursor = 0
+	}
+}
+
+func (n *NewCaseDialog) updateVersionMatches() {
+	query := strings.ToLower(strings.TrimSpace(n.versionInput.Value()))
+	n.versionMatches = n.versionMatches[:0]
+	for _, v := range n.versions {
+		if query == "" || strings.Contains(strings.ToLower(v), query) {
+			n.versionMatches = append(n.versionMatches, v)
+		}
+	}
+	if n.versionCursor >= len(n.versionMatches) || n.versionCursor < 0 {
+		n.versionCursor = 0
+	}
+}
+
+// showProductChoices reports whether the product match list should be shown
+func (n *NewCaseDialog) showProductChoices() bool {
+	return n.focusedField == ncFieldProduct && n.productInput.Value() != n.selectedProduct
+}
+
+// showVersionChoices reports whether the version match list should be shown
+func (n *NewCaseDialog) showVersionChoices() bool {
+	return n.focusedField == ncFieldVersion && len(n.versionMatches) > 0 &&
+		!(len(n.versionMatches) == 1 && n.versionMatches[0] == n.versionInput.Value())
+}
+
+// selectProduct records the chosen product and resolves its versions
+func (n *NewCaseDialog) selectProduct(name string) tea.Cmd {
+	n.selectedProduct = name
+	n.productInput.SetValue(name)
+	n.versionInput.SetValue("")
+	n.versions = nil
+	n.updateVersionMatches()
+
+	for _, p := range n.products {
+		if p.Name == name && len(p.Versions) > 0 {
+			n.versions = p.Versions
+			n.updateVersionMatches()
+			return nil
+		}
+	}
+
+	n.versionsLoading = true
+	return func() tea.Msg { return NewCaseProductSelectedMsg{Product: name} }
+}
+
+// buildRequest validates the form and creates the API request
+func (n *NewCaseDialog) buildRequest() (*api.CreateCaseRequest, error) {
+	req := &api.CreateCaseRequest{
+		Summary:       strings.TrimSpace(n.summaryInput.Value()),
+		Description:   strings.TrimSpace(n.description.Value()),
+		Product:       strings.TrimSpace(n.productInput.Value()),
+		Version:       strings.TrimSpace(n.versionInput.Value()),
+		AccountNumber: strings.TrimSpace(n.accountInput.Value()),
+		GroupNumber:   strings.TrimSpace(n.groupInput.Value()),
+	}
+	if n.severity >= 0 && n.severity < len(n.severities) {
+		req.Severity = n.severities[n.severity]
+	}
+	if n.caseType >= 0 && n.caseType < len(n.types) {
+		req.Type = n.types[n.caseType]
+	}
+
+	switch {
+	case req.Summary == "":
+		return nil, fmt.Errorf("summary is required")
+	case req.Product == "":
+		return nil, fmt.Errorf("product is required")
+	case req.Version == "":
+		return nil, fmt.Errorf("version is required")
+	case req.Description == "":
+		return nil, fmt.Errorf("description is required")
+	}
+	return req, nil
+}
+
+func (n *NewCaseDialog) submit() tea.Cmd {
+	req, err := n.buildRequest()
+	if err != nil {
+		n.errMsg = err.Error()
+		return nil
+	}
+	n.Hide()
+	return func() tea.Msg { return NewCaseSubmitMsg{Request: req} }
+}
+
+func (n *NewCaseDialog) cancel() tea.Cmd {
+	n.Hide()
+	return func() tea.Msg { return NewCaseCancelMsg{} }
+}
+
+// Update handles input
+func (n *NewCaseDialog) Update(msg tea.Msg) (*NewCaseDialog, tea.Cmd) {
+	if !n.visible {
+		return n, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		// Forward blink and other internal messages to the focused input
+		return n, n.updateFocusedInput(msg)
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		return n, n.cancel()
+
+	case "ctrl+s":
+		return n, n.submit()
+
+	case "tab":
+		n.moveFocus(1)
+		return n, nil
+
+	case "shift+tab":
+		n.moveFocus(-1)
+		return n, nil
+
+	case "down":
+		switch {
+		case n.showProductChoices() && len(n.productMatches) > 0:
+			n.productCursor = min(n.productCursor+1, len(n.productMatches)-1)
+			return n, nil
+		case n.showVersionChoices():
+			n.versionCursor = min(n.versionCursor+1, len(n.versionMatches)-1)
+			return n, nil
+		case n.focusedField == ncFieldDescription:
+			// Let the textarea move its cursor
+		default:
+			n.moveFocus(1)
+			return n, nil
+		}
+
+	case "up":
+		switch {
+		case n.showProductChoices() && len(n.productMatches) > 0:
+			n.productCursor = max(n.productCursor-1, 0)
+			return n, nil
+		case n.showVersionChoices():
+			n.versionCursor = max(n.versionCursor-1, 0)
+			return n, nil
+		case n.focusedField == ncFieldDescription:
+			// Let the textarea move its cursor
+		default:
+			n.moveFocus(-1)
+			return n, nil
+		}
+
+	case "left", "right", " ":
+		if n.focusedField == ncFieldSeverity || n.focusedField == ncFieldType {
+			delta := 1
+			if keyMsg.String() == "left" {
+				delta = -1
+			}
+			if n.focusedField == ncFieldSeverity && len(n.severities) > 0 {
+				n.severity = (n.severity + delta + len(n.severities)) % len(n.severities)
+			}
+			if n.focusedField == ncFieldType && len(n.types) > 0 {
+				n.caseType = (n.caseType + delta + len(n.types)) % len(n.types)
+			}
+			return n, nil
+		}
+
+	case "enter":
+		switch n.focusedField {
+		case ncFieldProduct:
+			if n.showProductChoices() && len(n.productMatches) > 0 {
+				cmd := n.selectProduct(n.productMatches[n.productCursor])
+				n.moveFocus(1)
+				return n, cmd
+			}
+			n.moveFocus(1)
+			return n, nil
+		case ncFieldVersion:
+			if n.showVersionChoices() {
+				n.versionInput.SetValue(n.versionMatches[n.versionCursor])
+				n.updateVersionMatches()
+			}
+			n.moveFocus(1)
+			return n, nil
+		case ncFieldDescription:
+			// Newline in the description
+		case ncFieldSubmit:
+			return n, n.submit()
+		case ncFieldCancel:
+			return n, n.cancel()
+		default:
+			n.moveFocus(1)
+			return n, nil
+		}
+	}
+
+	n.errMsg = ""
+	return n, n.updateFocusedInput(msg)
+}
+
+// updateFocusedInput forwards a message to the focused text input
+func (n *NewCaseDialog) updateFocusedInput(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	switch n.focusedField {
+	case ncFieldSummary:
+		n.summaryInput, cmd = n.summaryInput.Update(msg)
+	case ncFieldProduct:
+		n.productInput, cmd = n.productInput.Update(msg)
+		n.updateProductMatches()
+	case ncFieldVersion:
+		n.versionInput, cmd = n.versionInput.Update(msg)
+		n.updateVersionMatches()
+	case ncFieldAccount:
+		n.accountInput, cmd = n.accountInput.Update(msg)
+	case ncFieldGroup:
+		n.groupInput, cmd = n.groupInput.Update(msg)
+	case ncFieldDescription:
+		n.description, cmd = n.description.Update(msg)
+	}
+	return cmd
+}
+
+func (n *NewCaseDialog) fieldPrefix(field int) string {
+	if n.focusedField == field {
+		return "> "
+	}
+	return "  "
+}
+
+// renderChoices renders a short scrolling list of matches below a field
+func (n *NewCaseDialog) renderChoices(matches []string, cursor int) string {
+	helpStyle := lipgloss.NewStyle().Foreground(n.styles.Colors.Muted)
+	if len(matches) == 0 {
+		return "            " + helpStyle.Render("No matches") + "\n"
+	}
+
+	start := 0
+	if cursor >= maxChoiceRows {
+		start = cursor - maxChoiceRows + 1
+	}
+	end := min(start+maxChoiceRows, len(matches))
+
+	var sb strings.Builder
+	for i := start; i < end; i++ {
+		line := truncateSimpleFD(matches[i], 42)
+		if i == cursor {
+			line = n.styles.Selected.Render("> " + line)
+		} else {
+			line = "  " + line
+		}
+		sb.WriteString("          " + line + "\n")
+	}
+	sb.WriteString("            " + helpStyle.Render(fmt.Sprintf("(%d/%d) ↑↓ choose, Enter select", cursor+1, len(matches))) + "\n")
+	return sb.String()
+}
+
+// renderSelector renders a left/right selector for a list of values
+func (n *NewCaseDialog) renderSelector(values []string, selected int, focused bool) string {
+	if len(values) == 0 {
+		return n.styles.Muted.Render("(none)")
+	}
+	var parts []string
+	for i, v := range values {
+		if i == selected {
+			if focused {
+				parts = append(parts, n.styles.Selected.Render(" "+v+" "))
+			} else {
+				parts = append(parts, n.styles.HelpKey.Render(" "+v+" "))
+			}
+		} else {
+			parts = append(parts, n.styles.Muted.Render(" "+v+" "))
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// View renders the form
+func (n *NewCaseDialog) View() string {
+	if !n.visible {
+		return ""
+	}
+
+	var content strings.Builder
+	helpStyle := lipgloss.NewStyle().Foreground(n.styles.Colors.Muted)
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(n.styles.Colors.Highlight)
+	content.WriteString(titleStyle.Render("Open New Case"))
+	content.WriteString("\n\n")
+
+	content.WriteString(fmt.Sprintf("%sSummary:  %s\n\n", n.fieldPrefix(ncFieldSummary), n.summaryInput.View()))
+
+	// Product with inline choices
+	content.WriteString(fmt.Sprintf("%sProduct:  %s\n", n.fieldPrefix(ncFieldProduct), n.productInput.View()))
+	if n.focusedField == ncFieldProduct {
+		switch {
+		case n.productsLoading:
+			content.WriteString("            " + helpStyle.Render("Loading products...") + "\n")
+		case n.productsError != "":
+			content.WriteString("            " + helpStyle.Render("Failed to load products: "+n.productsError) + "\n")
+		case n.showProductChoices():
+			content.WriteString(n.renderChoices(n.productMatches, n.productCursor))
+		}
+	}
+	content.WriteString("\n")
+
+	// Version with inline choices
+	content.WriteString(fmt.Sprintf("%sVersion:  %s\n", n.fieldPrefix(ncFieldVersion), n.versionInput.View()))
+	if n.focusedField == ncFieldVersion {
+		switch {
+		case n.versionsLoading:
+			content.WriteString("            " + helpStyle.Render("Loading versions...") + "\n")
+		case n.selectedProduct == "":
+			content.WriteString("            " + helpStyle.Render("Select a product first") + "\n")
+		case n.showVersionChoices():
+			content.WriteString(n.renderChoices(n.versionMatches, n.versionCursor))
+		}
+	}
+	content.WriteString("\n")
+
+	content.WriteString(fmt.Sprintf("%sSeverity: %s\n\n", n.fieldPrefix(ncFieldSeverity),
+		n.renderSelector(n.severities, n.severity, n.focusedField == ncFieldSeverity)))
+	content.WriteString(fmt.Sprintf("%sType:     %s\n\n", n.fieldPrefix(ncFieldType),
+		n.renderSelector(n.types, n.caseType, n.focusedField == ncFieldType)))
+
+	content.WriteString(fmt.Sprintf("%sAccount:  %s\n", n.fieldPrefix(ncFieldAccount), n.accountInput.View()))
+	content.WriteString(fmt.Sprintf("%sGroup:    %s\n\n", n.fieldPrefix(ncFieldGroup), n.groupInput.View()))
+
+	content.WriteString(fmt.Sprintf("%sDescription:\n", n.fieldPrefix(ncFieldDescription)))
+	content.WriteString(n.description.View())
+	content.WriteString("\n\n")
+
+	content.WriteString(strings.Repeat("─", 54))
+	content.WriteString("\n\n")
+
+	submitBtn := renderDialogButton(n.styles, "Submit", n.focusedField == ncFieldSubmit)
+	cancelBtn := renderDialogButton(n.styles, "Cancel", n.focusedField == ncFieldCancel)
+	content.WriteString("  ")
+	content.WriteString(lipgloss.JoinHorizontal(lipgloss.Center, submitBtn, "  ", cancelBtn))
+	content.WriteString("\n\n")
+
+	if n.errMsg != "" {
+		content.WriteString(n.styles.Error.Render(n.errMsg))
+		content.WriteString("\n")
+	}
+	content.WriteString(helpStyle.Render("Tab/↑↓: Navigate  ←→: Choose  Ctrl+S: Submit  Esc: Cancel"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(n.styles.Colors.Highlight).
+		Padding(1, 2).
+		Width(60)
+
+	return boxStyle.Render(content.String())
+}
+
+// renderDialogButton renders a dialog button, highlighted when focused
+func renderDialogButton(s *styles.Styles, label string, focused bool) string {
+	if focused {
+		return lipgloss.NewStyle().
+			Bold(true).
+			Foreground(s.Colors.Highlight).
+			Render("[ " + label + " ]")
+	}
+	return lipgloss.NewStyle().
+		Foreground(s.Colors.Muted).
+		Render("  " + label + "  ")
+}
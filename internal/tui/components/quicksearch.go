@@ -120,7 +120,7 @@ func (q *QuickSearch) View() string {
 	boxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(q.styles.Header.GetBackground()).
-		Background(lipgloss.Color("248")).
+		Background(q.styles.Colors.Surface).
 		Padding(1, 3).
 		Width(40)
 
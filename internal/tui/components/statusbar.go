@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -23,18 +24,24 @@ type StatusBar struct {
 	loading    bool
 	loadingMsg string
 	spinner    spinner.Model
+	tokenExp   time.Time // Offline token expiry; zero if none
+	keys       *styles.KeyMap
 }
 
+// tokenWarnPeriod is how long before the offline token expires the status
+// bar starts warning
+const tokenWarnPeriod = 7 * 24 * time.Hour
+
 // NewStatusBar creates a new status bar component
-func NewStatusBar(s *styles.Styles) *StatusBar {
+func NewStatusBar(s *styles.Styles, keys *styles.KeyMap) *StatusBar {
 	sp := spinner.New()
 	sp.Spinner = spinner.Dot
-	sp.Style = lipgloss.NewStyle().Foreground(s.Warning.GetForeground())
 
 	return &StatusBar{
 		styles:    s,
 		connected: false,
 		spinner:   sp,
+		keys:      keys,
 	}
 }
 
@@ -48,6 +55,12 @@ func (s *StatusBar) SetConnected(connected bool) {
 	s.connected = connected
 }
 
+// SetTokenExpiry sets when the offline token expires so the status bar can
+// warn ahead of time
+func (s *StatusBar) SetTokenExpiry(t time.Time) {
+	s.tokenExp = t
+}
+
 // SetMessage sets a temporary message
 func (s *StatusBar) SetMessage(msg string, duration time.Duration) {
 	s.message = msg
@@ -94,27 +107,31 @@ func (s *StatusBar) Update(msg tea.Msg) (*StatusBar, tea.Cmd) {
 func (s *StatusBar) View() string {
 	var left, center, right string
 
-	// Left: Help shortcuts (updated for current keybindings)
+	// Left: Help shortcuts from the active key bindings
 	shortcuts := []struct {
-		key  string
-		desc string
+		binding key.Binding
+		desc    string
 	}{
-		{"s", "Sort"},
-		{"r", "Refresh"},
-		{"?", "Help"},
-		{"q", "Quit"},
+		{s.keys.Sort, "Sort"},
+		{s.keys.Refresh, "Refresh"},
+		{s.keys.Help, "Help"},
+		{s.keys.Quit, "Quit"},
 	}
 
 	var parts []string
 	for _, sc := range shortcuts {
+		if !sc.binding.Enabled() {
+			continue
+		}
 		parts = append(parts, fmt.Sprintf("%s %s",
-			s.styles.HelpKey.Render("["+sc.key+"]"),
+			s.styles.HelpKey.Render("["+sc.binding.Help().Key+"]"),
 			s.styles.HelpDesc.Render(sc.desc)))
 	}
 	left = strings.Join(parts, "  ")
 
 	// Center: Message or loading with spinner
 	if s.loading {
+		s.spinner.Style = lipgloss.NewStyle().Foreground(s.styles.Colors.Warning)
 		center = s.spinner.View() + " " + s.styles.Warning.Render(s.loadingMsg)
 	} else if s.message != "" {
 		center = s.message
@@ -129,6 +146,9 @@ func (s *StatusBar) View() string {
 	}
 	timeStr := time.Now().Format("15:04")
 	right = fmt.Sprintf("%s  %s", connStatus, s.styles.Muted.Render(timeStr))
+	if warning := s.tokenWarning(); warning != "" {
+		right = warning + "  " + right
+	}
 
 	// Calculate spacing
 	leftLen := len(stripAnsi(left))
@@ -164,6 +184,23 @@ func (s *StatusBar) View() string {
 		Render(content)
 }
 
+// tokenWarning describes an offline token that expires soon
+func (s *StatusBar) tokenWarning() string {
+	if s.tokenExp.IsZero() {
+		return ""
+	}
+	left := time.Until(s.tokenExp)
+	switch {
+	case left <= 0:
+		return s.styles.Error.Render("⚠ token expired")
+	case left < 24*time.Hour:
+		return s.styles.Warning.Render(fmt.Sprintf("⚠ token expires in %dh", int(left.Hours())+1))
+	case left < tokenWarnPeriod:
+		return s.styles.Warning.Render(fmt.Sprintf("⚠ token expires in %dd", int(left.Hours()/24)))
+	}
+	return ""
+}
+
 // stripAnsi removes ANSI escape codes for length calculation
 func stripAnsi(s string) string {
 	var result strings.Builder
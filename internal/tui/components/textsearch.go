@@ -193,7 +193,7 @@ func (t *TextSearch) View() string {
 	// Bar style
 	barStyle := lipgloss.NewStyle().
 		Border(lipgloss.NormalBorder(), true, false, false, false).
-		BorderForeground(lipgloss.Color("240")).
+		BorderForeground(t.styles.Colors.Border).
 		Width(t.width - 4).
 		Padding(0, 1)
 
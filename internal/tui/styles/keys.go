@@ -2,7 +2,13 @@
 // Copyright (C) 2026 Anthony Green <green@redhat.com>
 package styles
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
 
 // KeyMap defines all keyboard shortcuts
 type KeyMap struct {
@@ -20,7 +26,11 @@ type KeyMap struct {
 	ShiftTab    key.Binding
 	Search      key.Binding
 	Filter      key.Binding
+	ClearFilter key.Binding
+	SavePreset  key.Binding
+	Query       key.Binding
 	Sort        key.Binding
+	SortOrder   key.Binding
 	Refresh     key.Binding
 	Help        key.Binding
 	Quit        key.Binding
@@ -28,7 +38,19 @@ type KeyMap struct {
 	Open        key.Binding
 	Export      key.Binding
 	BulkExport  key.Binding
+	Bundle      key.Binding
 	TextSearch  key.Binding
+	NewCase     key.Binding
+	Comment     key.Binding
+	NextComment key.Binding
+	PrevComment key.Binding
+	Upload      key.Binding
+	CloseCase   key.Binding
+	BumpSev     key.Binding
+	UnreadOnly  key.Binding
+	Profile     key.Binding
+	Theme       key.Binding
+	Columns     key.Binding
 }
 
 // DefaultKeyMap returns the default key bindings
@@ -90,10 +112,26 @@ func DefaultKeyMap() *KeyMap {
 			key.WithKeys("f"),
 			key.WithHelp("f", "filter"),
 		),
+		ClearFilter: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "clear filter"),
+		),
+		SavePreset: key.NewBinding(
+			key.WithKeys("ctrl+s"),
+			key.WithHelp("ctrl+s", "save preset"),
+		),
+		Query: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "filter query"),
+		),
 		Sort: key.NewBinding(
 			key.WithKeys("s"),
 			key.WithHelp("s", "sort"),
 		),
+		SortOrder: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "sort order"),
+		),
 		Refresh: key.NewBinding(
 			key.WithKeys("r"),
 			key.WithHelp("r", "refresh"),
@@ -122,10 +160,58 @@ func DefaultKeyMap() *KeyMap {
 			key.WithKeys("E"),
 			key.WithHelp("E", "bulk export"),
 		),
+		Bundle: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "bundle export"),
+		),
 		TextSearch: key.NewBinding(
 			key.WithKeys("ctrl+f"),
 			key.WithHelp("ctrl+f", "find in case"),
 		),
+		NewCase: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "new case"),
+		),
+		Comment: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "add comment"),
+		),
+		NextComment: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next comment"),
+		),
+		PrevComment: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "prev comment"),
+		),
+		Upload: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "upload attachment"),
+		),
+		CloseCase: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", "close/reopen case"),
+		),
+		BumpSev: key.NewBinding(
+			key.WithKeys("+"),
+			key.WithHelp("+", "raise severity"),
+		),
+		UnreadOnly: key.NewBinding(
+			key.WithKeys("U"),
+			key.WithHelp("U", "unread only"),
+		),
+		Profile: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "switch profile"),
+		),
+		Theme: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "cycle theme"),
+		),
+		Columns: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "columns"),
+		),
 	}
 }
 
@@ -141,6 +227,163 @@ func (k *KeyMap) FullHelp() [][]key.Binding {
 		{k.PageUp, k.PageDown, k.Tab, k.ShiftTab},
 		{k.Select, k.Back, k.Search, k.Filter},
 		{k.Sort, k.Refresh, k.Copy, k.Open},
-		{k.Export, k.BulkExport, k.Help, k.Quit},
+		{k.Export, k.BulkExport, k.NewCase, k.Comment},
+		{k.Upload, k.CloseCase, k.BumpSev, k.UnreadOnly},
+		{k.Query, k.Profile, k.Theme, k.Columns},
+		{k.Help, k.Quit},
+	}
+}
+
+// bindings names each binding for config.yaml, in help order
+func (k *KeyMap) bindings() []struct {
+	name    string
+	binding *key.Binding
+} {
+	return []struct {
+		name    string
+		binding *key.Binding
+	}{
+		{"up", &k.Up},
+		{"down", &k.Down},
+		{"left", &k.Left},
+		{"right", &k.Right},
+		{"top", &k.Top},
+		{"bottom", &k.Bottom},
+		{"page_up", &k.PageUp},
+		{"page_down", &k.PageDown},
+		{"select", &k.Select},
+		{"back", &k.Back},
+		{"tab", &k.Tab},
+		{"shift_tab", &k.ShiftTab},
+		{"search", &k.Search},
+		{"filter", &k.Filter},
+		{"clear_filter", &k.ClearFilter},
+		{"save_preset", &k.SavePreset},
+		{"query", &k.Query},
+		{"sort", &k.Sort},
+		{"sort_order", &k.SortOrder},
+		{"refresh", &k.Refresh},
+		{"help", &k.Help},
+		{"quit", &k.Quit},
+		{"copy", &k.Copy},
+		{"open", &k.Open},
+		{"export", &k.Export},
+		{"bulk_export", &k.BulkExport},
+		{"bundle", &k.Bundle},
+		{"text_search", &k.TextSearch},
+		{"new_case", &k.NewCase},
+		{"comment", &k.Comment},
+		{"next_comment", &k.NextComment},
+		{"prev_comment", &k.PrevComment},
+		{"upload", &k.Upload},
+		{"close_case", &k.CloseCase},
+		{"bump_severity", &k.BumpSev},
+		{"unread_only", &k.UnreadOnly},
+		{"profile", &k.Profile},
+		{"theme", &k.Theme},
+		{"columns", &k.Columns},
+	}
+}
+
+// KeyInfo describes one binding of a KeyMap
+type KeyInfo struct {
+	Name string   // Name used in config.yaml
+	Keys []string // Empty when unbound
+	Desc string
+}
+
+// Keys lists the bindings with their names, in help order
+func (k *KeyMap) Keys() []KeyInfo {
+	var infos []KeyInfo
+	for _, b := range k.bindings() {
+		info := KeyInfo{Name: b.name, Desc: b.binding.Help().Desc}
+		if b.binding.Enabled() {
+			info.Keys = b.binding.Keys()
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// Label returns how a binding is shown in help, or "unbound"
+func Label(b key.Binding) string {
+	if !b.Enabled() {
+		return "unbound"
+	}
+	return b.Help().Key
+}
+
+// keyLabels are friendlier names for keys in help text
+var keyLabels = map[string]string{
+	"up":    "↑",
+	"down":  "↓",
+	"left":  "←",
+	"right": "→",
+}
+
+// reservedKeys are handled outside the KeyMap
+var reservedKeys = map[string]string{
+	"0": "presets", "1": "presets", "2": "presets", "3": "presets", "4": "presets",
+	"5": "presets", "6": "presets", "7": "presets", "8": "presets", "9": "presets",
+}
+
+// LoadKeyMap returns the default key bindings with overrides applied. An
+// override maps a binding name to its new keys; an empty list unbinds it.
+// Unknown names and keys bound to more than one action are errors.
+func LoadKeyMap(overrides map[string][]string) (*KeyMap, error) {
+	k := DefaultKeyMap()
+	byName := make(map[string]*key.Binding)
+	var names []string
+	for _, b := range k.bindings() {
+		byName[b.name] = b.binding
+		names = append(names, b.name)
+	}
+
+	overridden := make([]string, 0, len(overrides))
+	for name := range overrides {
+		overridden = append(overridden, name)
+	}
+	sort.Strings(overridden)
+
+	for _, name := range overridden {
+		b, ok := byName[strings.ReplaceAll(strings.ToLower(name), "-", "_")]
+		if !ok {
+			return nil, fmt.Errorf("unknown key binding %q (valid: %s)", name, strings.Join(names, ", "))
+		}
+		keys := overrides[name]
+		if len(keys) == 0 {
+			b.SetEnabled(false)
+			continue
+		}
+		labels := make([]string, len(keys))
+		for i, kk := range keys {
+			if strings.TrimSpace(kk) == "" {
+				return nil, fmt.Errorf("key binding %q: empty key", name)
+			}
+			if use, ok := reservedKeys[kk]; ok {
+				return nil, fmt.Errorf("key binding %q: %q is reserved for %s", name, kk, use)
+			}
+			labels[i] = kk
+			if l, ok := keyLabels[kk]; ok {
+				labels[i] = l
+			}
+		}
+		b.SetKeys(keys...)
+		b.SetHelp(strings.Join(labels, "/"), b.Help().Desc)
+	}
+
+	// Every key must do one thing
+	owner := make(map[string]string)
+	for _, b := range k.bindings() {
+		if !b.binding.Enabled() {
+			continue
+		}
+		for _, kk := range b.binding.Keys() {
+			if other, ok := owner[kk]; ok {
+				return nil, fmt.Errorf("key %q is bound to both %s and %s", kk, other, b.name)
+			}
+			owner[kk] = b.name
+		}
 	}
+	return k, nil
 }
@@ -17,6 +17,8 @@ type ColorScheme struct {
 	Foreground  lipgloss.Color
 	Border      lipgloss.Color
 	Highlight   lipgloss.Color
+	Contrast    lipgloss.Color // Text on Primary and Highlight backgrounds
+	Surface     lipgloss.Color // Dialog background
 	Severity1   lipgloss.Color // Critical
 	Severity2   lipgloss.Color // High
 	Severity3   lipgloss.Color // Normal
@@ -37,6 +39,8 @@ func DarkColors() ColorScheme {
 		Foreground: lipgloss.Color("#F9FAFB"), // Light gray
 		Border:     lipgloss.Color("#4B5563"), // Medium gray
 		Highlight:  lipgloss.Color("#3B82F6"), // Blue
+		Contrast:   lipgloss.Color("#FFFFFF"), // White
+		Surface:    lipgloss.Color("235"),     // Near black
 		Severity1:  lipgloss.Color("#EF4444"), // Red
 		Severity2:  lipgloss.Color("#F59E0B"), // Amber
 		Severity3:  lipgloss.Color("#3B82F6"), // Blue
@@ -58,6 +62,8 @@ func LightColors() ColorScheme {
 		Foreground: lipgloss.Color("#111827"), // Near black
 		Border:     lipgloss.Color("#D1D5DB"), // Light gray
 		Highlight:  lipgloss.Color("#2563EB"), // Blue (darker)
+		Contrast:   lipgloss.Color("#FFFFFF"), // White
+		Surface:    lipgloss.Color("#F3F4F6"), // Off white
 		Severity1:  lipgloss.Color("#DC2626"), // Red
 		Severity2:  lipgloss.Color("#D97706"), // Amber
 		Severity3:  lipgloss.Color("#2563EB"), // Blue
@@ -86,6 +92,7 @@ var (
 
 // Styles contains all the application styles
 type Styles struct {
+	Colors        ColorScheme // Palette the styles were built from
 	App           lipgloss.Style
 	Header        lipgloss.Style
 	Footer        lipgloss.Style
@@ -142,11 +149,13 @@ func LightStyles() *Styles {
 // NewStyles creates styles from a color scheme
 func NewStyles(c ColorScheme) *Styles {
 	return &Styles{
+		Colors: c,
+
 		App: lipgloss.NewStyle(),
 		// Don't set background - let terminal handle it
 
 		Header: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
+			Foreground(c.Contrast).
 			Background(c.Primary).
 			Bold(true).
 			Padding(0, 1),
@@ -182,7 +191,7 @@ func NewStyles(c ColorScheme) *Styles {
 			Foreground(c.Muted),
 
 		Selected: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
+			Foreground(c.Contrast).
 			Background(c.Highlight).
 			Bold(true),
 
@@ -217,7 +226,7 @@ func NewStyles(c ColorScheme) *Styles {
 			Foreground(c.Foreground),
 
 		ListItemSelected: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
+			Foreground(c.Contrast).
 			Background(c.Highlight).
 			Bold(true),
 
@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package styles
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Built-in theme names. ThemeAuto picks dark or light from the terminal
+// background.
+const (
+	ThemeAuto         = "auto"
+	ThemeDark         = "dark"
+	ThemeLight        = "light"
+	ThemeHighContrast = "high-contrast"
+)
+
+// BuiltinThemes lists the built-in themes in the order they are cycled
+func BuiltinThemes() []string {
+	return []string{ThemeAuto, ThemeDark, ThemeLight, ThemeHighContrast}
+}
+
+// HighContrastColors returns a high-contrast scheme for dark terminals.
+// Severity and status colors come from the Okabe-Ito palette so they stay
+// distinct with red-green color blindness.
+func HighContrastColors() ColorScheme {
+	return ColorScheme{
+		Primary:    lipgloss.Color("#0072B2"), // Blue
+		Secondary:  lipgloss.Color("#56B4E9"), // Sky blue
+		Accent:     lipgloss.Color("#F0E442"), // Yellow
+		Success:    lipgloss.Color("#56B4E9"), // Sky blue
+		Warning:    lipgloss.Color("#E69F00"), // Orange
+		Error:      lipgloss.Color("#FF8C42"), // Light vermillion
+		Muted:      lipgloss.Color("#D0D0D0"), // Light gray
+		Background: lipgloss.Color("#000000"), // Black
+		Foreground: lipgloss.Color("#FFFFFF"), // White
+		Border:     lipgloss.Color("#767676"), // Gray
+		Highlight:  lipgloss.Color("#0072B2"), // Blue
+		Contrast:   lipgloss.Color("#FFFFFF"), // White
+		Surface:    lipgloss.Color("#000000"), // Black
+		Severity1:  lipgloss.Color("#F0E442"), // Yellow
+		Severity2:  lipgloss.Color("#E69F00"), // Orange
+		Severity3:  lipgloss.Color("#56B4E9"), // Sky blue
+		Severity4:  lipgloss.Color("#D0D0D0"), // Light gray
+	}
+}
+
+// DetectTheme returns the built-in theme matching the terminal background
+func DetectTheme() string {
+	if lipgloss.HasDarkBackground() {
+		return ThemeDark
+	}
+	return ThemeLight
+}
+
+// BuiltinColors returns the scheme of a built-in theme
+func BuiltinColors(name string) (ColorScheme, bool) {
+	switch strings.ToLower(name) {
+	case "", ThemeAuto:
+		return BuiltinColors(DetectTheme())
+	case ThemeDark:
+		return DarkColors(), true
+	case ThemeLight:
+		return LightColors(), true
+	case ThemeHighContrast, "high_contrast", "colorblind":
+		return HighContrastColors(), true
+	}
+	return ColorScheme{}, false
+}
+
+// colorPattern accepts #RGB, #RRGGBB and ANSI 256-color numbers
+var colorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// colorFields maps palette keys to scheme fields
+func (c *ColorScheme) colorFields() map[string]*lipgloss.Color {
+	return map[string]*lipgloss.Color{
+		"primary":    &c.Primary,
+		"secondary":  &c.Secondary,
+		"accent":     &c.Accent,
+		"success":    &c.Success,
+		"warning":    &c.Warning,
+		"error":      &c.Error,
+		"muted":      &c.Muted,
+		"background": &c.Background,
+		"foreground": &c.Foreground,
+		"border":     &c.Border,
+		"highlight":  &c.Highlight,
+		"contrast":   &c.Contrast,
+		"surface":    &c.Surface,
+		"severity1":  &c.Severity1,
+		"severity2":  &c.Severity2,
+		"severity3":  &c.Severity3,
+		"severity4":  &c.Severity4,
+	}
+}
+
+// ColorNames returns the palette keys a user theme may set
+func ColorNames() []string {
+	var c ColorScheme
+	names := make([]string, 0, 17)
+	for name := range c.colorFields() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Set changes one color, e.g. Set("severity1", "#FF00FF") or
+// Set("border", "240")
+func (c *ColorScheme) Set(name, value string) error {
+	field, ok := c.colorFields()[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("unknown color %q (valid: %s)", name, strings.Join(ColorNames(), ", "))
+	}
+	value = strings.TrimSpace(value)
+	if n, err := strconv.Atoi(value); err == nil {
+		if n < 0 || n > 255 {
+			return fmt.Errorf("color %s: ANSI color %d out of range 0-255", name, n)
+		}
+	} else if !colorPattern.MatchString(value) {
+		return fmt.Errorf("color %s: %q is not #RRGGBB, #RGB or 0-255", name, value)
+	}
+	*field = lipgloss.Color(value)
+	return nil
+}
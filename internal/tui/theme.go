@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/green/agcm/internal/config"
+	"github.com/green/agcm/internal/tui/styles"
+)
+
+// LoadTheme builds the styles for a built-in or user-defined theme
+func LoadTheme(name string, themes map[string]config.ThemeConfig) (*styles.Styles, error) {
+	if colors, ok := styles.BuiltinColors(name); ok {
+		return styles.NewStyles(colors), nil
+	}
+	theme, ok := themes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(themeNames(themes), ", "))
+	}
+	colors, ok := styles.BuiltinColors(theme.Base)
+	if !ok {
+		return nil, fmt.Errorf("theme %q: unknown base theme %q (available: %s)", name, theme.Base, strings.Join(styles.BuiltinThemes(), ", "))
+	}
+	keys := make([]string, 0, len(theme.Colors))
+	for key := range theme.Colors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := colors.Set(key, theme.Colors[key]); err != nil {
+			return nil, fmt.Errorf("theme %q: %w", name, err)
+		}
+	}
+	return styles.NewStyles(colors), nil
+}
+
+// themeNames lists the built-in themes followed by the user's
+func themeNames(themes map[string]config.ThemeConfig) []string {
+	var user []string
+	for name := range themes {
+		if _, builtin := styles.BuiltinColors(name); !builtin {
+			user = append(user, name)
+		}
+	}
+	sort.Strings(user)
+	return append(styles.BuiltinThemes(), user...)
+}
+
+// themes returns the user-defined themes, if there is a config
+func (m *Model) themes() map[string]config.ThemeConfig {
+	if m.configMgr == nil {
+		return nil
+	}
+	return m.configMgr.GetThemes()
+}
+
+// setTheme restyles the UI in place, so every component picks it up
+func (m *Model) setTheme(name string) error {
+	s, err := LoadTheme(name, m.themes())
+	if err != nil {
+		return err
+	}
+	*m.styles = *s
+	m.theme = name
+	return nil
+}
+
+// cycleTheme switches to the next theme and saves the choice
+func (m *Model) cycleTheme() tea.Cmd {
+	names := themeNames(m.themes())
+	next := names[(slices.Index(names, m.theme)+1)%len(names)]
+	if err := m.setTheme(next); err != nil {
+		m.statusBar.SetMessage(m.styles.Error.Render("Theme: "+err.Error()), 5*time.Second)
+		return nil
+	}
+
+	label := next
+	if next == styles.ThemeAuto {
+		label += " (" + styles.DetectTheme() + ")"
+	}
+	if m.configMgr != nil {
+		m.configMgr.SetTheme(next)
+		if err := m.configMgr.Save(); err != nil {
+			m.statusBar.SetMessage(m.styles.Warning.Render(fmt.Sprintf("Theme: %s (not saved: %v)", label, err)), 3*time.Second)
+			return nil
+		}
+	}
+	m.statusBar.SetMessage(m.styles.Success.Render("Theme: "+label), 2*time.Second)
+	return nil
+}
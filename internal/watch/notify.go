@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package watch
+
+import (
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Notifier sends desktop notifications over the freedesktop D-Bus interface
+type Notifier struct {
+	conn *dbus.Conn
+}
+
+// NewNotifier connects to the session bus notification service
+func NewNotifier() (*Notifier, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to session bus: %w", err)
+	}
+	return &Notifier{conn: conn}, nil
+}
+
+// maxNotifyBody is the most characters of a comment shown in a notification
+const maxNotifyBody = 200
+
+// Notify shows a desktop notification for an event
+func (n *Notifier) Notify(e Event) error {
+	body := notifyBody(e)
+
+	obj := n.conn.Object("org.freedesktop.Notifications", "/org/freedesktop/Notifications")
+	call := obj.Call("org.freedesktop.Notifications.Notify", 0,
+		"agcm",                    // app name
+		uint32(0),                 // replaces id
+		"",                        // icon
+		e.Title(),                 // summary
+		body,                      // body
+		[]string{},                // actions
+		map[string]dbus.Variant{}, // hints
+		int32(-1),                 // default timeout
+	)
+	if call.Err != nil {
+		return fmt.Errorf("failed to send notification: %w", call.Err)
+	}
+	return nil
+}
+
+// notifyBody returns the text of an event's notification. Comments are cut
+// at a rune boundary, as notification daemons reject invalid UTF-8.
+func notifyBody(e Event) string {
+	if e.Type != EventCommentAdded || e.Text == "" {
+		return e.Summary
+	}
+	if runes := []rune(e.Text); len(runes) > maxNotifyBody {
+		return string(runes[:maxNotifyBody]) + "…"
+	}
+	return e.Text
+}
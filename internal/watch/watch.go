@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package watch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/green/agcm/internal/api"
+)
+
+const (
+	DefaultInterval    = 5 * time.Minute
+	DefaultMaxInterval = 30 * time.Minute
+	pollPageSize       = 100
+)
+
+// EventType identifies what changed on a case
+type EventType string
+
+const (
+	EventCaseOpened      EventType = "case_opened"
+	EventStatusChanged   EventType = "status_changed"
+	EventSeverityChanged EventType = "severity_changed"
+	EventCommentAdded    EventType = "comment_added"
+)
+
+// Event describes a single change observed between polls
+type Event struct {
+	Type       EventType `json:"type"`
+	CaseNumber string    `json:"case_number"`
+	Summary    string    `json:"summary"`
+	Old        string    `json:"old,omitempty"`
+	New        string    `json:"new,omitempty"`
+	Author     string    `json:"author,omitempty"`
+	Text       string    `json:"text,omitempty"`
+	Time       time.Time `json:"time"`
+}
+
+// Title returns a short human-readable description of the event
+func (e Event) Title() string {
+	switch e.Type {
+	case EventCaseOpened:
+		return fmt.Sprintf("New case %s", e.CaseNumber)
+	case EventStatusChanged:
+		return fmt.Sprintf("Case %s: %s → %s", e.CaseNumber, e.Old, e.New)
+	case EventSeverityChanged:
+		return fmt.Sprintf("Case %s severity: %s → %s", e.CaseNumber, e.Old, e.New)
+	case EventCommentAdded:
+		return fmt.Sprintf("Case %s: comment from %s", e.CaseNumber, e.Author)
+	}
+	return fmt.Sprintf("Case %s updated", e.CaseNumber)
+}
+
+// caseState is the part of a case compared between polls
+type caseState struct {
+	Status       string
+	Severity     string
+	LastModified time.Time
+}
+
+// Watcher polls for case changes and reports them as events
+type Watcher struct {
+	client      *api.Client
+	filter      api.CaseFilter
+	Interval    time.Duration
+	MaxInterval time.Duration
+
+	snapshot map[string]caseState
+	started  time.Time
+	primed   bool
+}
+
+// New creates a watcher for cases matching filter
+func New(client *api.Client, filter *api.CaseFilter) *Watcher {
+	w := &Watcher{
+		client:      client,
+		Interval:    DefaultInterval,
+		MaxInterval: DefaultMaxInterval,
+		snapshot:    make(map[string]caseState),
+	}
+	if filter != nil {
+		w.filter = *filter
+	}
+	// Closed cases must stay visible so closing a case is reported
+	w.filter.IncludeClosed = true
+	w.filter.Count = pollPageSize
+	w.filter.StartIndex = 0
+	return w
+}
+
+// Poll lists cases once and returns the changes since the previous poll.
+// The first poll only records a baseline and returns no events.
+func (w *Watcher) Poll(ctx context.Context) ([]Event, error) {
+	result, err := w.client.ListCases(ctx, &w.filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if !w.primed {
+		w.started = time.Now()
+	}
+
+	var events []Event
+	now := time.Now()
+	for _, c := range result.Items {
+		cur := caseState{Status: c.Status, Severity: c.Severity, LastModified: c.LastModified}
+		prev, known := w.snapshot[c.CaseNumber]
+		if !w.primed || !known {
+			w.snapshot[c.CaseNumber] = cur
+		}
+		if !w.primed {
+			continue
+		}
+
+		if !known {
+			// Older cases can enter the first page when modified; only
+			// cases created after the watch started count as new
+			if c.CreatedDate.After(w.started) {
+				events = append(events, Event{Type: EventCaseOpened, CaseNumber: c.CaseNumber,
+					Summary: c.Summary, New: c.Severity, Time: now})
+			}
+			continue
+		}
+
+		if prev.Status != cur.Status {
+			events = append(events, Event{Type: EventStatusChanged, CaseNumber: c.CaseNumber,
+				Summary: c.Summary, Old: prev.Status, New: cur.Status, Time: now})
+		}
+		if prev.Severity != cur.Severity {
+			events = append(events, Event{Type: EventSeverityChanged, CaseNumber: c.CaseNumber,
+				Summary: c.Summary, Old: prev.Severity, New: cur.Severity, Time: now})
+		}
+		if cur.LastModified.After(prev.LastModified) {
+			comments, err := w.client.GetCaseComments(ctx, c.CaseNumber)
+			if err != nil {
+				// Keep the old modification time so the next poll looks for
+				// these comments again; the status events are already out
+				w.snapshot[c.CaseNumber] = caseState{Status: cur.Status, Severity: cur.Severity, LastModified: prev.LastModified}
+				return events, fmt.Errorf("failed to get comments for case %s: %w", c.CaseNumber, err)
+			}
+			for _, cm := range comments {
+				if cm.CreatedDate.After(prev.LastModified) {
+					events = append(events, Event{Type: EventCommentAdded, CaseNumber: c.CaseNumber,
+						Summary: c.Summary, Author: cm.Author, Text: cm.GetText(), Time: cm.CreatedDate})
+				}
+			}
+		}
+		w.snapshot[c.CaseNumber] = cur
+	}
+
+	w.primed = true
+	return events, nil
+}
+
+// Run polls until ctx is cancelled, calling handle for every event.
+// Failed polls back off exponentially up to MaxInterval; onError (if set)
+// is told about each failure and the delay before the next attempt.
+func (w *Watcher) Run(ctx context.Context, handle func(Event), onError func(error, time.Duration)) error {
+	delay := w.Interval
+	for {
+		events, err := w.Poll(ctx)
+		for _, e := range events {
+			handle(e)
+		}
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			delay = min(delay*2, w.MaxInterval)
+			if onError != nil {
+				onError(err, delay)
+			}
+		} else {
+			delay = w.Interval
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
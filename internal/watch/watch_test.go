@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+// Copyright (C) 2026 Anthony Green <green@redhat.com>
+package watch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/green/agcm/internal/api"
+	"github.com/green/agcm/internal/apitest"
+)
+
+func TestPollRetriesCommentsAfterFailure(t *testing.T) {
+	fx := apitest.DefaultFixtures(time.Now())
+	srv := apitest.NewServer(fx)
+	defer srv.Close()
+
+	// Fail comment listings while failComments is set
+	var failComments atomic.Bool
+	portal := srv.Handler()
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failComments.Load() && r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/comments") {
+			http.Error(w, "unavailable", http.StatusInternalServerError)
+			return
+		}
+		portal.ServeHTTP(w, r)
+	}))
+	defer proxy.Close()
+
+	client := srv.NewClient(
+		api.WithBaseURL(proxy.URL),
+		api.WithRetryPolicy(api.RetryPolicy{}),
+		api.WithRateLimit(0, 0),
+	)
+	ctx := context.Background()
+	w := New(client, nil)
+	if _, err := w.Poll(ctx); err != nil {
+		t.Fatalf("baseline Poll: %v", err)
+	}
+
+	caseNumber := fx.Cases[0].CaseNumber
+	if _, err := client.AddComment(ctx, caseNumber, "Any update?"); err != nil {
+		t.Fatal(err)
+	}
+
+	failComments.Store(true)
+	if _, err := w.Poll(ctx); err == nil {
+		t.Fatal("Poll succeeded while comments were failing")
+	}
+
+	failComments.Store(false)
+	events, err := w.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found bool
+	for _, e := range events {
+		if e.Type == EventCommentAdded && e.CaseNumber == caseNumber && e.Text == "Any update?" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("comment not reported after the failed poll; events: %+v", events)
+	}
+}
+
+func TestNotifyBody(t *testing.T) {
+	long := strings.Repeat("é", maxNotifyBody+1)
+	tests := []struct {
+		event Event
+		want  string
+	}{
+		{Event{Type: EventStatusChanged, Summary: "Kernel panic"}, "Kernel panic"},
+		{Event{Type: EventCommentAdded, Summary: "Kernel panic"}, "Kernel panic"},
+		{Event{Type: EventCommentAdded, Text: "Any update?"}, "Any update?"},
+		{Event{Type: EventCommentAdded, Text: long[:2*maxNotifyBody]}, long[:2*maxNotifyBody]},
+		{Event{Type: EventCommentAdded, Text: long}, long[:2*maxNotifyBody] + "…"},
+		{Event{Type: EventCommentAdded, Text: "a" + long}, "a" + long[:2*(maxNotifyBody-1)] + "…"},
+	}
+	for _, tt := range tests {
+		got := notifyBody(tt.event)
+		if got != tt.want {
+			t.Errorf("notifyBody(%q) = %q, want %q", tt.event.Text, got, tt.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("notifyBody(%q) is not valid UTF-8", tt.event.Text)
+		}
+	}
+}